@@ -2,23 +2,34 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"practice_vgpek/internal/config"
 	"practice_vgpek/internal/dao"
 	"practice_vgpek/internal/handler"
 	"practice_vgpek/internal/service"
+	"practice_vgpek/internal/service/health"
+	"practice_vgpek/internal/service/link"
+	"practice_vgpek/internal/service/token"
+	"practice_vgpek/internal/service/upload"
+	"practice_vgpek/internal/storage"
+	"practice_vgpek/pkg/antivirus"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/converter"
 	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
 	"practice_vgpek/pkg/postgres"
+	"practice_vgpek/pkg/tracing"
 	"syscall"
-	"time"
 )
 
 // @title						ВГПЭК API
@@ -32,45 +43,127 @@ import (
 func main() {
 	mainCtx, cancel := context.WithCancel(context.Background())
 
-	err := mustLoadConfig()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		panic(err)
-	}
-
-	logCfg := logger.Config{
-		Level:         "debug",
-		HasCaller:     true,
-		HasStacktrace: true,
-		Encoding:      "json",
+		log.Fatal(err)
 	}
 
-	logging, _ := logger.New(logCfg)
+	logging, _ := logger.New(logger.Config{
+		Level:         cfg.Log.Level,
+		HasCaller:     cfg.Log.HasCaller,
+		HasStacktrace: cfg.Log.HasStacktrace,
+		Encoding:      cfg.Log.Encoding,
+		PIIFields:     cfg.Log.PIIFields,
+	})
 	defer logging.Sync()
 
+	shutdownTracing, err := tracing.New(mainCtx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		logging.Fatal("ошибка настройки трассировки", zap.Error(err))
+	}
+
 	db, err := postgres.NewPostgresPool(postgres.Config{
-		Host:     viper.GetString("db.host"),
-		Port:     viper.GetString("db.port"),
-		Username: viper.GetString("db.username"),
-		Password: viper.GetString("db.password"),
-		DBName:   viper.GetString("db.dbname"),
-		SSLMode:  viper.GetString("db.sslmode"),
+		Host:     cfg.DB.Host,
+		Port:     cfg.DB.Port,
+		Username: cfg.DB.Username,
+		Password: cfg.DB.Password,
+		DBName:   cfg.DB.DBName,
+		SSLMode:  cfg.DB.SSLMode,
 	})
 	if err != nil {
 		logging.Fatal("error connect to db", zap.Error(err))
 	}
 
+	err = metrics.RegisterPool(db)
+	if err != nil {
+		logging.Fatal("ошибка регистрации метрик пула соединений", zap.Error(err))
+	}
+
+	// Без применимых миграций приложение не запускается, чтобы не работать со старой схемой базы.
+	// migrations.allow_failure позволяет запуститься, тогда /readyz сообщит о неготовности
 	err = migrateDB(db)
 	if err != nil {
-		logging.Error("ошибка миграции", zap.Error(err))
+		if !cfg.Migrations.AllowFailure {
+			logging.Fatal("ошибка миграции", zap.Error(err))
+		}
+
+		logging.Error("ошибка миграции, запуск продолжен по настройке migrations.allow_failure", zap.Error(err))
+	}
+
+	migrationVersion, err := lastMigrationVersion()
+	if err != nil {
+		logging.Fatal("ошибка чтения каталога миграций", zap.Error(err))
+	}
+
+	err = storage.MakeDirs(cfg.Storage.Root)
+	if err != nil {
+		logging.Fatal("ошибка создания каталогов файлов", zap.Error(err))
+	}
+
+	uploadCfg := newUploadConfig(cfg)
+	storageCfg := storage.Config{
+		Root:    cfg.Storage.Root,
+		Preview: newPreviewConfig(cfg.Preview),
+	}
+	healthCfg := health.Config{
+		MigrationVersion: migrationVersion,
+		StorageDirs: []string{
+			filepath.Join(cfg.Storage.Root, storage.IssuedRoot),
+			filepath.Join(cfg.Storage.Root, storage.SolvedRoot),
+			uploadCfg.Root,
+		},
+	}
+	tokenCfg := token.Config{
+		SigningKey: cfg.Auth.JWTKey,
+		TTL:        cfg.Auth.TokenTTL,
 	}
 
 	dao := dao.New(db, logging)
-	services := service.New(dao, logging)
-	handlers := handler.New(services, logging)
+	services := service.New(dao, newScanner(cfg.Scanner), storageCfg, uploadCfg, newLinkConfig(cfg.Download, logging), tokenCfg, healthCfg, logging)
+	handlers := handler.New(services, apiutils.Timeouts{
+		Request: cfg.Handler.Timeout,
+		Long:    cfg.Handler.LongTimeout,
+		File:    cfg.Handler.FileTimeout,
+	}, logging)
+
+	// Фоновое сравнение сданных работ и удаление истекших загрузок и ссылок останавливаются вместе с приложением
+	go services.PlagiarismService.Run(mainCtx)
+	go services.UploadService.Run(mainCtx)
+	go services.LinkService.Run(mainCtx)
 
 	httpServer := &http.Server{
-		Addr:    ":8080",
-		Handler: handlers.Init(),
+		Addr:              cfg.Server.Addr(),
+		Handler:           handlers.Init(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	// Метрики отдаются на отдельном порту, недоступном снаружи, а не на основном роутере
+	var metricsServer *http.Server
+
+	if cfg.Server.MetricsPort != "" {
+		metricsServer = &http.Server{
+			Addr:              cfg.Server.MetricsAddr(),
+			Handler:           metrics.Handler(),
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		}
+
+		go func() {
+			logging.Info("метрики доступны", zap.String("адрес", metricsServer.Addr))
+
+			err := metricsServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logging.Error("ошибка сервера метрик", zap.Error(err))
+			}
+		}()
 	}
 
 	c := make(chan os.Signal, 1)
@@ -79,7 +172,8 @@ func main() {
 	go func() {
 		<-c
 
-		shutdownCtx, _ := context.WithTimeout(mainCtx, 30*time.Second)
+		shutdownCtx, shutdownCancel := context.WithTimeout(mainCtx, cfg.Server.ShutdownTimeout)
+		defer shutdownCancel()
 
 		go func() {
 			<-shutdownCtx.Done()
@@ -93,9 +187,21 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
+
+		if metricsServer != nil {
+			_ = metricsServer.Shutdown(shutdownCtx)
+		}
+
+		// Спаны, накопленные к остановке, отправляются до отмены основного контекста
+		err = shutdownTracing(shutdownCtx)
+		if err != nil {
+			logging.Error("ошибка остановки трассировки", zap.Error(err))
+		}
 		cancel()
 	}()
 
+	logging.Info("сервер запущен", zap.String("адрес", httpServer.Addr))
+
 	err = httpServer.ListenAndServe()
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal(err)
@@ -105,22 +211,90 @@ func main() {
 
 }
 
-func mustLoadConfig() error {
-	viper.AddConfigPath("configs")
-	viper.SetConfigName("local")
+// newScanner создает антивирусный сканер по настройкам. Без настроек файлы не проверяются
+func newScanner(cfg config.Scanner) antivirus.Scanner {
+	if cfg.Driver == "clamd" {
+		return antivirus.NewClamd(cfg.Network, cfg.Address, cfg.Timeout)
+	}
+
+	return antivirus.Noop{}
+}
 
-	return viper.ReadInConfig()
+// newPreviewConfig возвращает настройки предпросмотра документов. Без настроек предпросмотр недоступен
+func newPreviewConfig(cfg config.Preview) storage.PreviewConfig {
+	preview := storage.PreviewConfig{
+		Converter:      converter.Noop{},
+		ThumbnailWidth: cfg.ThumbnailWidth,
+	}
+
+	if cfg.Driver == "libreoffice" {
+		preview.Converter = converter.NewLibreOffice(cfg.Binary, cfg.Timeout, cfg.Workers)
+	}
+
+	return preview
+}
+
+// newUploadConfig возвращает настройки загрузки файлов. Относительный каталог загрузок
+// располагается в каталоге файлов storage.root
+func newUploadConfig(cfg config.Config) upload.Config {
+	root := cfg.Upload.Root
+	if !filepath.IsAbs(root) {
+		root = filepath.Join(cfg.Storage.Root, root)
+	}
+
+	return upload.Config{
+		MaxSize:          int64(cfg.Upload.MaxSize),
+		ResumableMaxSize: int64(cfg.Upload.ResumableMaxSize),
+		Root:             root,
+		TTL:              cfg.Upload.TTL,
+	}
 }
 
+// newLinkConfig возвращает настройки подписанных ссылок на скачивание. Без ключа в настройках он создается
+// при запуске, и выданные ранее ссылки перестают действовать после перезапуска
+func newLinkConfig(cfg config.Download, logging *zap.Logger) link.Config {
+	linkCfg := link.Config{
+		Secret: []byte(cfg.LinkSecret),
+		TTL:    cfg.LinkTTL,
+	}
+
+	if len(linkCfg.Secret) == 0 {
+		logging.Warn("не задан ключ подписи ссылок, используется случайный ключ")
+
+		linkCfg.Secret = make([]byte, 32)
+		_, _ = rand.Read(linkCfg.Secret)
+	}
+
+	return linkCfg
+}
+
+const migrationsDir = "migrations"
+
 func migrateDB(pool *pgxpool.Pool) error {
 	if err := goose.SetDialect("postgres"); err != nil {
 		return err
 	}
 
 	db := stdlib.OpenDBFromPool(pool)
-	if err := goose.Up(db, "migrations"); err != nil {
+	if err := goose.Up(db, migrationsDir); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// lastMigrationVersion возвращает версию последней миграции из каталога, до которой migrateDB
+// обновляет базу данных
+func lastMigrationVersion() (int64, error) {
+	migrations, err := goose.CollectMigrations(migrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+
+	return last.Version, nil
+}
@@ -1,34 +1,28 @@
 package main
 
 import (
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"log"
+	"os"
+	"practice_vgpek/internal/config"
 	"practice_vgpek/pkg/postgres"
 )
 
 const baseApiUrl = "127.0.0.1:8080"
 
-func mustLoadConfig() error {
-	viper.AddConfigPath("configs")
-	viper.SetConfigName("local")
-
-	return viper.ReadInConfig()
-}
-
 func main() {
-	err := mustLoadConfig()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatalf(err.Error())
+		log.Fatal(err)
 	}
 
 	db, err := postgres.NewPostgresPool(postgres.Config{
-		Host:     viper.GetString("db.host"),
-		Port:     viper.GetString("db.port"),
-		Username: viper.GetString("db.username"),
-		Password: viper.GetString("db.password"),
-		DBName:   viper.GetString("db.dbname"),
-		SSLMode:  viper.GetString("db.sslmode"),
+		Host:     cfg.DB.Host,
+		Port:     cfg.DB.Port,
+		Username: cfg.DB.Username,
+		Password: cfg.DB.Password,
+		DBName:   cfg.DB.DBName,
+		SSLMode:  cfg.DB.SSLMode,
 	})
 	if err != nil {
 		log.Fatal("error connect to db", zap.Error(err))
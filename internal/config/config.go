@@ -0,0 +1,145 @@
+package config
+
+import (
+	"practice_vgpek/pkg/sizeutils"
+	"time"
+)
+
+// Config настройки приложения. Значения берутся по возрастанию приоритета из значений по умолчанию,
+// файла конфигурации, переменных окружения PRACTICE_* и флагов командной строки
+type Config struct {
+	Server     Server     `mapstructure:"server"`
+	Handler    Handler    `mapstructure:"handler"`
+	DB         DB         `mapstructure:"db"`
+	Migrations Migrations `mapstructure:"migrations"`
+	Log        Log        `mapstructure:"log"`
+	Auth       Auth       `mapstructure:"auth"`
+	Storage    Storage    `mapstructure:"storage"`
+	Upload     Upload     `mapstructure:"upload"`
+	Scanner    Scanner    `mapstructure:"scanner"`
+	Preview    Preview    `mapstructure:"preview"`
+	Download   Download   `mapstructure:"download"`
+	Tracing    Tracing    `mapstructure:"tracing"`
+}
+
+type Server struct {
+	Port string `mapstructure:"port"`
+	// MetricsPort порт для /metrics, отдельный от основного, чтобы метрики не были доступны снаружи.
+	// Пустое значение отключает метрики
+	MetricsPort string `mapstructure:"metrics_port"`
+
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	// ReadTimeout и WriteTimeout ограничивают весь запрос и весь ответ, включая загрузку
+	// и скачивание файлов, поэтому по умолчанию не заданы
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+	// ShutdownTimeout время на завершение текущих запросов при остановке
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// Addr адрес, на котором сервер принимает запросы
+func (s Server) Addr() string {
+	return ":" + s.Port
+}
+
+// MetricsAddr адрес, на котором отдаются метрики
+func (s Server) MetricsAddr() string {
+	return ":" + s.MetricsPort
+}
+
+// Handler ограничения времени обработки запроса, после которых запросы к базе и хранилищу отменяются
+type Handler struct {
+	// Timeout обычные запросы
+	Timeout time.Duration `mapstructure:"timeout"`
+	// LongTimeout запросы, обрабатывающие много записей: клонирование, импорт оценок, перевод групп
+	LongTimeout time.Duration `mapstructure:"long_timeout"`
+	// FileTimeout загрузка и выдача файлов, предпросмотр и архивы работ
+	FileTimeout time.Duration `mapstructure:"file_timeout"`
+}
+
+type DB struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	DBName   string `mapstructure:"dbname"`
+	SSLMode  string `mapstructure:"sslmode"`
+}
+
+type Migrations struct {
+	// AllowFailure запускаться, даже если миграции не применились
+	AllowFailure bool `mapstructure:"allow_failure"`
+}
+
+type Log struct {
+	Level         string `mapstructure:"level"`
+	Encoding      string `mapstructure:"encoding"`
+	HasCaller     bool   `mapstructure:"caller"`
+	HasStacktrace bool   `mapstructure:"stacktrace"`
+	// PIIFields ключи полей с персональными данными, пустой список - значения по умолчанию логгера
+	PIIFields []string `mapstructure:"pii_fields"`
+}
+
+type Auth struct {
+	// JWTKey ключ подписи токенов
+	JWTKey   string        `mapstructure:"jwt_key"`
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+}
+
+type Storage struct {
+	// Root каталог с файлами заданий, сданных работ и карантином
+	Root string `mapstructure:"root"`
+}
+
+type Upload struct {
+	MaxSize Size `mapstructure:"max_size"`
+	// ResumableMaxSize допустимый размер файла, загруженного возобновляемой загрузкой
+	ResumableMaxSize Size `mapstructure:"resumable_max_size"`
+	// Root каталог незавершенных загрузок, относительный путь считается от storage.root
+	Root string        `mapstructure:"root"`
+	TTL  time.Duration `mapstructure:"ttl"`
+}
+
+type Scanner struct {
+	Driver  string        `mapstructure:"driver"`
+	Network string        `mapstructure:"network"`
+	Address string        `mapstructure:"address"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type Preview struct {
+	Driver         string        `mapstructure:"driver"`
+	Binary         string        `mapstructure:"binary"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	Workers        int           `mapstructure:"workers"`
+	ThumbnailWidth int           `mapstructure:"thumbnail_width"`
+}
+
+type Download struct {
+	// LinkSecret ключ подписи ссылок, без него ключ создается при запуске
+	LinkSecret string        `mapstructure:"link_secret"`
+	LinkTTL    time.Duration `mapstructure:"link_ttl"`
+}
+
+type Tracing struct {
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	ServiceName string  `mapstructure:"service_name"`
+}
+
+// Size размер в байтах, в файле и переменных окружения задается как "10MB"
+type Size int64
+
+func (s *Size) UnmarshalText(text []byte) error {
+	n, err := sizeutils.Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = Size(n)
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+)
+
+// EnvPrefix префикс переменных окружения: db.host задается в PRACTICE_DB_HOST
+const EnvPrefix = "PRACTICE"
+
+// defaultFile файл конфигурации, если он не задан флагом --config
+const defaultFile = "configs/local.yaml"
+
+// secretSuffix суффикс ключа с путем к файлу секрета: db.password_file или PRACTICE_DB_PASSWORD_FILE.
+// Так передаются Docker secrets, которые монтируются файлами в /run/secrets
+const secretSuffix = "_file"
+
+// secrets ключи, значения которых можно прочитать из файла
+var secrets = []string{"db.password", "auth.jwt_key", "download.link_secret"}
+
+var defaults = map[string]any{
+	"server.port":                "8080",
+	"server.metrics_port":        "9090",
+	"server.read_header_timeout": "10s",
+	"server.read_timeout":        "0s",
+	"server.write_timeout":       "0s",
+	"server.idle_timeout":        "2m",
+	"server.shutdown_timeout":    "30s",
+
+	"handler.timeout":      "5s",
+	"handler.long_timeout": "30s",
+	"handler.file_timeout": "10m",
+
+	"db.host":     "localhost",
+	"db.port":     "5432",
+	"db.username": "",
+	"db.password": "",
+	"db.dbname":   "",
+	"db.sslmode":  "disable",
+
+	"migrations.allow_failure": false,
+
+	"log.level":      "info",
+	"log.encoding":   "json",
+	"log.caller":     true,
+	"log.stacktrace": true,
+	"log.pii_fields": []string{},
+
+	"auth.jwt_key":   "",
+	"auth.token_ttl": "24h",
+
+	"storage.root": ".",
+
+	"upload.max_size":           "10MB",
+	"upload.resumable_max_size": "100MB",
+	"upload.root":               "uploads",
+	"upload.ttl":                "24h",
+
+	"scanner.driver":  "none",
+	"scanner.network": "tcp",
+	"scanner.address": "",
+	"scanner.timeout": "30s",
+
+	"preview.driver":          "none",
+	"preview.binary":          "soffice",
+	"preview.timeout":         "1m",
+	"preview.workers":         2,
+	"preview.thumbnail_width": 320,
+
+	"download.link_secret": "",
+	"download.link_ttl":    "15m",
+
+	"tracing.exporter":     "none",
+	"tracing.endpoint":     "",
+	"tracing.insecure":     false,
+	"tracing.sample_ratio": 1.0,
+	"tracing.service_name": "practice_vgpek",
+}
+
+// Load читает конфигурацию из файла, переменных окружения и флагов args и проверяет ее.
+// Ошибки проверки возвращаются все сразу, по одной на строку, с ключами настроек
+func Load(args []string) (Config, error) {
+	v := viper.New()
+
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	// Без значений по умолчанию viper не находит переменные окружения для ключей секретов из файлов
+	for _, key := range secrets {
+		v.SetDefault(key+secretSuffix, "")
+	}
+
+	flags := newFlags()
+
+	err := flags.Parse(args)
+	if err != nil {
+		return Config{}, err
+	}
+
+	err = v.BindPFlags(flags)
+	if err != nil {
+		return Config{}, err
+	}
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	file, _ := flags.GetString("config")
+	if file == "" {
+		file = os.Getenv(EnvPrefix + "_CONFIG")
+	}
+
+	if file != "" {
+		v.SetConfigFile(file)
+	} else {
+		v.SetConfigFile(defaultFile)
+	}
+
+	err = v.ReadInConfig()
+	// Файл по умолчанию необязателен: все можно задать переменными окружения
+	if err != nil && (file != "" || !errors.Is(err, os.ErrNotExist)) {
+		return Config{}, fmt.Errorf("чтение файла конфигурации: %w", err)
+	}
+
+	err = readSecrets(v)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+
+	err = v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)))
+	if err != nil {
+		return Config{}, fmt.Errorf("разбор конфигурации: %w", err)
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// newFlags флаги командной строки. Имена флагов совпадают с ключами настроек, поэтому
+// флаг переопределяет одноименное значение из файла и окружения
+func newFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("practice", pflag.ContinueOnError)
+
+	flags.String("config", "", "путь к файлу конфигурации, по умолчанию "+defaultFile+" или "+EnvPrefix+"_CONFIG")
+	flags.String("server.port", "", "порт HTTP сервера")
+	flags.String("log.level", "", "уровень журнала: debug, info, warn, error")
+	flags.String("storage.root", "", "каталог файлов")
+	flags.Bool("migrations.allow_failure", false, "запускаться, даже если миграции не применились")
+
+	return flags
+}
+
+// readSecrets подставляет вместо секретов содержимое файлов из ключей с суффиксом _file.
+// Файл имеет приоритет над значением, заданным напрямую
+func readSecrets(v *viper.Viper) error {
+	for _, key := range secrets {
+		path := v.GetString(key + secretSuffix)
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s%s: чтение секрета: %w", key, secretSuffix, err)
+		}
+
+		v.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return nil
+}
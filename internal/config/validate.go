@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	sslModes       = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logEncodings   = []string{"json", "console"}
+	scanDrivers    = []string{"none", "clamd"}
+	scanNetworks   = []string{"tcp", "unix"}
+	previewDrivers = []string{"none", "libreoffice"}
+	exporters      = []string{"none", "stdout", "otlp"}
+)
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки
+func (c Config) Validate() error {
+	var v validator
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		v.fail("server.port", "ожидается номер порта от 1 до 65535, получено %q", c.Server.Port)
+	}
+	if c.Server.MetricsPort != "" {
+		if port, err := strconv.Atoi(c.Server.MetricsPort); err != nil || port < 1 || port > 65535 {
+			v.fail("server.metrics_port", "ожидается номер порта от 1 до 65535, получено %q", c.Server.MetricsPort)
+		} else if c.Server.MetricsPort == c.Server.Port {
+			v.fail("server.metrics_port", "должен отличаться от server.port")
+		}
+	}
+	v.notNegative("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.notNegative("server.read_timeout", c.Server.ReadTimeout)
+	v.notNegative("server.write_timeout", c.Server.WriteTimeout)
+	v.notNegative("server.idle_timeout", c.Server.IdleTimeout)
+	v.positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+
+	v.positive("handler.timeout", c.Handler.Timeout)
+	v.positive("handler.long_timeout", c.Handler.LongTimeout)
+	v.positive("handler.file_timeout", c.Handler.FileTimeout)
+	if c.Handler.LongTimeout > 0 && c.Handler.LongTimeout < c.Handler.Timeout {
+		v.fail("handler.long_timeout", "не может быть меньше handler.timeout (%s), получено %s", c.Handler.Timeout, c.Handler.LongTimeout)
+	}
+
+	v.required("db.host", c.DB.Host)
+	v.required("db.port", c.DB.Port)
+	v.required("db.username", c.DB.Username)
+	v.required("db.dbname", c.DB.DBName)
+	v.oneOf("db.sslmode", c.DB.SSLMode, sslModes)
+
+	if _, err := zapcore.ParseLevel(c.Log.Level); err != nil {
+		v.fail("log.level", "ожидается debug, info, warn или error, получено %q", c.Log.Level)
+	}
+	v.oneOf("log.encoding", c.Log.Encoding, logEncodings)
+
+	v.required("auth.jwt_key", c.Auth.JWTKey)
+	v.positive("auth.token_ttl", c.Auth.TokenTTL)
+
+	v.required("storage.root", c.Storage.Root)
+
+	if c.Upload.MaxSize <= 0 {
+		v.fail("upload.max_size", "должен быть больше нуля")
+	}
+	if c.Upload.ResumableMaxSize < c.Upload.MaxSize {
+		v.fail("upload.resumable_max_size", "не может быть меньше upload.max_size")
+	}
+	v.required("upload.root", c.Upload.Root)
+	v.positive("upload.ttl", c.Upload.TTL)
+
+	v.oneOf("scanner.driver", c.Scanner.Driver, scanDrivers)
+	if c.Scanner.Driver == "clamd" {
+		v.oneOf("scanner.network", c.Scanner.Network, scanNetworks)
+		v.required("scanner.address", c.Scanner.Address)
+		v.positive("scanner.timeout", c.Scanner.Timeout)
+	}
+
+	v.oneOf("preview.driver", c.Preview.Driver, previewDrivers)
+	if c.Preview.Driver == "libreoffice" {
+		v.required("preview.binary", c.Preview.Binary)
+		v.positive("preview.timeout", c.Preview.Timeout)
+
+		if c.Preview.Workers < 1 {
+			v.fail("preview.workers", "должно быть не меньше 1")
+		}
+	}
+	if c.Preview.ThumbnailWidth < 1 {
+		v.fail("preview.thumbnail_width", "должна быть не меньше 1")
+	}
+
+	v.positive("download.link_ttl", c.Download.LinkTTL)
+
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, exporters)
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.fail("tracing.sample_ratio", "ожидается доля от 0 до 1, получено %v", c.Tracing.SampleRatio)
+	}
+
+	return v.err()
+}
+
+// validator копит ошибки проверки, чтобы сообщить обо всех неверных настройках за один запуск
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(key, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.fail(key, "обязательная настройка не задана")
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	if !slices.Contains(allowed, value) {
+		v.fail(key, "ожидается одно из %s, получено %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	if d <= 0 {
+		v.fail(key, "должно быть больше нуля, получено %s", d)
+	}
+}
+
+func (v *validator) notNegative(key string, d time.Duration) {
+	if d < 0 {
+		v.fail(key, "не может быть отрицательным, получено %s", d)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("неверная конфигурация:\n%w", errors.Join(v.errs...))
+}
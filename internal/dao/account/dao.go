@@ -1,16 +1,16 @@
 package account
 
 import (
-	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
+	"practice_vgpek/pkg/postgres"
 )
 
 type DAO struct {
-	db     *pgxpool.Pool
+	db     postgres.DB
 	logger *zap.Logger
 }
 
-func New(db *pgxpool.Pool, logger *zap.Logger) DAO {
+func New(db postgres.DB, logger *zap.Logger) DAO {
 	return DAO{
 		db:     db,
 		logger: logger,
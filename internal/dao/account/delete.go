@@ -5,10 +5,12 @@ import (
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/postgres"
 )
 
 func (dao DAO) HardDeleteById(ctx context.Context, id int) error {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.HardDeleteAccountByIdDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -20,7 +22,7 @@ func (dao DAO) HardDeleteById(ctx context.Context, id int) error {
 	_, err := dao.db.Exec(ctx, deleteQuery, id)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return err
+		return postgres.MapError(err)
 	}
 
 	return nil
@@ -8,12 +8,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, data dto.AccountRegistrationData) (entity.Account, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveAccountDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -31,7 +33,7 @@ func (dao DAO) Save(ctx context.Context, data dto.AccountRegistrationData) (enti
 	}
 
 	l.Debug("аргументы запроса",
-		zap.String("login", args["Login"].(string)),
+		logger.PII("логин", args["Login"].(string)),
 		zap.Int("id роли", args["RoleId"].(int)),
 		zap.Int("id ключа", args["KeyId"].(int)),
 	)
@@ -42,10 +44,10 @@ func (dao DAO) Save(ctx context.Context, data dto.AccountRegistrationData) (enti
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveAccountDAO, now)))
 
 	selectQuery := `SELECT * FROM account WHERE account_id=@AccountId`
 
@@ -58,15 +60,15 @@ func (dao DAO) Save(ctx context.Context, data dto.AccountRegistrationData) (enti
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveAccountDAO, now)))
 
 	account, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Account])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
 	return account, nil
@@ -9,12 +9,14 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.Account, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectAccountByIdDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -32,15 +34,15 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Account, error) {
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectAccountByIdDAO, now)))
 
 	account, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Account])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("id аккаунта", account.Id))
@@ -49,7 +51,7 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Account, error) {
 }
 
 func (dao DAO) ByLogin(ctx context.Context, login string) (entity.Account, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectAccountByLoginDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -60,22 +62,22 @@ func (dao DAO) ByLogin(ctx context.Context, login string) (entity.Account, error
 		"Login": login,
 	}
 
-	l.Debug("аргументы запроса", zap.String("логин", args["Login"].(string)))
+	l.Debug("аргументы запроса", logger.PII("логин", args["Login"].(string)))
 
 	now := time.Now()
 	rows, err := dao.db.Query(ctx, getQuery, args)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectAccountByLoginDAO, now)))
 
 	account, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Account])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Account{}, err
+		return entity.Account{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("id аккаунта", account.Id))
@@ -84,7 +86,7 @@ func (dao DAO) ByLogin(ctx context.Context, login string) (entity.Account, error
 }
 
 func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Account, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectAccountsByParamsDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -98,7 +100,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Account
 	if err != nil {
 		l.Warn("ошибка подготовки запроса", zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Debug("аргументы запроса",
@@ -111,15 +113,15 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Account
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectAccountsByParamsDAO, now)))
 
 	persons, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Account])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("количество аккаунтов", len(persons)))
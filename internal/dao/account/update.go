@@ -0,0 +1,45 @@
+package account
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Deactivate(ctx context.Context, id int, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeactivateAccountByIdDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE account SET is_active = false, deactivate_time = @DeactivateTime WHERE account_id = @AccountId`
+
+	args := pgx.NamedArgs{
+		"AccountId":      id,
+		"DeactivateTime": at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.Time("время деактивации", args["DeactivateTime"].(time.Time)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeactivateAccountByIdDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated, zap.Int("id аккаунта", id))
+
+	return nil
+}
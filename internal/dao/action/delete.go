@@ -7,12 +7,14 @@ import (
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SoftDeleteActionById),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -30,10 +32,10 @@ func (dao DAO) SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo)
 	_, err := dao.db.Exec(ctx, deleteQuery, args)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return err
+		return postgres.MapError(err)
 	}
 
-	l.Debug(operation.Update, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.SoftDeleteActionById, now)))
 
 	l.Info(operation.SuccessfullyUpdated)
 
@@ -8,12 +8,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, action dto.NewRBACPart) (entity.Action, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveActionDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -40,10 +42,10 @@ func (dao DAO) Save(ctx context.Context, action dto.NewRBACPart) (entity.Action,
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Action{}, err
+		return entity.Action{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveActionDAO, now)))
 
 	getQuery := `SELECT * FROM internal_action WHERE internal_action_id=$1`
 
@@ -52,15 +54,15 @@ func (dao DAO) Save(ctx context.Context, action dto.NewRBACPart) (entity.Action,
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Action{}, err
+		return entity.Action{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveActionDAO, now)))
 
 	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Action])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Action{}, err
+		return entity.Action{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyRecorded, zap.Int("id действия", id))
@@ -5,13 +5,34 @@ import (
 	"go.uber.org/zap"
 	"practice_vgpek/internal/dao/account"
 	"practice_vgpek/internal/dao/action"
+	"practice_vgpek/internal/dao/assignment"
+	"practice_vgpek/internal/dao/audit"
+	"practice_vgpek/internal/dao/digest"
+	"practice_vgpek/internal/dao/discipline"
+	"practice_vgpek/internal/dao/group"
+	"practice_vgpek/internal/dao/health"
 	"practice_vgpek/internal/dao/issued"
 	"practice_vgpek/internal/dao/key"
+	"practice_vgpek/internal/dao/link"
+	"practice_vgpek/internal/dao/notification"
 	"practice_vgpek/internal/dao/object"
 	"practice_vgpek/internal/dao/permission"
 	"practice_vgpek/internal/dao/person"
+	"practice_vgpek/internal/dao/quota"
 	"practice_vgpek/internal/dao/role"
+	"practice_vgpek/internal/dao/scan"
+	"practice_vgpek/internal/dao/similarity"
 	"practice_vgpek/internal/dao/solved"
+	"practice_vgpek/internal/dao/term"
+	"practice_vgpek/internal/dao/upload"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// Транзакции повторяются при конфликтах сериализации с нарастающей паузой
+const (
+	txAttempts = 5
+	txBackoff  = 20 * time.Millisecond
 )
 
 type Aggregator struct {
@@ -23,14 +44,38 @@ type Aggregator struct {
 
 	PersonDAO  PersonDAO
 	AccountDAO AccountDAO
+	GroupDAO   GroupDAO
 
 	KeyDAO KeyDAO
 
 	IssuedDAO IssuedPracticeDAO
 	SolvedDAO SolvedPracticeDAO
+
+	SimilarityDAO SimilarityDAO
+
+	DisciplineDAO DisciplineDAO
+	AssignmentDAO AssignmentDAO
+	TermDAO       TermDAO
+
+	NotificationDAO NotificationDAO
+
+	FileScanDAO   FileScanDAO
+	FileDigestDAO FileDigestDAO
+	AuditDAO      AuditDAO
+
+	QuotaDAO  QuotaDAO
+	UploadDAO UploadDAO
+
+	DownloadLinkDAO DownloadLinkDAO
+
+	HealthDAO HealthDAO
+
+	TxManager postgres.TxManager
 }
 
-func New(db *pgxpool.Pool, logger *zap.Logger) Aggregator {
+func New(pool *pgxpool.Pool, logger *zap.Logger) Aggregator {
+	db := postgres.NewDB(pool)
+
 	return Aggregator{
 		ActionDAO: action.New(db, logger),
 		ObjectDAO: object.New(db, logger),
@@ -40,10 +85,32 @@ func New(db *pgxpool.Pool, logger *zap.Logger) Aggregator {
 
 		PersonDAO:  person.New(db, logger),
 		AccountDAO: account.New(db, logger),
+		GroupDAO:   group.New(db, logger),
 
 		KeyDAO: key.New(db, logger),
 
 		IssuedDAO: issued.New(db, logger),
 		SolvedDAO: solved.New(db, logger),
+
+		SimilarityDAO: similarity.New(db, logger),
+
+		DisciplineDAO: discipline.New(db, logger),
+		AssignmentDAO: assignment.New(db, logger),
+		TermDAO:       term.New(db, logger),
+
+		NotificationDAO: notification.New(db, logger),
+
+		FileScanDAO:   scan.New(db, logger),
+		FileDigestDAO: digest.New(db, logger),
+		AuditDAO:      audit.New(db, logger),
+
+		QuotaDAO:  quota.New(db, logger),
+		UploadDAO: upload.New(db, logger),
+
+		DownloadLinkDAO: link.New(db, logger),
+
+		HealthDAO: health.New(db, logger),
+
+		TxManager: postgres.NewTxManager(pool, txAttempts, txBackoff),
 	}
 }
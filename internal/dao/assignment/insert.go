@@ -0,0 +1,75 @@
+package assignment
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewAssignmentReq) (entity.TeachingAssignment, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveAssignmentDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO teaching_assignment 
+    				(account_id, discipline_id, group_name, term) 
+					VALUES 
+					(@AccountId, @DisciplineId, @GroupName, @Term)
+					RETURNING teaching_assignment_id`
+
+	args := pgx.NamedArgs{
+		"AccountId":    data.AccountId,
+		"DisciplineId": data.DisciplineId,
+		"GroupName":    data.GroupName,
+		"Term":         data.Term,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.Int("id дисциплины", args["DisciplineId"].(int)),
+		zap.String("группа", args["GroupName"].(string)),
+		zap.String("семестр", args["Term"].(string)),
+	)
+
+	var id int
+
+	now := time.Now()
+	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.TeachingAssignment{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveAssignmentDAO, now)))
+
+	getQuery := `SELECT * FROM teaching_assignment WHERE teaching_assignment_id=$1`
+
+	now = time.Now()
+	rows, err := dao.db.Query(ctx, getQuery, id)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.TeachingAssignment{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveAssignmentDAO, now)))
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.TeachingAssignment])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.TeachingAssignment{}, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyRecorded, zap.Int("id назначения", id))
+
+	return saved, nil
+}
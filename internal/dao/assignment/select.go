@@ -0,0 +1,85 @@
+package assignment
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) ById(ctx context.Context, id int) (entity.TeachingAssignment, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectAssignmentById),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM teaching_assignment WHERE teaching_assignment_id=@AssignmentId`
+
+	args := pgx.NamedArgs{
+		"AssignmentId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id назначения", args["AssignmentId"].(int)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, getQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.TeachingAssignment{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectAssignmentById, now)))
+
+	assignment, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.TeachingAssignment])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.TeachingAssignment{}, postgres.MapError(err)
+	}
+
+	return assignment, nil
+}
+
+// ByAccountId возвращает действующие (не удаленные) назначения преподавателя
+func (dao DAO) ByAccountId(ctx context.Context, accountId int) ([]entity.TeachingAssignment, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectAssignmentsByAccountId),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM teaching_assignment 
+         		 WHERE account_id=@AccountId AND is_deleted IS NULL 
+         		 ORDER BY discipline_id, group_name`
+
+	args := pgx.NamedArgs{
+		"AccountId": accountId,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id аккаунта", args["AccountId"].(int)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, getQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectAssignmentsByAccountId, now)))
+
+	assignments, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.TeachingAssignment])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyReceived, zap.Int("количество назначений", len(assignments)))
+
+	return assignments, nil
+}
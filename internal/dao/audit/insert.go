@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewAuditLog) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveAuditLogDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO audit_log (account_id, event, object, details) 
+					VALUES (NULLIF(@AccountId, 0), @Event, @Object, @Details)`
+
+	args := pgx.NamedArgs{
+		"AccountId": data.AccountId,
+		"Event":     data.Event,
+		"Object":    data.Object,
+		"Details":   data.Details,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.String("событие", args["Event"].(string)),
+		zap.String("объект", args["Object"].(string)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveAuditLogDAO, now)))
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/params"
+	"time"
 )
 
 type ActionDAO interface {
@@ -45,6 +46,7 @@ type AccountDAO interface {
 	ByParams(ctx context.Context, p params.Default) ([]entity.Account, error)
 
 	HardDeleteById(ctx context.Context, id int) error
+	Deactivate(ctx context.Context, id int, at time.Time) error
 }
 
 type KeyDAO interface {
@@ -60,15 +62,131 @@ type KeyDAO interface {
 type PermissionDAO interface {
 	ByRoleId(ctx context.Context, roleId int) ([]entity.Permissions, error)
 	Save(ctx context.Context, roleId, objectId int, actionsId []int) error
+	DeleteByRoleObject(ctx context.Context, roleId, objectId int) error
 }
 
 type IssuedPracticeDAO interface {
 	Save(ctx context.Context, data dto.NewIssuedPractice) (entity.IssuedPractice, error)
 	ById(ctx context.Context, id int) (entity.IssuedPractice, error)
+	ByParams(ctx context.Context, p params.IssuedPractice) ([]entity.IssuedPractice, error)
+	ByDiscipline(ctx context.Context, disciplineId, termId int) ([]entity.IssuedPractice, error)
+
+	Update(ctx context.Context, practice entity.IssuedPracticeUpdate) (entity.IssuedPractice, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+	RestoreById(ctx context.Context, id int) error
+
+	SaveFileVersion(ctx context.Context, version entity.IssuedPracticeFileVersion) error
+
+	SaveText(ctx context.Context, practiceId int, text string) error
+	CopyText(ctx context.Context, fromId, toId int) error
+	Search(ctx context.Context, p params.PracticeSearch) ([]entity.IssuedPracticeSearchHit, error)
+	FileVersions(ctx context.Context, practiceId int) ([]entity.IssuedPracticeFileVersion, error)
 }
 
 type SolvedPracticeDAO interface {
 	Save(ctx context.Context, data dto.NewSolvedPractice) (entity.SolvedPractice, error)
 	ById(ctx context.Context, id int) (entity.SolvedPractice, error)
 	Update(ctx context.Context, old entity.SolvedPracticeUpdate) (entity.SolvedPractice, error)
+
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error)
+	ByContentHash(ctx context.Context, issuedPracticeId int, hash string) ([]entity.SolvedPractice, error)
+
+	SetMarks(ctx context.Context, changes []dto.MarkChange) error
+	MarkHistory(ctx context.Context, solvedPracticeId int) ([]entity.MarkHistory, error)
+}
+
+type GroupDAO interface {
+	Save(ctx context.Context, data dto.NewMembership) (entity.AccountGroup, error)
+
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
+	MembersByGroup(ctx context.Context, groupName string, at time.Time) ([]entity.AccountGroup, error)
+
+	Close(ctx context.Context, id int, at time.Time) error
+}
+
+type DisciplineDAO interface {
+	Save(ctx context.Context, data dto.NewDisciplineReq) (entity.Discipline, error)
+	ById(ctx context.Context, id int) (entity.Discipline, error)
+	ByParams(ctx context.Context, p params.Default) ([]entity.Discipline, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+}
+
+type AssignmentDAO interface {
+	Save(ctx context.Context, data dto.NewAssignmentReq) (entity.TeachingAssignment, error)
+	ById(ctx context.Context, id int) (entity.TeachingAssignment, error)
+	ByAccountId(ctx context.Context, accountId int) ([]entity.TeachingAssignment, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+}
+
+type TermDAO interface {
+	Save(ctx context.Context, data dto.NewTermReq) (entity.AcademicTerm, error)
+	ById(ctx context.Context, id int) (entity.AcademicTerm, error)
+	ByName(ctx context.Context, name string) (entity.AcademicTerm, error)
+	Current(ctx context.Context) (entity.AcademicTerm, error)
+	ByParams(ctx context.Context, p params.Default) ([]entity.AcademicTerm, error)
+
+	SetCurrent(ctx context.Context, id int) error
+	Close(ctx context.Context, id int, at time.Time) error
+}
+
+type NotificationDAO interface {
+	Save(ctx context.Context, data dto.NewNotification) error
+	ByAccountId(ctx context.Context, accountId int, p params.Default) ([]entity.Notification, error)
+	MarkRead(ctx context.Context, id, accountId int, at time.Time) error
+}
+
+type SimilarityDAO interface {
+	ReplaceForPractice(ctx context.Context, issuedPracticeId int, pairs []dto.NewSimilarity) error
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int, minScore float64) ([]entity.Similarity, error)
+}
+
+type FileScanDAO interface {
+	Save(ctx context.Context, data dto.NewFileScan) error
+	ByPath(ctx context.Context, path string) (entity.FileScan, error)
+}
+
+type FileDigestDAO interface {
+	Save(ctx context.Context, data dto.NewFileDigest) error
+	ByPath(ctx context.Context, path string) (entity.FileDigest, error)
+}
+
+type AuditDAO interface {
+	Save(ctx context.Context, data dto.NewAuditLog) error
+}
+
+type QuotaDAO interface {
+	SaveFile(ctx context.Context, data dto.NewStoredFile) error
+	Release(ctx context.Context, paths []string, at time.Time) error
+	Restore(ctx context.Context, paths []string) error
+
+	AccountUsage(ctx context.Context, accountId int) (int64, error)
+	GroupUsage(ctx context.Context, groupName string) (int64, error)
+	Top(ctx context.Context, by string, limit int) ([]entity.StorageUsage, error)
+
+	SaveQuota(ctx context.Context, data dto.SetQuotaReq) (entity.StorageQuota, error)
+	QuotaByRole(ctx context.Context, roleId int) (entity.StorageQuota, error)
+	QuotaByGroup(ctx context.Context, groupName string) (entity.StorageQuota, error)
+	Quotas(ctx context.Context) ([]entity.StorageQuota, error)
+	DeleteQuota(ctx context.Context, id int) error
+}
+
+type UploadDAO interface {
+	Save(ctx context.Context, data dto.NewUploadSession) (entity.UploadSession, error)
+	ById(ctx context.Context, id string) (entity.UploadSession, error)
+	UpdateOffset(ctx context.Context, id string, from, to int64) error
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, at time.Time) ([]string, error)
+}
+
+type DownloadLinkDAO interface {
+	Save(ctx context.Context, data dto.NewDownloadLink) (entity.DownloadLink, error)
+	ById(ctx context.Context, id string) (entity.DownloadLink, error)
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+	Revoke(ctx context.Context, id string, by int, at time.Time) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type HealthDAO interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,52 @@
+package digest
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// Save сохраняет хэш файла, заменяя прежний
+func (dao DAO) Save(ctx context.Context, data dto.NewFileDigest) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveFileDigestDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO file_digest (file_path, content_hash, size_bytes, computed_at) 
+					VALUES (@Path, @Hash, @Size, @ComputedAt)
+					ON CONFLICT (file_path) DO UPDATE 
+					SET content_hash = excluded.content_hash, size_bytes = excluded.size_bytes, 
+					    computed_at = excluded.computed_at`
+
+	args := pgx.NamedArgs{
+		"Path":       data.Path,
+		"Hash":       data.Hash,
+		"Size":       data.Size,
+		"ComputedAt": time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("путь к файлу", args["Path"].(string)),
+		zap.String("хэш содержимого", args["Hash"].(string)),
+		zap.Int64("размер", args["Size"].(int64)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveFileDigestDAO, now)))
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package digest
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ByPath возвращает хэш файла, если он еще не считался - pgx.ErrNoRows
+func (dao DAO) ByPath(ctx context.Context, path string) (entity.FileDigest, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectFileDigestDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM file_digest WHERE file_path=@Path`
+
+	args := pgx.NamedArgs{
+		"Path": path,
+	}
+
+	l.Debug("аргументы запроса", zap.String("путь к файлу", args["Path"].(string)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.FileDigest{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectFileDigestDAO, now)))
+
+	digest, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.FileDigest])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.FileDigest{}, postgres.MapError(err)
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,18 @@
+package discipline
+
+import (
+	"go.uber.org/zap"
+	"practice_vgpek/pkg/postgres"
+)
+
+type DAO struct {
+	db     postgres.DB
+	logger *zap.Logger
+}
+
+func New(db postgres.DB, logger *zap.Logger) DAO {
+	return DAO{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,43 @@
+package discipline
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SoftDeleteDisciplineById),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `UPDATE discipline SET is_deleted = @DeleteTime WHERE discipline_id = @DisciplineId`
+
+	args := pgx.NamedArgs{
+		"DisciplineId": id,
+		"DeleteTime":   info.DeleteTime,
+	}
+
+	l.Debug("аргументы запроса", zap.Time("время удаления", args["DeleteTime"].(time.Time)))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.SoftDeleteDisciplineById, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
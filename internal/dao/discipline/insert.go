@@ -0,0 +1,71 @@
+package discipline
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewDisciplineReq) (entity.Discipline, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveDisciplineDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO discipline 
+    				(discipline_name, description) 
+					VALUES 
+					(@Name, @Description)
+					RETURNING discipline_id`
+
+	args := pgx.NamedArgs{
+		"Name":        data.Name,
+		"Description": data.Description,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("название", args["Name"].(string)),
+		zap.String("описание", args["Description"].(string)),
+	)
+
+	var id int
+
+	now := time.Now()
+	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.Discipline{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveDisciplineDAO, now)))
+
+	getQuery := `SELECT * FROM discipline WHERE discipline_id=$1`
+
+	now = time.Now()
+	rows, err := dao.db.Query(ctx, getQuery, id)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.Discipline{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveDisciplineDAO, now)))
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Discipline])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.Discipline{}, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyRecorded, zap.Int("id дисциплины", id))
+
+	return saved, nil
+}
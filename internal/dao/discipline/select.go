@@ -0,0 +1,95 @@
+package discipline
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) ById(ctx context.Context, id int) (entity.Discipline, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectDisciplineById),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM discipline WHERE discipline_id=@DisciplineId`
+
+	args := pgx.NamedArgs{
+		"DisciplineId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id дисциплины", args["DisciplineId"].(int)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, getQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.Discipline{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectDisciplineById, now)))
+
+	discipline, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Discipline])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.Discipline{}, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyReceived, zap.Int("id дисциплины", discipline.Id))
+
+	return discipline, nil
+}
+
+func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Discipline, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectDisciplinesByParams),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := squirrel.Select("*").From("discipline").
+		Limit(uint64(p.Limit)).
+		Offset(uint64(p.Offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	q, args, err := selectQuery.ToSql()
+	if err != nil {
+		l.Warn("ошибка подготовки запроса", zap.Error(err))
+
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("лимит", p.Limit),
+		zap.Int("смещение", p.Offset),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, q, args...)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectDisciplinesByParams, now)))
+
+	disciplines, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Discipline])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyReceived, zap.Int("количество дисциплин", len(disciplines)))
+
+	return disciplines, nil
+}
@@ -0,0 +1,75 @@
+package group
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewMembership) (entity.AccountGroup, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveMembershipDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO 
+						account_group (account_id, group_name, effective_from, reason) 
+					VALUES 
+					    (@AccountId, @GroupName, @EffectiveFrom, @Reason)
+					RETURNING account_group_id`
+
+	args := pgx.NamedArgs{
+		"AccountId":     data.AccountId,
+		"GroupName":     data.GroupName,
+		"EffectiveFrom": data.EffectiveFrom,
+		"Reason":        data.Reason,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.String("группа", args["GroupName"].(string)),
+		zap.Time("действует с", args["EffectiveFrom"].(time.Time)),
+		zap.String("причина", args["Reason"].(string)),
+	)
+
+	var id int
+
+	now := time.Now()
+	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.AccountGroup{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveMembershipDAO, now)))
+
+	selectQuery := `SELECT * FROM account_group WHERE account_group_id=$1`
+
+	now = time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, id)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.AccountGroup{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveMembershipDAO, now)))
+
+	membership, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AccountGroup])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.AccountGroup{}, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyRecorded, zap.Int("id членства", membership.Id))
+
+	return membership, nil
+}
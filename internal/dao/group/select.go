@@ -0,0 +1,101 @@
+package group
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// CurrentByAccountId возвращает группу, в которой аккаунт состоял в момент at
+func (dao DAO) CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectMembershipByAccountIdDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM account_group 
+         			WHERE account_id=@AccountId 
+         			  AND effective_from <= @At 
+         			  AND (effective_to IS NULL OR effective_to > @At)
+         			ORDER BY effective_from DESC 
+         			LIMIT 1`
+
+	args := pgx.NamedArgs{
+		"AccountId": accountId,
+		"At":        at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.Time("момент времени", args["At"].(time.Time)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.AccountGroup{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectMembershipByAccountIdDAO, now)))
+
+	membership, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AccountGroup])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.AccountGroup{}, postgres.MapError(err)
+	}
+
+	return membership, nil
+}
+
+// MembersByGroup возвращает членства всех аккаунтов, состоявших в группе в момент at
+func (dao DAO) MembersByGroup(ctx context.Context, groupName string, at time.Time) ([]entity.AccountGroup, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectMembersByGroupDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM account_group 
+         			WHERE group_name=@GroupName 
+         			  AND effective_from <= @At 
+         			  AND (effective_to IS NULL OR effective_to > @At)
+         			ORDER BY account_id`
+
+	args := pgx.NamedArgs{
+		"GroupName": groupName,
+		"At":        at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("группа", args["GroupName"].(string)),
+		zap.Time("момент времени", args["At"].(time.Time)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectMembersByGroupDAO, now)))
+
+	members, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AccountGroup])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyReceived, zap.Int("количество членств", len(members)))
+
+	return members, nil
+}
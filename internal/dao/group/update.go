@@ -0,0 +1,46 @@
+package group
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// Close завершает членство в группе с момента at
+func (dao DAO) Close(ctx context.Context, id int, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.CloseMembershipDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE account_group SET effective_to = @At WHERE account_group_id = @MembershipId`
+
+	args := pgx.NamedArgs{
+		"MembershipId": id,
+		"At":           at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id членства", args["MembershipId"].(int)),
+		zap.Time("действует до", args["At"].(time.Time)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.CloseMembershipDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Ping(ctx context.Context) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.PingDBDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	now := time.Now()
+	err := dao.db.Ping(ctx)
+	if err != nil {
+		l.Warn(operation.ExecuteError, zap.Error(err))
+		return err
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.PingDBDAO, now)))
+
+	return nil
+}
+
+// MigrationVersion возвращает версию последней примененной миграции goose. Записи журнала миграций
+// просматриваются от новых к старым, и версия, отмененная позже своего применения, пропускается,
+// как это делает сам goose
+func (dao DAO) MigrationVersion(ctx context.Context) (int64, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectMigrationVersionDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT version_id, is_applied FROM goose_db_version ORDER BY id DESC`
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return 0, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectMigrationVersionDAO, now)))
+
+	rolledBack := make(map[int64]bool)
+
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+		)
+
+		err = rows.Scan(&version, &isApplied)
+		if err != nil {
+			l.Error(operation.CollectError, zap.Error(err))
+			return 0, postgres.MapError(err)
+		}
+
+		if !isApplied {
+			rolledBack[version] = true
+			continue
+		}
+
+		if !rolledBack[version] {
+			return version, nil
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return 0, postgres.MapError(err)
+	}
+
+	return 0, nil
+}
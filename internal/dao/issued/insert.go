@@ -8,39 +8,51 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, data dto.NewIssuedPractice) (entity.IssuedPractice, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveIssuedPracticeDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
 
 	insertQuery := `INSERT INTO 
-						issued_practice (account_id, target_groups, title, theme, major, practice_path, upload_at) 
+						issued_practice (account_id, target_groups, discipline_id, academic_term_id, title, theme, major, 
+						                 practice_path, file_name, upload_at, deadline, cloned_from) 
 					VALUES 
-					    (@AccountId, @TargetGroups, @Title, @Theme, @Major, @PracticePath, @UploadAt)
+					    (@AccountId, @TargetGroups, @DisciplineId, @AcademicTermId, @Title, @Theme, @Major, 
+					     @PracticePath, NULLIF(@FileName, ''), @UploadAt, @Deadline, @ClonedFrom)
 					RETURNING issued_practice_id`
 
 	args := pgx.NamedArgs{
-		"AccountId":    data.AccountId,
-		"TargetGroups": data.TargetGroups,
-		"Title":        data.Title,
-		"Theme":        data.Theme,
-		"Major":        data.Major,
-		"PracticePath": data.Path,
-		"UploadAt":     data.UploadAt,
+		"AccountId":      data.AccountId,
+		"TargetGroups":   data.TargetGroups,
+		"DisciplineId":   data.DisciplineId,
+		"AcademicTermId": data.AcademicTermId,
+		"Title":          data.Title,
+		"Theme":          data.Theme,
+		"Major":          data.Major,
+		"PracticePath":   data.Path,
+		"FileName":       data.FileName,
+		"UploadAt":       data.UploadAt,
+		"Deadline":       data.Deadline,
+		"ClonedFrom":     data.ClonedFrom,
 	}
 
 	l.Debug("аргументы запроса",
 		zap.Int("id аккаунта", args["AccountId"].(int)),
 		zap.Strings("целевые группы", args["TargetGroups"].([]string)),
+		zap.Int("id дисциплины", args["DisciplineId"].(int)),
+		zap.Int("id семестра", args["AcademicTermId"].(int)),
 		zap.String("название", args["Title"].(string)),
 		zap.String("тема", args["Theme"].(string)),
 		zap.String("специальность", args["Major"].(string)),
 		zap.String("путь к практике", args["PracticePath"].(string)),
+		zap.String("имя файла", args["FileName"].(string)),
 		zap.Time("дата загрузки", args["UploadAt"].(time.Time)),
 	)
 
@@ -50,10 +62,10 @@ func (dao DAO) Save(ctx context.Context, data dto.NewIssuedPractice) (entity.Iss
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&issuedPracticeId)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.IssuedPractice{}, err
+		return entity.IssuedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveIssuedPracticeDAO, now)))
 
 	selectQuery := `SELECT * FROM issued_practice WHERE issued_practice_id=@IssuedPracticeId`
 
@@ -66,15 +78,15 @@ func (dao DAO) Save(ctx context.Context, data dto.NewIssuedPractice) (entity.Iss
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.IssuedPractice{}, err
+		return entity.IssuedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveIssuedPracticeDAO, now)))
 
 	practice, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.IssuedPractice])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.IssuedPractice{}, err
+		return entity.IssuedPractice{}, postgres.MapError(err)
 	}
 
 	return practice, nil
@@ -0,0 +1,151 @@
+package issued
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// SaveText сохраняет извлеченный текст файла задания, заменяя прежний
+func (dao DAO) SaveText(ctx context.Context, practiceId int, text string) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveIssuedPracticeTextDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO issued_practice_text (issued_practice_id, body_text, extracted_at)
+					VALUES (@IssuedPracticeId, @BodyText, @ExtractedAt)
+					ON CONFLICT (issued_practice_id) DO UPDATE
+					SET body_text = excluded.body_text, extracted_at = excluded.extracted_at`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": practiceId,
+		"BodyText":         text,
+		"ExtractedAt":      time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id задания", args["IssuedPracticeId"].(int)),
+		zap.Int("длина текста", len(text)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveIssuedPracticeTextDAO, now)))
+
+	return nil
+}
+
+// CopyText переносит текст файла задания на его копию, чтобы не извлекать его повторно
+func (dao DAO) CopyText(ctx context.Context, fromId, toId int) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.CopyIssuedPracticeTextDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO issued_practice_text (issued_practice_id, body_text, extracted_at)
+					SELECT @ToId, body_text, extracted_at FROM issued_practice_text WHERE issued_practice_id = @FromId
+					ON CONFLICT (issued_practice_id) DO UPDATE
+					SET body_text = excluded.body_text, extracted_at = excluded.extracted_at`
+
+	args := pgx.NamedArgs{
+		"FromId": fromId,
+		"ToId":   toId,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id исходного задания", args["FromId"].(int)),
+		zap.Int("id задания", args["ToId"].(int)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.CopyIssuedPracticeTextDAO, now)))
+
+	return nil
+}
+
+// Search ищет не удаленные задания по названию, теме, специальности и тексту файла.
+// Совпадения в метаданных весят больше совпадений в тексте файла. Название и текст экранируются
+// для HTML до выделения совпадений, поэтому единственная разметка во фрагментах - теги <b></b>.
+// Парсер полнотекстового поиска считает сущности вроде &lt; отдельными токенами и не индексирует их
+func (dao DAO) Search(ctx context.Context, p params.PracticeSearch) ([]entity.IssuedPracticeSearchHit, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SearchIssuedPracticesDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `WITH q AS (SELECT websearch_to_tsquery('russian', @Query) AS query)
+					SELECT ip.*,
+						ts_rank(
+							setweight(to_tsvector('russian', ip.title || ' ' || ip.theme || ' ' || ip.major), 'A') ||
+							setweight(coalesce(t.body_vector, ''::tsvector), 'D'),
+							q.query
+						)::float8 AS rank,
+						ts_headline('russian',
+							replace(replace(replace(replace(ip.title, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'),
+							q.query, 'StartSel=<b>, StopSel=</b>, HighlightAll=true') AS title_headline,
+						coalesce(ts_headline('russian',
+							replace(replace(replace(replace(t.body_text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'),
+							q.query,
+							'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … "'), '') AS snippet
+					FROM issued_practice ip
+					CROSS JOIN q
+					LEFT JOIN issued_practice_text t ON t.issued_practice_id = ip.issued_practice_id
+					WHERE ip.deleted_at IS NULL
+						AND (to_tsvector('russian', ip.title || ' ' || ip.theme || ' ' || ip.major) @@ q.query
+							OR t.body_vector @@ q.query)
+						AND (@GroupName = '' OR @GroupName = ANY(ip.target_groups))
+					ORDER BY rank DESC, ip.upload_at DESC
+					LIMIT @Limit OFFSET @Offset`
+
+	args := pgx.NamedArgs{
+		"Query":     p.Query,
+		"GroupName": p.GroupName,
+		"Limit":     p.Limit,
+		"Offset":    p.Offset,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("запрос", args["Query"].(string)),
+		zap.String("группа", args["GroupName"].(string)),
+		zap.Int("лимит", args["Limit"].(int)),
+		zap.Int("смещение", args["Offset"].(int)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SearchIssuedPracticesDAO, now)))
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.IssuedPracticeSearchHit])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return hits, nil
+}
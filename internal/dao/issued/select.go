@@ -2,17 +2,21 @@ package issued
 
 import (
 	"context"
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.IssuedPractice, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.GetIssuedPracticeInfoById),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -28,16 +32,122 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.IssuedPractice, error)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.IssuedPractice{}, err
+		return entity.IssuedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.GetIssuedPracticeInfoById, now)))
 
 	issuedPractice, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.IssuedPractice])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.IssuedPractice{}, err
+		return entity.IssuedPractice{}, postgres.MapError(err)
 	}
 
 	return issuedPractice, nil
 }
+
+// ByParams возвращает задания семестра. Если указана группа - только задания этой группы,
+// фильтр по решенности применяется к работам аккаунта из параметров
+func (dao DAO) ByParams(ctx context.Context, p params.IssuedPractice) ([]entity.IssuedPractice, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.GetIssuedPracticeInfoByParams),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	solvedQuery := `SELECT 1 FROM solved_practice sp 
+                	WHERE sp.issued_practice_id = issued_practice.issued_practice_id AND sp.performed_account_id = ?`
+
+	selectQuery := squirrel.Select("*").From("issued_practice").
+		Where(squirrel.Eq{"academic_term_id": p.TermId}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("upload_at DESC").
+		Limit(uint64(p.Limit)).
+		Offset(uint64(p.Offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if p.GroupName != "" {
+		selectQuery = selectQuery.Where("? = ANY(target_groups)", p.GroupName)
+	}
+
+	if p.AccountId != 0 {
+		switch p.IsSolved {
+		case "yes":
+			selectQuery = selectQuery.Where("EXISTS ("+solvedQuery+")", p.AccountId)
+		case "no":
+			selectQuery = selectQuery.Where("NOT EXISTS ("+solvedQuery+")", p.AccountId)
+		}
+	}
+
+	q, args, err := selectQuery.ToSql()
+	if err != nil {
+		l.Warn("ошибка подготовки запроса", zap.Error(err))
+
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id семестра", p.TermId),
+		zap.String("группа", p.GroupName),
+		zap.String("статус решения", p.IsSolved),
+		zap.Int("лимит", p.Limit),
+		zap.Int("смещение", p.Offset),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, q, args...)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.GetIssuedPracticeInfoByParams, now)))
+
+	practices, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.IssuedPractice])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return practices, nil
+}
+
+// ByDiscipline возвращает все не удаленные задания дисциплины в семестре
+func (dao DAO) ByDiscipline(ctx context.Context, disciplineId, termId int) ([]entity.IssuedPractice, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectIssuedPracticesByDisciplineDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM issued_practice 
+                	WHERE discipline_id=@DisciplineId AND academic_term_id=@TermId AND deleted_at IS NULL
+                	ORDER BY upload_at`
+
+	args := pgx.NamedArgs{
+		"DisciplineId": disciplineId,
+		"TermId":       termId,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id дисциплины", args["DisciplineId"].(int)),
+		zap.Int("id семестра", args["TermId"].(int)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectIssuedPracticesByDisciplineDAO, now)))
+
+	practices, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.IssuedPractice])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return practices, nil
+}
@@ -0,0 +1,125 @@
+package issued
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Update(ctx context.Context, practice entity.IssuedPracticeUpdate) (entity.IssuedPractice, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.UpdateIssuedPracticeDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	update := updateQ("issued_practice", practice).Where(squirrel.Eq{"issued_practice_id": practice.Id})
+
+	updateQuery, args, err := update.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		l.Error("ошибка сборки запроса", zap.Error(err))
+		return entity.IssuedPractice{}, postgres.MapError(err)
+	}
+
+	now := time.Now()
+	_, err = dao.db.Exec(ctx, updateQuery, args...)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.IssuedPractice{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateIssuedPracticeDAO, now)))
+
+	return dao.ById(ctx, practice.Id)
+}
+
+func (dao DAO) SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SoftDeleteIssuedPracticeDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `UPDATE issued_practice SET deleted_at = @DeleteTime WHERE issued_practice_id = @IssuedPracticeId`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": id,
+		"DeleteTime":       info.DeleteTime,
+	}
+
+	l.Debug("аргументы запроса", zap.Time("время удаления", args["DeleteTime"].(time.Time)))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.SoftDeleteIssuedPracticeDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
+
+func (dao DAO) RestoreById(ctx context.Context, id int) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.RestoreIssuedPracticeDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	restoreQuery := `UPDATE issued_practice SET deleted_at = NULL WHERE issued_practice_id = @IssuedPracticeId`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": id,
+	}
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, restoreQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.RestoreIssuedPracticeDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
+
+func updateQ(table string, newPractice entity.IssuedPracticeUpdate) squirrel.UpdateBuilder {
+	updateBuilder := squirrel.Update(table)
+
+	if newPractice.TargetGroups != nil {
+		updateBuilder = updateBuilder.Set("target_groups", newPractice.TargetGroups)
+	}
+	if newPractice.Deadline != nil {
+		updateBuilder = updateBuilder.Set("deadline", newPractice.Deadline)
+	}
+	if newPractice.Title != nil {
+		updateBuilder = updateBuilder.Set("title", newPractice.Title)
+	}
+	if newPractice.Theme != nil {
+		updateBuilder = updateBuilder.Set("theme", newPractice.Theme)
+	}
+	if newPractice.Major != nil {
+		updateBuilder = updateBuilder.Set("major", newPractice.Major)
+	}
+	if newPractice.Path != nil {
+		updateBuilder = updateBuilder.Set("practice_path", newPractice.Path)
+	}
+	if newPractice.FileName != nil {
+		updateBuilder = updateBuilder.Set("file_name", squirrel.Expr("NULLIF(?, '')", *newPractice.FileName))
+	}
+
+	return updateBuilder
+}
@@ -0,0 +1,82 @@
+package issued
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// SaveFileVersion запоминает предыдущий файл задания перед его заменой
+func (dao DAO) SaveFileVersion(ctx context.Context, version entity.IssuedPracticeFileVersion) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveIssuedPracticeFileVersionDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO issued_practice_file_version 
+    				(issued_practice_id, practice_path, replaced_by, replaced_at) 
+					VALUES 
+					(@IssuedPracticeId, @PracticePath, @ReplacedBy, @ReplacedAt)`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": version.IssuedPracticeId,
+		"PracticePath":     version.Path,
+		"ReplacedBy":       version.ReplacedBy,
+		"ReplacedAt":       version.ReplacedAt,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id задания", args["IssuedPracticeId"].(int)),
+		zap.String("путь к практике", args["PracticePath"].(string)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveIssuedPracticeFileVersionDAO, now)))
+
+	return nil
+}
+
+func (dao DAO) FileVersions(ctx context.Context, practiceId int) ([]entity.IssuedPracticeFileVersion, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectIssuedPracticeFileVersionsDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM issued_practice_file_version 
+                	WHERE issued_practice_id=@IssuedPracticeId ORDER BY replaced_at`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": practiceId,
+	}
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectIssuedPracticeFileVersionsDAO, now)))
+
+	versions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.IssuedPracticeFileVersion])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return versions, nil
+}
@@ -8,12 +8,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, info dto.NewKeyInfo) (entity.Key, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveKeyDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -35,7 +37,7 @@ func (dao DAO) Save(ctx context.Context, info dto.NewKeyInfo) (entity.Key, error
 
 	l.Debug("аргументы запроса",
 		zap.Int("id роли", args["RoleId"].(int)),
-		zap.String("тело ключа", args["Body"].(string)),
+		logger.Fingerprint("тело ключа", args["Body"].(string)),
 		zap.Int("макс. кол-во исп-ий", args["MaxUsages"].(int)),
 		zap.Int("тек. кол-во исп-ий", args["CurrentUsages"].(int)),
 		zap.Time("время создания", args["CreatedAt"].(time.Time)),
@@ -48,10 +50,10 @@ func (dao DAO) Save(ctx context.Context, info dto.NewKeyInfo) (entity.Key, error
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveKeyDAO, now)))
 
 	getQuery := `SELECT * FROM registration_key WHERE reg_key_id=$1`
 
@@ -60,15 +62,15 @@ func (dao DAO) Save(ctx context.Context, info dto.NewKeyInfo) (entity.Key, error
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveKeyDAO, now)))
 
 	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Key])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyRecorded, zap.Int("id ключа", id))
@@ -9,12 +9,14 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.Key, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectKeyById),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -32,15 +34,15 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Key, error) {
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectKeyById, now)))
 
 	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Key])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("id ключа", key.Id))
@@ -49,7 +51,7 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Key, error) {
 }
 
 func (dao DAO) ByBody(ctx context.Context, body string) (entity.Key, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectKeyByBody),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -60,29 +62,29 @@ func (dao DAO) ByBody(ctx context.Context, body string) (entity.Key, error) {
 		"Body": body,
 	}
 
-	l.Debug("аргументы запроса", zap.String("тело ключа", args["Body"].(string)))
+	l.Debug("аргументы запроса", logger.Fingerprint("тело ключа", args["Body"].(string)))
 
 	now := time.Now()
 	rows, err := dao.db.Query(ctx, getQuery, args)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectKeyByBody, now)))
 
 	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Key])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
 	return key, nil
 }
 
 func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Key, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectKeysByParams),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -96,7 +98,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Key, er
 	if err != nil {
 		l.Warn("ошибка подготовки запроса", zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Debug("аргументы запроса",
@@ -109,15 +111,15 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Key, er
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectKeysByParams, now)))
 
 	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Key])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("количество ключей", len(keys)))
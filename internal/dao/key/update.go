@@ -6,16 +6,17 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
-	"log"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Update(ctx context.Context, new entity.KeyUpdate) (entity.Key, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.UpdateKeyDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -28,19 +29,17 @@ func (dao DAO) Update(ctx context.Context, new entity.KeyUpdate) (entity.Key, er
 	updateQuery, args, err := update.PlaceholderFormat(squirrel.Dollar).ToSql()
 	if err != nil {
 		l.Error("ошибка сборки запроса", zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	log.Println(updateQuery)
-
 	now := time.Now()
 	_, err = dao.db.Exec(ctx, updateQuery, args...)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Update, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateKeyDAO, now)))
 
 	getQuery := `SELECT * FROM registration_key WHERE reg_key_id=$1`
 
@@ -49,15 +48,15 @@ func (dao DAO) Update(ctx context.Context, new entity.KeyUpdate) (entity.Key, er
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateKeyDAO, now)))
 
 	updated, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Key])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Key{}, err
+		return entity.Key{}, postgres.MapError(err)
 	}
 
 	return updated, nil
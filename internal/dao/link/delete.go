@@ -0,0 +1,40 @@
+package link
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// DeleteExpired удаляет ссылки, истекшие до before, и возвращает их количество
+func (dao DAO) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeleteExpiredDownloadLinkDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `DELETE FROM download_link WHERE expires_at < @Before`
+
+	args := pgx.NamedArgs{
+		"Before": before,
+	}
+
+	l.Debug("аргументы запроса", zap.Time("время", args["Before"].(time.Time)))
+
+	now := time.Now()
+	tag, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return 0, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Delete, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeleteExpiredDownloadLinkDAO, now)))
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,62 @@
+package link
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewDownloadLink) (entity.DownloadLink, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveDownloadLinkDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO download_link (link_id, account_id, object, resource_id, single_use, expires_at) 
+					VALUES (@LinkId, @AccountId, @Object, @ResourceId, @SingleUse, @ExpiresAt)
+					RETURNING *`
+
+	args := pgx.NamedArgs{
+		"LinkId":     data.Id,
+		"AccountId":  data.AccountId,
+		"Object":     data.Object,
+		"ResourceId": data.ResourceId,
+		"SingleUse":  data.SingleUse,
+		"ExpiresAt":  data.ExpiresAt,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("id ссылки", args["LinkId"].(string)),
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.String("объект", args["Object"].(string)),
+		zap.Int("id записи", args["ResourceId"].(int)),
+		zap.Bool("одноразовая", args["SingleUse"].(bool)),
+		zap.Time("истекает", args["ExpiresAt"].(time.Time)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, insertQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.DownloadLink{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveDownloadLinkDAO, now)))
+
+	link, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DownloadLink])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.DownloadLink{}, postgres.MapError(err)
+	}
+
+	return link, nil
+}
@@ -0,0 +1,48 @@
+package link
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ById возвращает ссылку, если ее нет - pgx.ErrNoRows
+func (dao DAO) ById(ctx context.Context, id string) (entity.DownloadLink, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectDownloadLinkDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM download_link WHERE link_id = @LinkId`
+
+	args := pgx.NamedArgs{
+		"LinkId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.String("id ссылки", args["LinkId"].(string)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.DownloadLink{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectDownloadLinkDAO, now)))
+
+	link, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DownloadLink])
+	if err != nil {
+		l.Warn(operation.CollectError, zap.Error(err))
+		return entity.DownloadLink{}, postgres.MapError(err)
+	}
+
+	return link, nil
+}
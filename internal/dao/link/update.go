@@ -0,0 +1,88 @@
+package link
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// MarkUsed отмечает использование ссылки. Если ссылка уже использована или отозвана - pgx.ErrNoRows,
+// поэтому одноразовую ссылку нельзя использовать дважды даже при одновременных запросах
+func (dao DAO) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.UseDownloadLinkDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE download_link SET used_at = @UsedAt
+					WHERE link_id = @LinkId AND used_at IS NULL AND revoked_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"LinkId": id,
+		"UsedAt": at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("id ссылки", args["LinkId"].(string)),
+		zap.Time("время использования", args["UsedAt"].(time.Time)),
+	)
+
+	now := time.Now()
+	tag, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.UseDownloadLinkDAO, now)))
+
+	if tag.RowsAffected() == 0 {
+		return postgres.MapError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Revoke отзывает ссылку. Если ссылка уже отозвана - pgx.ErrNoRows
+func (dao DAO) Revoke(ctx context.Context, id string, by int, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.RevokeDownloadLinkDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE download_link SET revoked_at = @RevokedAt, revoked_by = @RevokedBy
+					WHERE link_id = @LinkId AND revoked_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"LinkId":    id,
+		"RevokedBy": by,
+		"RevokedAt": at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("id ссылки", args["LinkId"].(string)),
+		zap.Int("id отозвавшего аккаунта", args["RevokedBy"].(int)),
+		zap.Time("время отзыва", args["RevokedAt"].(time.Time)),
+	)
+
+	now := time.Now()
+	tag, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.RevokeDownloadLinkDAO, now)))
+
+	if tag.RowsAffected() == 0 {
+		return postgres.MapError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
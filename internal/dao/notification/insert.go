@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewNotification) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveNotificationDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO notification (account_id, message) VALUES (@AccountId, @Message)`
+
+	args := pgx.NamedArgs{
+		"AccountId": data.AccountId,
+		"Message":   data.Message,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id аккаунта", args["AccountId"].(int)))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveNotificationDAO, now)))
+
+	return nil
+}
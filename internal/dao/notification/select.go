@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ByAccountId возвращает уведомления аккаунта, сначала новые
+func (dao DAO) ByAccountId(ctx context.Context, accountId int, p params.Default) ([]entity.Notification, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectNotificationsByAccountIdDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM notification WHERE account_id=@AccountId 
+                    ORDER BY created_at DESC LIMIT @Limit OFFSET @Offset`
+
+	args := pgx.NamedArgs{
+		"AccountId": accountId,
+		"Limit":     p.Limit,
+		"Offset":    p.Offset,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.Int("лимит", p.Limit),
+		zap.Int("смещение", p.Offset),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectNotificationsByAccountIdDAO, now)))
+
+	notifications, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Notification])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return notifications, nil
+}
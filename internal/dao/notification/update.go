@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// MarkRead отмечает уведомление прочитанным, только если оно принадлежит аккаунту
+func (dao DAO) MarkRead(ctx context.Context, id, accountId int, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.MarkNotificationReadDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE notification SET read_at = @ReadAt 
+                    WHERE notification_id = @NotificationId AND account_id = @AccountId AND read_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"NotificationId": id,
+		"AccountId":      accountId,
+		"ReadAt":         at,
+	}
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.MarkNotificationReadDAO, now)))
+
+	return nil
+}
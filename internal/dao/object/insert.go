@@ -8,12 +8,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, object dto.NewRBACPart) (entity.Object, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveObjectDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -40,10 +42,10 @@ func (dao DAO) Save(ctx context.Context, object dto.NewRBACPart) (entity.Object,
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Object{}, err
+		return entity.Object{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveObjectDAO, now)))
 
 	getQuery := `SELECT * FROM internal_object WHERE internal_object_id=$1`
 
@@ -52,15 +54,15 @@ func (dao DAO) Save(ctx context.Context, object dto.NewRBACPart) (entity.Object,
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Object{}, err
+		return entity.Object{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveObjectDAO, now)))
 
 	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Object])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Object{}, err
+		return entity.Object{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyRecorded, zap.Int("id объекта", id))
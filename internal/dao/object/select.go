@@ -9,12 +9,14 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.Object, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectObjectById),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -32,15 +34,15 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Object, error) {
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Object{}, err
+		return entity.Object{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectObjectById, now)))
 
 	object, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Object])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Object{}, err
+		return entity.Object{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("id действия", object.Id))
@@ -49,7 +51,7 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Object, error) {
 }
 
 func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Object, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectObjectByParams),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -63,7 +65,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Object,
 	if err != nil {
 		l.Warn("ошибка подготовки запроса", zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Debug("аргументы запроса",
@@ -76,15 +78,15 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Object,
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectObjectByParams, now)))
 
 	objects, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Object])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("количество объектов", len(objects)))
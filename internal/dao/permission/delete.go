@@ -0,0 +1,44 @@
+package permission
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// DeleteByRoleObject удаляет все доступы роли к объекту
+func (dao DAO) DeleteByRoleObject(ctx context.Context, roleId, objectId int) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeletePermissionsDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `DELETE FROM role_permission WHERE internal_role_id = @RoleId AND internal_object_id = @ObjectId`
+
+	args := pgx.NamedArgs{
+		"RoleId":   roleId,
+		"ObjectId": objectId,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id роли", args["RoleId"].(int)),
+		zap.Int("id объекта", args["ObjectId"].(int)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Delete, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeletePermissionsDAO, now)))
+
+	return nil
+}
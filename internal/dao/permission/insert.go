@@ -5,12 +5,14 @@ import (
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, roleId, objectId int, actionsId []int) error {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SavePermissionsDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -26,11 +28,11 @@ func (dao DAO) Save(ctx context.Context, roleId, objectId int, actionsId []int)
 		if err != nil {
 			l.Warn(operation.ExecuteError, zap.Error(err))
 
-			return err
+			return postgres.MapError(err)
 		}
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SavePermissionsDAO, now)))
 	l.Info(operation.SuccessfullyRecorded)
 
 	return nil
@@ -7,12 +7,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ByRoleId(ctx context.Context, roleId int) ([]entity.Permissions, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectPermByRoleIdDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -34,16 +36,16 @@ WHERE ir.internal_role_id = $1;`
 	if err != nil {
 		l.Warn(operation.ExecuteError, zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectPermByRoleIdDAO, now)))
 
 	perm, err := pgx.CollectRows(rows, pgx.RowToStructByPos[entity.Permissions])
 	if err != nil {
 		l.Warn(operation.CollectError, zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived)
@@ -9,12 +9,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, data dto.PersonRegistrationData) (entity.Person, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SavePersonDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -35,9 +37,9 @@ func (dao DAO) Save(ctx context.Context, data dto.PersonRegistrationData) (entit
 	l.Debug("аргументы запроса",
 		zap.String("uuid пользователя", args["PersonUUID"].(uuid.UUID).String()),
 		zap.Int("id аккаунта", args["AccountId"].(int)),
-		zap.String("имя", args["FirstName"].(string)),
-		zap.String("фамилия", args["MiddleName"].(string)),
-		zap.String("отчество", args["LastName"].(string)),
+		logger.PII("имя", args["FirstName"].(string)),
+		logger.PII("фамилия", args["MiddleName"].(string)),
+		logger.PII("отчество", args["LastName"].(string)),
 	)
 
 	var personUUID uuid.UUID
@@ -46,10 +48,10 @@ func (dao DAO) Save(ctx context.Context, data dto.PersonRegistrationData) (entit
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&personUUID)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SavePersonDAO, now)))
 
 	selectQuery := `SELECT * FROM person WHERE person_uuid=@PersonUIID`
 
@@ -62,15 +64,15 @@ func (dao DAO) Save(ctx context.Context, data dto.PersonRegistrationData) (entit
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SavePersonDAO, now)))
 
 	person, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Person])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.String("uuid пользователя", person.UUID.String()))
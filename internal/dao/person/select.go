@@ -10,12 +10,14 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ByUUID(ctx context.Context, uid uuid.UUID) (entity.Person, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectPersonByUIIDDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -33,15 +35,15 @@ func (dao DAO) ByUUID(ctx context.Context, uid uuid.UUID) (entity.Person, error)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectPersonByUIIDDAO, now)))
 
 	person, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Person])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.String("uuid пользователя", person.UUID.String()))
@@ -50,7 +52,7 @@ func (dao DAO) ByUUID(ctx context.Context, uid uuid.UUID) (entity.Person, error)
 }
 
 func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Person, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectPersonByUIIDDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -64,7 +66,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Person,
 	if err != nil {
 		l.Warn("ошибка подготовки запроса", zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Debug("аргументы запроса",
@@ -77,15 +79,15 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Person,
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectPersonByUIIDDAO, now)))
 
 	persons, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Person])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("количество пользователей", len(persons)))
@@ -94,7 +96,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Person,
 }
 
 func (dao DAO) ByAccountId(ctx context.Context, id int) (entity.Person, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectPersonByAccIdDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -112,15 +114,15 @@ func (dao DAO) ByAccountId(ctx context.Context, id int) (entity.Person, error) {
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectPersonByAccIdDAO, now)))
 
 	person, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Person])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Person{}, err
+		return entity.Person{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.String("uuid пользователя", person.UUID.String()))
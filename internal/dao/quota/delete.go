@@ -0,0 +1,44 @@
+package quota
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// DeleteQuota снимает квоту, если квоты с таким id нет - pgx.ErrNoRows
+func (dao DAO) DeleteQuota(ctx context.Context, id int) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeleteQuotaDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `DELETE FROM storage_quota WHERE storage_quota_id = @QuotaId`
+
+	args := pgx.NamedArgs{
+		"QuotaId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id квоты", args["QuotaId"].(int)))
+
+	now := time.Now()
+	tag, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Delete, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeleteQuotaDAO, now)))
+
+	if tag.RowsAffected() == 0 {
+		return postgres.MapError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package quota
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// SaveFile учитывает сохраненный файл. Повторное сохранение по тому же пути заменяет запись
+func (dao DAO) SaveFile(ctx context.Context, data dto.NewStoredFile) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveStoredFileDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO stored_file (file_path, account_id, group_name, size_bytes, stored_at) 
+					VALUES (@Path, @AccountId, NULLIF(@GroupName, ''), @Size, @StoredAt)
+					ON CONFLICT (file_path) DO UPDATE 
+					SET account_id = excluded.account_id, group_name = excluded.group_name, 
+					    size_bytes = excluded.size_bytes, stored_at = excluded.stored_at, released_at = NULL`
+
+	args := pgx.NamedArgs{
+		"Path":      data.Path,
+		"AccountId": data.AccountId,
+		"GroupName": data.GroupName,
+		"Size":      data.Size,
+		"StoredAt":  time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("путь к файлу", args["Path"].(string)),
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.Int64("размер", args["Size"].(int64)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveStoredFileDAO, now)))
+
+	return nil
+}
+
+// SaveQuota задает квоту роли или группы, заменяя прежнюю
+func (dao DAO) SaveQuota(ctx context.Context, data dto.SetQuotaReq) (entity.StorageQuota, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveQuotaDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO storage_quota (internal_role_id, limit_bytes, updated_at) 
+					VALUES (@RoleId, @LimitBytes, @UpdatedAt)
+					ON CONFLICT (internal_role_id) DO UPDATE 
+					SET limit_bytes = excluded.limit_bytes, updated_at = excluded.updated_at
+					RETURNING *`
+
+	if data.GroupName != "" {
+		insertQuery = `INSERT INTO storage_quota (group_name, limit_bytes, updated_at) 
+					VALUES (@GroupName, @LimitBytes, @UpdatedAt)
+					ON CONFLICT (group_name) DO UPDATE 
+					SET limit_bytes = excluded.limit_bytes, updated_at = excluded.updated_at
+					RETURNING *`
+	}
+
+	args := pgx.NamedArgs{
+		"RoleId":     data.RoleId,
+		"GroupName":  data.GroupName,
+		"LimitBytes": data.LimitBytes,
+		"UpdatedAt":  time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id роли", args["RoleId"].(int)),
+		zap.String("группа", args["GroupName"].(string)),
+		zap.Int64("лимит", args["LimitBytes"].(int64)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, insertQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.StorageQuota{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveQuotaDAO, now)))
+
+	quota, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.StorageQuota])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.StorageQuota{}, postgres.MapError(err)
+	}
+
+	return quota, nil
+}
@@ -0,0 +1,204 @@
+package quota
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// AccountUsage возвращает место, занятое файлами аккаунта
+func (dao DAO) AccountUsage(ctx context.Context, accountId int) (int64, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectUsageDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT coalesce(sum(size_bytes), 0) FROM stored_file 
+                	WHERE account_id = @AccountId AND released_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"AccountId": accountId,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id аккаунта", args["AccountId"].(int)))
+
+	return dao.usage(ctx, l, operation.SelectUsageDAO, selectQuery, args)
+}
+
+// GroupUsage возвращает место, занятое файлами, загруженными членами группы
+func (dao DAO) GroupUsage(ctx context.Context, groupName string) (int64, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectUsageDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT coalesce(sum(size_bytes), 0) FROM stored_file 
+                	WHERE group_name = @GroupName AND released_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"GroupName": groupName,
+	}
+
+	l.Debug("аргументы запроса", zap.String("группа", args["GroupName"].(string)))
+
+	return dao.usage(ctx, l, operation.SelectUsageDAO, selectQuery, args)
+}
+
+func (dao DAO) usage(ctx context.Context, l *zap.Logger, op string, selectQuery string, args pgx.NamedArgs) (int64, error) {
+	var used int64
+
+	now := time.Now()
+	err := dao.db.QueryRow(ctx, selectQuery, args).Scan(&used)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return 0, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(op, now)))
+
+	return used, nil
+}
+
+// Top возвращает аккаунты или группы, занимающие больше всего места
+func (dao DAO) Top(ctx context.Context, by string, limit int) ([]entity.StorageUsage, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectTopUsageDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT sf.account_id, a.login, NULL::varchar AS group_name, 
+       					sum(sf.size_bytes)::bigint AS used_bytes, count(*)::int AS file_count
+					FROM stored_file sf JOIN account a ON a.account_id = sf.account_id
+					WHERE sf.released_at IS NULL
+					GROUP BY sf.account_id, a.login
+					ORDER BY used_bytes DESC
+					LIMIT @Limit`
+
+	if by == domain.UsageByGroup {
+		selectQuery = `SELECT NULL::int AS account_id, NULL::varchar AS login, group_name, 
+       					sum(size_bytes)::bigint AS used_bytes, count(*)::int AS file_count
+					FROM stored_file
+					WHERE released_at IS NULL AND group_name IS NOT NULL
+					GROUP BY group_name
+					ORDER BY used_bytes DESC
+					LIMIT @Limit`
+	}
+
+	args := pgx.NamedArgs{
+		"Limit": limit,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("разрез", by),
+		zap.Int("лимит", args["Limit"].(int)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectTopUsageDAO, now)))
+
+	usage, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.StorageUsage])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return usage, nil
+}
+
+// QuotaByRole возвращает квоту роли, если квота не задана - pgx.ErrNoRows
+func (dao DAO) QuotaByRole(ctx context.Context, roleId int) (entity.StorageQuota, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectQuotaDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM storage_quota WHERE internal_role_id = @RoleId`
+
+	args := pgx.NamedArgs{
+		"RoleId": roleId,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id роли", args["RoleId"].(int)))
+
+	return dao.quota(ctx, l, operation.SelectQuotaDAO, selectQuery, args)
+}
+
+// QuotaByGroup возвращает квоту группы, если квота не задана - pgx.ErrNoRows
+func (dao DAO) QuotaByGroup(ctx context.Context, groupName string) (entity.StorageQuota, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectQuotaDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM storage_quota WHERE group_name = @GroupName`
+
+	args := pgx.NamedArgs{
+		"GroupName": groupName,
+	}
+
+	l.Debug("аргументы запроса", zap.String("группа", args["GroupName"].(string)))
+
+	return dao.quota(ctx, l, operation.SelectQuotaDAO, selectQuery, args)
+}
+
+func (dao DAO) quota(ctx context.Context, l *zap.Logger, op string, selectQuery string, args pgx.NamedArgs) (entity.StorageQuota, error) {
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.StorageQuota{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(op, now)))
+
+	quota, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.StorageQuota])
+	if err != nil {
+		l.Debug(operation.CollectError, zap.Error(err))
+		return entity.StorageQuota{}, postgres.MapError(err)
+	}
+
+	return quota, nil
+}
+
+func (dao DAO) Quotas(ctx context.Context) ([]entity.StorageQuota, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectQuotaDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM storage_quota ORDER BY internal_role_id NULLS LAST, group_name`
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectQuotaDAO, now)))
+
+	quotas, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.StorageQuota])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return quotas, nil
+}
@@ -0,0 +1,74 @@
+package quota
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// Release исключает файлы из занятого места, сами файлы остаются на диске.
+// Файл, который еще использует не удаленное задание (например, его копия), остается в учете
+func (dao DAO) Release(ctx context.Context, paths []string, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.ReleaseStoredFilesDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE stored_file SET released_at = @ReleasedAt 
+                   	WHERE file_path = ANY(@Paths) AND released_at IS NULL 
+                   	  AND NOT EXISTS (
+                   	      SELECT 1 FROM issued_practice ip 
+                   	      WHERE ip.practice_path = stored_file.file_path AND ip.deleted_at IS NULL
+                   	  )`
+
+	args := pgx.NamedArgs{
+		"Paths":      paths,
+		"ReleasedAt": at,
+	}
+
+	l.Debug("аргументы запроса", zap.Strings("пути к файлам", paths))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.ReleaseStoredFilesDAO, now)))
+
+	return nil
+}
+
+// Restore возвращает файлы в учет занятого места
+func (dao DAO) Restore(ctx context.Context, paths []string) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.RestoreStoredFilesDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE stored_file SET released_at = NULL WHERE file_path = ANY(@Paths)`
+
+	args := pgx.NamedArgs{
+		"Paths": paths,
+	}
+
+	l.Debug("аргументы запроса", zap.Strings("пути к файлам", paths))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.RestoreStoredFilesDAO, now)))
+
+	return nil
+}
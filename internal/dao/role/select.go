@@ -9,12 +9,14 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.Role, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectRoleById),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -32,15 +34,15 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Role, error) {
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.Role{}, err
+		return entity.Role{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectRoleById, now)))
 
 	role, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Role])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.Role{}, err
+		return entity.Role{}, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("id роли", role.Id))
@@ -49,7 +51,7 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.Role, error) {
 }
 
 func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Role, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SelectRoleByParams),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -63,7 +65,7 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Role, e
 	if err != nil {
 		l.Warn("ошибка подготовки запроса", zap.Error(err))
 
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Debug("аргументы запроса",
@@ -76,15 +78,15 @@ func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.Role, e
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectRoleByParams, now)))
 
 	roles, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Role])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return nil, err
+		return nil, postgres.MapError(err)
 	}
 
 	l.Info(operation.SuccessfullyReceived, zap.Int("количество ролей", len(roles)))
@@ -0,0 +1,53 @@
+package scan
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// Save сохраняет вердикт по файлу, заменяя прежний
+func (dao DAO) Save(ctx context.Context, data dto.NewFileScan) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveFileScanDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO file_scan (file_path, is_infected, signature, scanner, scanned_at) 
+					VALUES (@Path, @IsInfected, NULLIF(@Signature, ''), @Scanner, @ScannedAt)
+					ON CONFLICT (file_path) DO UPDATE 
+					SET is_infected = excluded.is_infected, signature = excluded.signature, 
+					    scanner = excluded.scanner, scanned_at = excluded.scanned_at`
+
+	args := pgx.NamedArgs{
+		"Path":       data.Path,
+		"IsInfected": data.IsInfected,
+		"Signature":  data.Signature,
+		"Scanner":    data.Scanner,
+		"ScannedAt":  time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("путь к файлу", args["Path"].(string)),
+		zap.Bool("заражен", args["IsInfected"].(bool)),
+		zap.String("сканер", args["Scanner"].(string)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, insertQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveFileScanDAO, now)))
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package scan
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ByPath возвращает вердикт по файлу, если файл еще не проверялся - pgx.ErrNoRows
+func (dao DAO) ByPath(ctx context.Context, path string) (entity.FileScan, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectFileScanDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM file_scan WHERE file_path=@Path`
+
+	args := pgx.NamedArgs{
+		"Path": path,
+	}
+
+	l.Debug("аргументы запроса", zap.String("путь к файлу", args["Path"].(string)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.FileScan{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectFileScanDAO, now)))
+
+	scan, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.FileScan])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.FileScan{}, postgres.MapError(err)
+	}
+
+	return scan, nil
+}
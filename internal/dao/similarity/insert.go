@@ -0,0 +1,74 @@
+package similarity
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ReplaceForPractice заменяет результаты сравнения работ по заданию новыми в одной транзакции
+func (dao DAO) ReplaceForPractice(ctx context.Context, issuedPracticeId int, pairs []dto.NewSimilarity) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.ReplaceSimilarityDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO 
+    					similarity (issued_practice_id, first_solved_id, second_solved_id, score, is_identical, fragments, computed_at) 
+					VALUES 
+					    (@IssuedPracticeId, @FirstSolvedId, @SecondSolvedId, @Score, @IsIdentical, @Fragments, @ComputedAt)`
+
+	l.Debug("аргументы запроса",
+		zap.Int("id задания", issuedPracticeId),
+		zap.Int("кол-во пар", len(pairs)),
+	)
+
+	now := time.Now()
+
+	tx, err := dao.db.Begin(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM similarity WHERE issued_practice_id = @IssuedPracticeId`,
+		pgx.NamedArgs{"IssuedPracticeId": issuedPracticeId})
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	for _, pair := range pairs {
+		_, err = tx.Exec(ctx, insertQuery, pgx.NamedArgs{
+			"IssuedPracticeId": issuedPracticeId,
+			"FirstSolvedId":    pair.FirstSolvedId,
+			"SecondSolvedId":   pair.SecondSolvedId,
+			"Score":            pair.Score,
+			"IsIdentical":      pair.IsIdentical,
+			"Fragments":        pair.Fragments,
+			"ComputedAt":       pair.ComputedAt,
+		})
+		if err != nil {
+			l.Error(operation.ExecuteError, zap.Error(err))
+			return postgres.MapError(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.ReplaceSimilarityDAO, now)))
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package similarity
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ByIssuedPracticeId возвращает пары работ по заданию со схожестью не ниже minScore, от самых похожих
+func (dao DAO) ByIssuedPracticeId(ctx context.Context, issuedPracticeId int, minScore float64) ([]entity.Similarity, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectSimilarityDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM similarity 
+         			WHERE issued_practice_id = @IssuedPracticeId AND (score >= @MinScore OR is_identical) 
+         			ORDER BY is_identical DESC, score DESC`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": issuedPracticeId,
+		"MinScore":         minScore,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id задания", args["IssuedPracticeId"].(int)),
+		zap.Float64("минимальная схожесть", args["MinScore"].(float64)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectSimilarityDAO, now)))
+
+	pairs, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Similarity])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return pairs, nil
+}
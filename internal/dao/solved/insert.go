@@ -8,20 +8,22 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Save(ctx context.Context, data dto.NewSolvedPractice) (entity.SolvedPractice, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.SaveSolvedPracticeDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
 
 	insertQuery := `INSERT INTO 
-						solved_practice (performed_account_id, issued_practice_id, solved_time, path) 
+						solved_practice (performed_account_id, issued_practice_id, solved_time, path, file_name, group_name, content_hash, duplicate_of) 
 					VALUES 
-					    (@PerformedAccountId, @IssuedPracticeId, @SolvedTime, @Path)
+					    (@PerformedAccountId, @IssuedPracticeId, @SolvedTime, @Path, NULLIF(@FileName, ''), @GroupName, @ContentHash, @DuplicateOf)
 					RETURNING solved_practice_id`
 
 	args := pgx.NamedArgs{
@@ -29,6 +31,10 @@ func (dao DAO) Save(ctx context.Context, data dto.NewSolvedPractice) (entity.Sol
 		"IssuedPracticeId":   data.IssuedPracticeId,
 		"SolvedTime":         data.SolvedTime,
 		"Path":               data.Path,
+		"FileName":           data.FileName,
+		"GroupName":          data.GroupName,
+		"ContentHash":        data.ContentHash,
+		"DuplicateOf":        data.DuplicateOf,
 	}
 
 	l.Debug("аргументы запроса",
@@ -36,6 +42,9 @@ func (dao DAO) Save(ctx context.Context, data dto.NewSolvedPractice) (entity.Sol
 		zap.Int("id решенной практической", args["IssuedPracticeId"].(int)),
 		zap.Time("время загрузки", args["SolvedTime"].(time.Time)),
 		zap.String("путь к практике", args["Path"].(string)),
+		zap.String("имя файла", args["FileName"].(string)),
+		zap.String("группа", args["GroupName"].(string)),
+		zap.String("хэш содержимого", args["ContentHash"].(string)),
 	)
 
 	var solvedPracticeId int
@@ -44,10 +53,10 @@ func (dao DAO) Save(ctx context.Context, data dto.NewSolvedPractice) (entity.Sol
 	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&solvedPracticeId)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Insert, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveSolvedPracticeDAO, now)))
 
 	selectQuery := `SELECT * FROM solved_practice WHERE solved_practice_id=@SolvedPracticeId`
 
@@ -60,15 +69,15 @@ func (dao DAO) Save(ctx context.Context, data dto.NewSolvedPractice) (entity.Sol
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveSolvedPracticeDAO, now)))
 
 	practice, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.SolvedPractice])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
 	return practice, nil
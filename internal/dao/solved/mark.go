@@ -0,0 +1,116 @@
+package solved
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// SetMarks выставляет оценки и записывает их в историю в одной транзакции.
+// Оценка меняется, только если у работы все еще стоит OldMark, иначе транзакция откатывается
+func (dao DAO) SetMarks(ctx context.Context, changes []dto.MarkChange) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SetMarksDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE solved_practice SET mark = @NewMark, mark_time = @ChangedAt 
+                	WHERE solved_practice_id = @SolvedPracticeId AND mark = @OldMark`
+
+	historyQuery := `INSERT INTO 
+    					mark_history (solved_practice_id, old_mark, new_mark, changed_by, changed_at, source) 
+					VALUES 
+					    (@SolvedPracticeId, @OldMark, @NewMark, @ChangedBy, @ChangedAt, @Source)`
+
+	l.Debug("аргументы запроса", zap.Int("кол-во оценок", len(changes)))
+
+	now := time.Now()
+
+	tx, err := dao.db.Begin(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, change := range changes {
+		args := pgx.NamedArgs{
+			"SolvedPracticeId": change.SolvedPracticeId,
+			"OldMark":          change.OldMark,
+			"NewMark":          change.NewMark,
+			"ChangedBy":        change.ChangedBy,
+			"ChangedAt":        change.ChangedAt,
+			"Source":           change.Source,
+		}
+
+		tag, err := tx.Exec(ctx, updateQuery, args)
+		if err != nil {
+			l.Error(operation.ExecuteError, zap.Error(err))
+			return postgres.MapError(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			l.Warn("оценка работы изменилась после проверки", zap.Int("id работы", change.SolvedPracticeId))
+			return domain.ErrMarkChanged
+		}
+
+		_, err = tx.Exec(ctx, historyQuery, args)
+		if err != nil {
+			l.Error(operation.ExecuteError, zap.Error(err))
+			return postgres.MapError(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.SetMarksDAO, now)))
+
+	return nil
+}
+
+// MarkHistory возвращает историю оценок работы от старых к новым
+func (dao DAO) MarkHistory(ctx context.Context, solvedPracticeId int) ([]entity.MarkHistory, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectMarkHistoryDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM mark_history WHERE solved_practice_id = @SolvedPracticeId ORDER BY changed_at`
+
+	args := pgx.NamedArgs{
+		"SolvedPracticeId": solvedPracticeId,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id работы", args["SolvedPracticeId"].(int)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectMarkHistoryDAO, now)))
+
+	history, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.MarkHistory])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return history, nil
+}
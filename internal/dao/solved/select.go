@@ -7,12 +7,14 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) ById(ctx context.Context, id int) (entity.SolvedPractice, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.GetSolvedPracticeInfoByIdDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
@@ -28,16 +30,90 @@ func (dao DAO) ById(ctx context.Context, id int) (entity.SolvedPractice, error)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.GetSolvedPracticeInfoByIdDAO, now)))
 
 	solvedPractice, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.SolvedPractice])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
 	return solvedPractice, nil
 }
+
+// ByIssuedPracticeId возвращает все не удаленные работы по заданию
+func (dao DAO) ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.GetSolvedPracticesByIssuedIdDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM solved_practice 
+              		WHERE issued_practice_id=@IssuedPracticeId AND is_deleted IS NULL 
+              		ORDER BY solved_time`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": issuedPracticeId,
+	}
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.GetSolvedPracticesByIssuedIdDAO, now)))
+
+	practices, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.SolvedPractice])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return practices, nil
+}
+
+// ByContentHash возвращает не удаленные работы по заданию с тем же содержимым файла
+func (dao DAO) ByContentHash(ctx context.Context, issuedPracticeId int, hash string) ([]entity.SolvedPractice, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.GetSolvedPracticesByHashDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM solved_practice 
+              		WHERE issued_practice_id=@IssuedPracticeId AND content_hash=@ContentHash AND is_deleted IS NULL 
+              		ORDER BY solved_time`
+
+	args := pgx.NamedArgs{
+		"IssuedPracticeId": issuedPracticeId,
+		"ContentHash":      hash,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id задания", args["IssuedPracticeId"].(int)),
+		zap.String("хэш содержимого", args["ContentHash"].(string)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.GetSolvedPracticesByHashDAO, now)))
+
+	practices, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.SolvedPractice])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return practices, nil
+}
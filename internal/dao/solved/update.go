@@ -8,34 +8,36 @@ import (
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
-	"practice_vgpek/pkg/timeutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
 	"time"
 )
 
 func (dao DAO) Update(ctx context.Context, practice entity.SolvedPracticeUpdate) (entity.SolvedPractice, error) {
-	l := dao.logger.With(
+	l := logger.FromContext(ctx, dao.logger).With(
 		zap.String(operation.Operation, operation.UpdateSolvedPracticeDAO),
 		zap.String(layer.Layer, layer.DataLayer),
 	)
 
 	update := updateQ("solved_practice", practice)
 
-	update = update.Where("solved_practice_id = $2", practice.Id)
+	update = update.Where(squirrel.Eq{"solved_practice_id": practice.Id})
 
 	updateQuery, args, err := update.PlaceholderFormat(squirrel.Dollar).ToSql()
 	if err != nil {
 		l.Error("ошибка сборки запроса", zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
 	now := time.Now()
 	_, err = dao.db.Exec(ctx, updateQuery, args...)
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Operation, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Operation, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateSolvedPracticeDAO, now)))
 
 	getQuery := `SELECT * FROM solved_practice WHERE solved_practice_id=$1`
 
@@ -44,18 +46,18 @@ func (dao DAO) Update(ctx context.Context, practice entity.SolvedPracticeUpdate)
 	defer rows.Close()
 	if err != nil {
 		l.Error(operation.ExecuteError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	l.Debug(operation.Select, zap.Duration("время выполнения", timeutils.TrackTime(now)))
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateSolvedPracticeDAO, now)))
 
 	updated, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.SolvedPractice])
 	if err != nil {
 		l.Error(operation.CollectError, zap.Error(err))
-		return entity.SolvedPractice{}, err
+		return entity.SolvedPractice{}, postgres.MapError(err)
 	}
 
-	return updated, err
+	return updated, postgres.MapError(err)
 }
 
 func updateQ(table string, newPractice entity.SolvedPracticeUpdate) squirrel.UpdateBuilder {
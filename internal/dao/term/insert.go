@@ -0,0 +1,55 @@
+package term
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewTermReq) (entity.AcademicTerm, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveTermDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO academic_term 
+    				(term_name, start_date, end_date) 
+					VALUES 
+					(@Name, @StartDate, @EndDate)
+					RETURNING academic_term_id`
+
+	args := pgx.NamedArgs{
+		"Name":      data.Name,
+		"StartDate": data.StartDate,
+		"EndDate":   data.EndDate,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("название", args["Name"].(string)),
+		zap.Time("дата начала", args["StartDate"].(time.Time)),
+		zap.Time("дата окончания", args["EndDate"].(time.Time)),
+	)
+
+	var id int
+
+	now := time.Now()
+	err := dao.db.QueryRow(ctx, insertQuery, args).Scan(&id)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.AcademicTerm{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveTermDAO, now)))
+
+	l.Info(operation.SuccessfullyRecorded, zap.Int("id семестра", id))
+
+	return dao.ById(ctx, id)
+}
@@ -0,0 +1,127 @@
+package term
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) ById(ctx context.Context, id int) (entity.AcademicTerm, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectTermById),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM academic_term WHERE academic_term_id=@TermId`
+
+	args := pgx.NamedArgs{
+		"TermId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.Int("id семестра", args["TermId"].(int)))
+
+	return dao.one(ctx, l, operation.SelectTermById, getQuery, args)
+}
+
+func (dao DAO) ByName(ctx context.Context, name string) (entity.AcademicTerm, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectTermByName),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM academic_term WHERE term_name=@Name`
+
+	args := pgx.NamedArgs{
+		"Name": name,
+	}
+
+	l.Debug("аргументы запроса", zap.String("название", args["Name"].(string)))
+
+	return dao.one(ctx, l, operation.SelectTermByName, getQuery, args)
+}
+
+// Current возвращает текущий семестр, если он не задан - pgx.ErrNoRows
+func (dao DAO) Current(ctx context.Context) (entity.AcademicTerm, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectCurrentTerm),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	getQuery := `SELECT * FROM academic_term WHERE is_current`
+
+	return dao.one(ctx, l, operation.SelectCurrentTerm, getQuery, pgx.NamedArgs{})
+}
+
+func (dao DAO) ByParams(ctx context.Context, p params.Default) ([]entity.AcademicTerm, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectTermsByParams),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := squirrel.Select("*").From("academic_term").
+		OrderBy("start_date DESC").
+		Limit(uint64(p.Limit)).
+		Offset(uint64(p.Offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	q, args, err := selectQuery.ToSql()
+	if err != nil {
+		l.Warn("ошибка подготовки запроса", zap.Error(err))
+
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("лимит", p.Limit),
+		zap.Int("смещение", p.Offset),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, q, args...)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectTermsByParams, now)))
+
+	terms, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AcademicTerm])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Info(operation.SuccessfullyReceived, zap.Int("количество семестров", len(terms)))
+
+	return terms, nil
+}
+
+func (dao DAO) one(ctx context.Context, l *zap.Logger, op string, query string, args pgx.NamedArgs) (entity.AcademicTerm, error) {
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, query, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.AcademicTerm{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(op, now)))
+
+	term, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AcademicTerm])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.AcademicTerm{}, postgres.MapError(err)
+	}
+
+	return term, nil
+}
@@ -0,0 +1,90 @@
+package term
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// SetCurrent делает семестр текущим, снимая отметку с предыдущего в одной транзакции
+func (dao DAO) SetCurrent(ctx context.Context, id int) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SetCurrentTermDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	l.Debug("аргументы запроса", zap.Int("id семестра", id))
+
+	now := time.Now()
+
+	tx, err := dao.db.Begin(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `UPDATE academic_term SET is_current = false WHERE is_current`)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE academic_term SET is_current = true WHERE academic_term_id = @TermId`,
+		pgx.NamedArgs{"TermId": id})
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.SetCurrentTermDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
+
+// Close закрывает семестр, после чего его практические и оценки доступны только для чтения
+func (dao DAO) Close(ctx context.Context, id int, at time.Time) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.CloseTermDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE academic_term SET closed_at = @ClosedAt WHERE academic_term_id = @TermId AND closed_at IS NULL`
+
+	args := pgx.NamedArgs{
+		"TermId":   id,
+		"ClosedAt": at,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.Int("id семестра", args["TermId"].(int)),
+		zap.Time("время закрытия", args["ClosedAt"].(time.Time)),
+	)
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.CloseTermDAO, now)))
+
+	l.Info(operation.SuccessfullyUpdated)
+
+	return nil
+}
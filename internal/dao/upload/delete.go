@@ -0,0 +1,73 @@
+package upload
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Delete(ctx context.Context, id string) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeleteUploadSessionDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `DELETE FROM upload_session WHERE upload_id = @UploadId`
+
+	args := pgx.NamedArgs{
+		"UploadId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.String("id загрузки", args["UploadId"].(string)))
+
+	now := time.Now()
+	_, err := dao.db.Exec(ctx, deleteQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Delete, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeleteUploadSessionDAO, now)))
+
+	return nil
+}
+
+// DeleteExpired удаляет загрузки, срок которых истек к at, и возвращает их id
+func (dao DAO) DeleteExpired(ctx context.Context, at time.Time) ([]string, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.DeleteExpiredUploadsDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	deleteQuery := `DELETE FROM upload_session WHERE expires_at < @At RETURNING upload_id`
+
+	args := pgx.NamedArgs{
+		"At": at,
+	}
+
+	l.Debug("аргументы запроса", zap.Time("время", args["At"].(time.Time)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, deleteQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Delete, zap.Duration("время выполнения", metrics.TrackQuery(operation.DeleteExpiredUploadsDAO, now)))
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return nil, postgres.MapError(err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,60 @@
+package upload
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+func (dao DAO) Save(ctx context.Context, data dto.NewUploadSession) (entity.UploadSession, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SaveUploadSessionDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	insertQuery := `INSERT INTO upload_session (upload_id, account_id, file_name, upload_length, expires_at) 
+					VALUES (@UploadId, @AccountId, @FileName, @Length, @ExpiresAt)
+					RETURNING *`
+
+	args := pgx.NamedArgs{
+		"UploadId":  data.Id,
+		"AccountId": data.AccountId,
+		"FileName":  data.FileName,
+		"Length":    data.Length,
+		"ExpiresAt": data.ExpiresAt,
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("id загрузки", args["UploadId"].(string)),
+		zap.Int("id аккаунта", args["AccountId"].(int)),
+		zap.String("имя файла", args["FileName"].(string)),
+		zap.Int64("размер", args["Length"].(int64)),
+		zap.Time("истекает", args["ExpiresAt"].(time.Time)),
+	)
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, insertQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.UploadSession{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Insert, zap.Duration("время выполнения", metrics.TrackQuery(operation.SaveUploadSessionDAO, now)))
+
+	session, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.UploadSession])
+	if err != nil {
+		l.Error(operation.CollectError, zap.Error(err))
+		return entity.UploadSession{}, postgres.MapError(err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,48 @@
+package upload
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// ById возвращает загрузку, если ее нет - pgx.ErrNoRows
+func (dao DAO) ById(ctx context.Context, id string) (entity.UploadSession, error) {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.SelectUploadSessionDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	selectQuery := `SELECT * FROM upload_session WHERE upload_id = @UploadId`
+
+	args := pgx.NamedArgs{
+		"UploadId": id,
+	}
+
+	l.Debug("аргументы запроса", zap.String("id загрузки", args["UploadId"].(string)))
+
+	now := time.Now()
+	rows, err := dao.db.Query(ctx, selectQuery, args)
+	defer rows.Close()
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return entity.UploadSession{}, postgres.MapError(err)
+	}
+
+	l.Debug(operation.Select, zap.Duration("время выполнения", metrics.TrackQuery(operation.SelectUploadSessionDAO, now)))
+
+	session, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.UploadSession])
+	if err != nil {
+		l.Warn(operation.CollectError, zap.Error(err))
+		return entity.UploadSession{}, postgres.MapError(err)
+	}
+
+	return session, nil
+}
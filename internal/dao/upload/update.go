@@ -0,0 +1,52 @@
+package upload
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/postgres"
+	"time"
+)
+
+// UpdateOffset сдвигает смещение загрузки, только если оно еще равно from, иначе - pgx.ErrNoRows
+func (dao DAO) UpdateOffset(ctx context.Context, id string, from, to int64) error {
+	l := logger.FromContext(ctx, dao.logger).With(
+		zap.String(operation.Operation, operation.UpdateUploadOffsetDAO),
+		zap.String(layer.Layer, layer.DataLayer),
+	)
+
+	updateQuery := `UPDATE upload_session SET upload_offset = @To, updated_at = @UpdatedAt
+					WHERE upload_id = @UploadId AND upload_offset = @From`
+
+	args := pgx.NamedArgs{
+		"UploadId":  id,
+		"From":      from,
+		"To":        to,
+		"UpdatedAt": time.Now(),
+	}
+
+	l.Debug("аргументы запроса",
+		zap.String("id загрузки", args["UploadId"].(string)),
+		zap.Int64("прежнее смещение", args["From"].(int64)),
+		zap.Int64("новое смещение", args["To"].(int64)),
+	)
+
+	now := time.Now()
+	tag, err := dao.db.Exec(ctx, updateQuery, args)
+	if err != nil {
+		l.Error(operation.ExecuteError, zap.Error(err))
+		return postgres.MapError(err)
+	}
+
+	l.Debug(operation.Update, zap.Duration("время выполнения", metrics.TrackQuery(operation.UpdateUploadOffsetDAO, now)))
+
+	if tag.RowsAffected() == 0 {
+		return postgres.MapError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
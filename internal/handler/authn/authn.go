@@ -5,6 +5,7 @@ import (
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type PersonService interface {
@@ -31,13 +32,16 @@ type Handler struct {
 	personService PersonService
 	tokenService  TokenService
 	RBACService   RBACService
+
+	timeouts apiutils.Timeouts
 }
 
-func NewAuthenticationHandler(personService PersonService, tokenService TokenService, rbacService RBACService, logger *zap.Logger) Handler {
+func NewAuthenticationHandler(personService PersonService, tokenService TokenService, rbacService RBACService, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	return Handler{
 		logger:        logger,
 		personService: personService,
 		tokenService:  tokenService,
 		RBACService:   rbacService,
+		timeouts:      timeouts,
 	}
 }
@@ -2,9 +2,12 @@ package authn
 
 import (
 	"context"
+	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"strings"
 )
 
@@ -15,7 +18,7 @@ func (h Handler) Identity(next http.Handler) http.Handler {
 		if header == "" {
 			apperr.New(w, r, http.StatusUnauthorized, apperr.AppError{
 				Action: operation.LoginOperation,
-				Error:  "пустой хедер Authorization",
+				Code:   domain.CodeAuthHeaderMissing,
 			})
 			return
 		}
@@ -24,21 +27,19 @@ func (h Handler) Identity(next http.Handler) http.Handler {
 		if len(headerParts) != 2 {
 			apperr.New(w, r, http.StatusUnauthorized, apperr.AppError{
 				Action: operation.LoginOperation,
-				Error:  "невалидный хедер Authorization",
+				Code:   domain.CodeAuthHeaderInvalid,
 			})
 			return
 		}
 
 		id, err := h.tokenService.ParseToken(r.Context(), headerParts[1])
 		if err != nil {
-			apperr.New(w, r, http.StatusUnauthorized, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  err.Error(),
-			})
+			apperr.Write(w, r, operation.LoginOperation, err)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), "AccountId", id)
+		ctx = logger.WithFields(ctx, zap.Int(operation.AccountId, id))
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
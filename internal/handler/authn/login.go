@@ -12,7 +12,8 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
 )
 
 // @Summary		Авторизация
@@ -26,12 +27,12 @@ import (
 // @Failure		default	{object}	apperr.AppError
 // @Router			/login [post]
 func (h Handler) Login(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 30000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var cred dto.Credentials
 
-	l := h.logger.With(
+	l := logger.FromContext(ctx, h.logger).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.LoginOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -43,85 +44,47 @@ func (h Handler) Login(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.LoginOperation,
-			Error:  "Преобразование запроса на вход",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	l.Info("попытка входа",
-		zap.String("логин", cred.Login),
-		zap.String("пароль", cred.Password),
-	)
+	l.Info("попытка входа", logger.PII("логин", cred.Login))
 
 	token, err := h.tokenService.CreateToken(ctx, cred)
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+	if errors.Is(err, apperr.ErrUnauthenticated) {
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
+
+		apperr.Write(w, r, operation.LoginOperation, err)
+		return
+	} else if err != nil {
+		metrics.Logins.WithLabelValues(metrics.LoginError).Inc()
+
+		apperr.Write(w, r, operation.LoginOperation, err)
+		return
 	}
 
 	accountId, err := h.tokenService.ParseToken(ctx, token)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.LoginOperation, err)
+		return
 	}
 
 	account, err := h.personService.AccountById(ctx, dto.EntityId{Id: accountId})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.LoginOperation, err)
+		return
 	}
 
 	role, err := h.RBACService.RoleById(ctx, dto.EntityId{Id: account.RoleId})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.LoginOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.LoginOperation, err)
+		return
 	}
 
-	l.Info("пользователь успешно вошел", zap.String("логин", cred.Login))
+	metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
+
+	l.Info("пользователь успешно вошел", logger.PII("логин", cred.Login))
 
 	render.JSON(w, r, rest.Token{}.TokenToResponse(token, role))
 	return
@@ -3,16 +3,16 @@ package authn
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
 )
 
 // @Summary		Регистрация пользователя
@@ -25,10 +25,10 @@ import (
 // @Success		200					{object}	domain.Person		"Пользователь и его созданный аккаунт"
 // @Router			/sign-in [post]
 func (h Handler) Registration(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 50000000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.logger.With(
+	l := logger.FromContext(ctx, h.logger).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.RegistrationReq),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -44,32 +44,26 @@ func (h Handler) Registration(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.RegistrationReq,
-			Error:  "Неизвестная ошибка декодирования запроса",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	err = checkInput(req)
 	if err != nil {
-		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
-			Action: operation.RegistrationReq,
-			Error:  "Ошибка запроса: " + err.Error(),
-		})
+		apperr.Write(w, r, operation.RegistrationReq, err)
 		return
 	}
 
 	user, err := h.personService.NewUser(ctx, req)
 	if err != nil {
-		apperr.New(w, r, http.StatusUnprocessableEntity, apperr.AppError{
-			Action: operation.RegistrationReq,
-			Error:  err.Error(),
-		})
+		apperr.Write(w, r, operation.RegistrationReq, err)
 		return
 	}
 
 	l.Info("пользователь успешно зарегистрирован",
 		zap.String("UUID пользователя", user.UUID.String()),
-		zap.String("логин аккаунта", user.Account.Login),
+		logger.PII("логин аккаунта", user.Account.Login),
 	)
 
 	render.JSON(w, r, rest.Person{}.DomainToResponse(user))
@@ -79,24 +73,24 @@ func (h Handler) Registration(w http.ResponseWriter, r *http.Request) {
 
 // checkInput проверяет, что все необходимые поля заданны
 func checkInput(req dto.RegistrationReq) error {
-	var errMsg string
+	var field string
 
 	switch {
 	case req.Login == "":
-		errMsg = "пустой логин"
+		field = "login"
 	case req.Password == "":
-		errMsg = "пустой пароль"
+		field = "password"
 	case req.FirstName == "":
-		errMsg = "пустое имя"
+		field = "first_name"
 	case req.SecondName == "":
-		errMsg = "пустая фамилия"
+		field = "second_name"
 	case req.BodyKey == "":
-		errMsg = "пустой ключ регистрации"
+		field = "registration_key"
 	}
 
-	if errMsg == "" {
+	if field == "" {
 		return nil
 	}
 
-	return fmt.Errorf(errMsg)
+	return apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": field})
 }
@@ -0,0 +1,123 @@
+package discipline
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+)
+
+func (h Handler) AddDiscipline(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.AddDisciplineOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	var req dto.NewDisciplineReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.AddDisciplineOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.DisciplineObject, domain.AddAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddDisciplineOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddDisciplineOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	discipline, err := h.s.NewDiscipline(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.AddDisciplineOperation, err)
+		return
+	}
+
+	l.Info("дисциплина успешно добавлена", zap.String("название", discipline.Name))
+
+	render.JSON(w, r, rest.Discipline{}.DomainToResponse(discipline))
+	return
+}
+
+func (h Handler) AddAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.AddAssignmentOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	var req dto.NewAssignmentReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.AddAssignmentOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	// Назначать преподавателей может только администратор
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.DisciplineObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddAssignmentOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddAssignmentOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	assignment, err := h.s.NewAssignment(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.AddAssignmentOperation, err)
+		return
+	}
+
+	l.Info("преподаватель успешно назначен", zap.Int("id назначения", assignment.Id))
+
+	render.JSON(w, r, rest.TeachingAssignment{}.DomainToResponse(assignment))
+	return
+}
@@ -0,0 +1,116 @@
+package discipline
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+func (h Handler) DeleteDiscipline(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.DeleteDisciplineOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.DeleteDisciplineOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.DisciplineObject, domain.DeleteAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DeleteDisciplineOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DeleteDisciplineOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	discipline, err := h.s.DeleteDisciplineById(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.DeleteDisciplineOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.Discipline{}.DomainToResponse(discipline))
+	return
+}
+
+func (h Handler) DeleteAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.DeleteAssignmentOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.DeleteAssignmentOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.DisciplineObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DeleteAssignmentOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DeleteAssignmentOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	assignment, err := h.s.DeleteAssignmentById(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.DeleteAssignmentOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.TeachingAssignment{}.DomainToResponse(assignment))
+	return
+}
@@ -0,0 +1,43 @@
+package discipline
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
+)
+
+type Service interface {
+	NewDiscipline(ctx context.Context, req dto.NewDisciplineReq) (domain.Discipline, error)
+	DisciplinesByParams(ctx context.Context, p params.State) ([]domain.Discipline, error)
+	DisciplinesByAccountId(ctx context.Context, req dto.EntityId) ([]domain.Discipline, error)
+	DeleteDisciplineById(ctx context.Context, req dto.EntityId) (domain.Discipline, error)
+
+	NewAssignment(ctx context.Context, req dto.NewAssignmentReq) (domain.TeachingAssignment, error)
+	AssignmentsByAccountId(ctx context.Context, req dto.EntityId) ([]domain.TeachingAssignment, error)
+	DeleteAssignmentById(ctx context.Context, req dto.EntityId) (domain.TeachingAssignment, error)
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
+}
+
+func NewDisciplineHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:               logger,
+		s:               service,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
+	}
+}
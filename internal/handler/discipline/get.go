@@ -0,0 +1,156 @@
+package discipline
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/queryutils"
+	"strconv"
+)
+
+// GetDisciplines отдает все дисциплины администратору, а преподавателю - только те, которые он ведет
+func (h Handler) GetDisciplines(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetDisciplinesOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, accountId, domain.DisciplineObject, domain.GetAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetDisciplinesOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetDisciplinesOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	canEdit, err := h.accountMediator.HasAccess(ctx, accountId, domain.DisciplineObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetDisciplinesOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	var disciplines []domain.Discipline
+
+	if canEdit {
+		defaultParams, err := queryutils.DefaultParams(r, 10, 0)
+		if err != nil {
+			l.Warn("ошибка получение параметров запроса", zap.Error(err))
+
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action: operation.GetDisciplinesOperation,
+				Code:   domain.CodeBadParams,
+			})
+			return
+		}
+
+		disciplines, err = h.s.DisciplinesByParams(ctx, queryutils.StateParams(r, defaultParams))
+	} else {
+		disciplines, err = h.s.DisciplinesByAccountId(ctx, dto.EntityId{Id: accountId})
+	}
+
+	if err != nil {
+		apperr.Write(w, r, operation.GetDisciplinesOperation, err)
+		return
+	}
+
+	l.Info("дисциплины успешно отданы", zap.Int("кол-во", len(disciplines)))
+
+	render.JSON(w, r, rest.Disciplines{}.DomainToResponse(disciplines))
+	return
+}
+
+// GetAssignments отдает назначения текущего преподавателя,
+// администратор может запросить назначения любого аккаунта через account_id
+func (h Handler) GetAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetAssignmentsOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+	targetId := accountId
+
+	if raw := r.URL.Query().Get("account_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			l.Warn(operation.DecodeError, zap.Error(err))
+
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action: operation.GetAssignmentsOperation,
+				Code:   domain.CodeBadParams,
+			})
+			return
+		}
+
+		targetId = id
+	}
+
+	action := domain.GetAction
+	if targetId != accountId {
+		action = domain.EditAction
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, accountId, domain.DisciplineObject, action)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetAssignmentsOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetAssignmentsOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	assignments, err := h.s.AssignmentsByAccountId(ctx, dto.EntityId{Id: targetId})
+	if err != nil {
+		apperr.Write(w, r, operation.GetAssignmentsOperation, err)
+		return
+	}
+
+	l.Info("назначения успешно отданы", zap.Int("кол-во", len(assignments)))
+
+	render.JSON(w, r, rest.TeachingAssignments{}.DomainToResponse(assignments))
+	return
+}
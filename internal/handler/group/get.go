@@ -0,0 +1,79 @@
+package group
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"time"
+)
+
+func (h Handler) Members(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetGroupMembersOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	req := dto.GroupMembersReq{
+		GroupName: r.URL.Query().Get("name"),
+		At:        time.Now(),
+	}
+
+	// Состав группы можно получить на любую дату в прошлом, например для исторических отчетов
+	if at := r.URL.Query().Get("at"); at != "" {
+		parsed, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			l.Warn("ошибка получения параметров запроса", zap.Error(err))
+
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action:  operation.GetGroupMembersOperation,
+				Code:    domain.CodeInvalidDate,
+				Details: map[string]any{"field": "at", "format": "RFC3339"},
+			})
+			return
+		}
+
+		req.At = parsed
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.AccountObject, domain.GetAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetGroupMembersOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetGroupMembersOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	members, err := h.s.MembersByGroup(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.GetGroupMembersOperation, err)
+		return
+	}
+
+	l.Info("состав группы успешно отдан", zap.Int("кол-во", len(members)))
+
+	render.JSON(w, r, rest.GroupMemberships{}.DomainToResponse(members))
+	return
+}
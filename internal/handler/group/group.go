@@ -0,0 +1,38 @@
+package group
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/apiutils"
+)
+
+type Service interface {
+	Transfer(ctx context.Context, req dto.TransferReq) ([]domain.GroupMembership, error)
+	PromoteCohort(ctx context.Context, req dto.PromoteCohortReq) (domain.Cohort, error)
+
+	MembersByGroup(ctx context.Context, req dto.GroupMembersReq) ([]domain.GroupMembership, error)
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
+}
+
+func NewGroupHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:               logger,
+		s:               service,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
+	}
+}
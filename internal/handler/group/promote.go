@@ -0,0 +1,76 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+)
+
+func (h Handler) PromoteCohort(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Long)
+	defer cancel()
+
+	var req dto.PromoteCohortReq
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.PromoteCohortOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.PromoteCohortOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.GroupObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.PromoteCohortOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.PromoteCohortOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	l.Info("попытка перевести группу",
+		zap.String("группа", req.GroupName),
+		zap.String("новая группа", req.NewGroupName),
+		zap.Bool("выпуск", req.Graduate),
+	)
+
+	cohort, err := h.s.PromoteCohort(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.PromoteCohortOperation, err)
+		return
+	}
+
+	l.Info("группа успешно переведена", zap.Int("кол-во студентов", len(cohort.Members)))
+
+	render.JSON(w, r, rest.Cohort{}.DomainToResponse(cohort))
+	return
+}
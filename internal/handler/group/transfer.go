@@ -0,0 +1,75 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+)
+
+func (h Handler) Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Long)
+	defer cancel()
+
+	var req dto.TransferReq
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.TransferAccountsOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.TransferAccountsOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.GroupObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.TransferAccountsOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.TransferAccountsOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	l.Info("попытка перевести аккаунты",
+		zap.Ints("id аккаунтов", req.AccountIds),
+		zap.String("группа", req.GroupName),
+	)
+
+	memberships, err := h.s.Transfer(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.TransferAccountsOperation, err)
+		return
+	}
+
+	l.Info("аккаунты успешно переведены", zap.Int("кол-во", len(memberships)))
+
+	render.JSON(w, r, rest.GroupMemberships{}.DomainToResponse(memberships))
+	return
+}
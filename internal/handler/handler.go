@@ -6,13 +6,23 @@ import (
 	"net/http"
 	_ "practice_vgpek/docs" // docs are generated by Swag CLI, you have to import it.
 	"practice_vgpek/internal/handler/authn"
+	"practice_vgpek/internal/handler/discipline"
+	"practice_vgpek/internal/handler/group"
+	"practice_vgpek/internal/handler/health"
 	"practice_vgpek/internal/handler/issued_practice"
+	"practice_vgpek/internal/handler/link"
+	"practice_vgpek/internal/handler/notification"
+	"practice_vgpek/internal/handler/plagiarism"
+	"practice_vgpek/internal/handler/quota"
 	"practice_vgpek/internal/handler/rbac"
 	"practice_vgpek/internal/handler/reg_key"
 	"practice_vgpek/internal/handler/solved_practice"
+	"practice_vgpek/internal/handler/term"
+	"practice_vgpek/internal/handler/upload"
 	"practice_vgpek/internal/handler/user"
 	"practice_vgpek/internal/mediator/account"
 	"practice_vgpek/internal/service"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type AuthnHandler interface {
@@ -47,6 +57,7 @@ type RBACHandler interface {
 	DeleteRole(w http.ResponseWriter, r *http.Request)
 
 	AddPermission(w http.ResponseWriter, r *http.Request)
+	ReplacePermission(w http.ResponseWriter, r *http.Request)
 
 	GetActions(w http.ResponseWriter, r *http.Request)
 	GetAction(w http.ResponseWriter, r *http.Request)
@@ -63,16 +74,98 @@ type IssuedPracticeHandler interface {
 
 	PracticeById(w http.ResponseWriter, r *http.Request)
 	PracticeByParams(w http.ResponseWriter, r *http.Request)
+	Search(w http.ResponseWriter, r *http.Request)
 
 	Download(w http.ResponseWriter, r *http.Request)
+	Preview(w http.ResponseWriter, r *http.Request)
+
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+
+	Clone(w http.ResponseWriter, r *http.Request)
+	CloneDiscipline(w http.ResponseWriter, r *http.Request)
+
+	Submissions(w http.ResponseWriter, r *http.Request)
 }
 
 type SolvedPracticeHandler interface {
 	Upload(w http.ResponseWriter, r *http.Request)
 
 	PracticeById(w http.ResponseWriter, r *http.Request)
+	Download(w http.ResponseWriter, r *http.Request)
+	Preview(w http.ResponseWriter, r *http.Request)
 
 	SetMark(w http.ResponseWriter, r *http.Request)
+	ImportMarks(w http.ResponseWriter, r *http.Request)
+	MarkHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type GroupHandler interface {
+	Transfer(w http.ResponseWriter, r *http.Request)
+	PromoteCohort(w http.ResponseWriter, r *http.Request)
+
+	Members(w http.ResponseWriter, r *http.Request)
+}
+
+type DisciplineHandler interface {
+	AddDiscipline(w http.ResponseWriter, r *http.Request)
+	DeleteDiscipline(w http.ResponseWriter, r *http.Request)
+	GetDisciplines(w http.ResponseWriter, r *http.Request)
+
+	AddAssignment(w http.ResponseWriter, r *http.Request)
+	DeleteAssignment(w http.ResponseWriter, r *http.Request)
+	GetAssignments(w http.ResponseWriter, r *http.Request)
+}
+
+type TermHandler interface {
+	AddTerm(w http.ResponseWriter, r *http.Request)
+
+	GetCurrentTerm(w http.ResponseWriter, r *http.Request)
+	GetTerms(w http.ResponseWriter, r *http.Request)
+
+	SetCurrentTerm(w http.ResponseWriter, r *http.Request)
+	CloseTerm(w http.ResponseWriter, r *http.Request)
+}
+
+type NotificationHandler interface {
+	GetNotifications(w http.ResponseWriter, r *http.Request)
+	ReadNotification(w http.ResponseWriter, r *http.Request)
+}
+
+type PlagiarismHandler interface {
+	Report(w http.ResponseWriter, r *http.Request)
+	Reanalyze(w http.ResponseWriter, r *http.Request)
+}
+
+type QuotaHandler interface {
+	SetQuota(w http.ResponseWriter, r *http.Request)
+	GetQuotas(w http.ResponseWriter, r *http.Request)
+	DeleteQuota(w http.ResponseWriter, r *http.Request)
+
+	GetUsage(w http.ResponseWriter, r *http.Request)
+	TopConsumers(w http.ResponseWriter, r *http.Request)
+}
+
+type UploadHandler interface {
+	Options(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Head(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type LinkHandler interface {
+	IssuedPractice(w http.ResponseWriter, r *http.Request)
+	SolvedPractice(w http.ResponseWriter, r *http.Request)
+
+	Revoke(w http.ResponseWriter, r *http.Request)
+}
+
+type HealthHandler interface {
+	Healthz(w http.ResponseWriter, r *http.Request)
+	Readyz(w http.ResponseWriter, r *http.Request)
+	Version(w http.ResponseWriter, r *http.Request)
 }
 
 type Handler struct {
@@ -81,31 +174,61 @@ type Handler struct {
 	AuthnHandler
 
 	UserHandler
+	GroupHandler
 
 	KeyHandler
 
 	RBACHandler
 
+	DisciplineHandler
+	TermHandler
+
+	NotificationHandler
+
 	IssuedPracticeHandler
 	SolvedPracticeHandler
+	PlagiarismHandler
+
+	QuotaHandler
+	UploadHandler
+	LinkHandler
+
+	HealthHandler
 }
 
-func New(service service.Service, logger *zap.Logger) Handler {
+// New создает обработчики запросов. timeouts ограничивают время обработки запроса по его виду
+func New(service service.Service, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	accountMediator := account.NewAccountMediator(service.PersonService, service.KeyService, service.RBACService, service.RBACService)
 	return Handler{
 		l:                     logger,
-		AuthnHandler:          authn.NewAuthenticationHandler(service.PersonService, service.TokenService, service.RBACService, logger),
-		KeyHandler:            reg_key.NewKeyHandler(service.KeyService, accountMediator, logger),
-		RBACHandler:           rbac.NewAccessHandler(service.RBACService, accountMediator, logger),
-		IssuedPracticeHandler: issued_practice.NewIssuedPracticeHandler(service.IssuedPracticeService, logger),
-		SolvedPracticeHandler: solved_practice.NewCompletedPracticeHandler(service.SolvedPracticeService, logger),
-		UserHandler:           user.New(service.PersonService, service.PersonService, accountMediator, logger),
+		AuthnHandler:          authn.NewAuthenticationHandler(service.PersonService, service.TokenService, service.RBACService, timeouts, logger),
+		KeyHandler:            reg_key.NewKeyHandler(service.KeyService, accountMediator, timeouts, logger),
+		RBACHandler:           rbac.NewAccessHandler(service.RBACService, accountMediator, timeouts, logger),
+		IssuedPracticeHandler: issued_practice.NewIssuedPracticeHandler(service.IssuedPracticeService, service.UploadService, service.LinkService, accountMediator, timeouts, logger),
+		SolvedPracticeHandler: solved_practice.NewCompletedPracticeHandler(service.SolvedPracticeService, service.UploadService, service.LinkService, accountMediator, timeouts, logger),
+		UserHandler:           user.New(service.PersonService, service.PersonService, accountMediator, timeouts, logger),
+		GroupHandler:          group.NewGroupHandler(service.GroupService, accountMediator, timeouts, logger),
+		DisciplineHandler:     discipline.NewDisciplineHandler(service.DisciplineService, accountMediator, timeouts, logger),
+		TermHandler:           term.NewTermHandler(service.TermService, accountMediator, timeouts, logger),
+		NotificationHandler:   notification.NewNotificationHandler(service.NotificationService, timeouts, logger),
+		PlagiarismHandler:     plagiarism.NewPlagiarismHandler(service.PlagiarismService, accountMediator, timeouts, logger),
+		QuotaHandler:          quota.NewQuotaHandler(service.QuotaService, accountMediator, timeouts, logger),
+		UploadHandler:         upload.NewUploadHandler(service.UploadService, timeouts, logger),
+		LinkHandler:           link.NewLinkHandler(service.LinkService, service.IssuedPracticeService, service.SolvedPracticeService, timeouts, logger),
+		HealthHandler:         health.NewHealthHandler(service.HealthService, logger),
 	}
 }
 
 func (h Handler) Init() *chi.Mux {
 	r := chi.NewRouter()
 
+	r.Use(h.RequestId, h.Tracing, h.Metrics, h.AccessLog, h.Recover)
+
+	// Пробы для оркестратора и docker-compose, без авторизации
+	r.Get("/healthz", h.HealthHandler.Healthz)
+	r.Get("/readyz", h.HealthHandler.Readyz)
+	r.Get("/version", h.HealthHandler.Version)
+
 	r.Route("/person", func(r chi.Router) {
 		r.Post("/", h.AuthnHandler.Registration)
 
@@ -122,6 +245,80 @@ func (h Handler) Init() *chi.Mux {
 		})
 	})
 
+	r.Route("/group", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
+		r.Get("/", h.GroupHandler.Members)
+
+		r.Post("/transfer", h.GroupHandler.Transfer)
+		r.Post("/promote", h.GroupHandler.PromoteCohort)
+	})
+
+	r.Route("/discipline", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
+		r.Post("/", h.DisciplineHandler.AddDiscipline)
+		r.Get("/params", h.DisciplineHandler.GetDisciplines)
+		r.Delete("/", h.DisciplineHandler.DeleteDiscipline)
+
+		r.Post("/assignment", h.DisciplineHandler.AddAssignment)
+		r.Get("/assignment", h.DisciplineHandler.GetAssignments)
+		r.Delete("/assignment", h.DisciplineHandler.DeleteAssignment)
+	})
+
+	r.Route("/term", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
+		r.Post("/", h.TermHandler.AddTerm)
+
+		r.Get("/current", h.TermHandler.GetCurrentTerm)
+		r.Get("/params", h.TermHandler.GetTerms)
+
+		r.Post("/current", h.TermHandler.SetCurrentTerm)
+		r.Post("/close", h.TermHandler.CloseTerm)
+	})
+
+	r.Route("/notification", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
+		r.Get("/", h.NotificationHandler.GetNotifications)
+		r.Post("/read", h.NotificationHandler.ReadNotification)
+	})
+
+	r.Route("/storage", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
+		r.Get("/usage", h.QuotaHandler.GetUsage)
+		r.Get("/usage/top", h.QuotaHandler.TopConsumers)
+
+		r.Post("/quota", h.QuotaHandler.SetQuota)
+		r.Get("/quota", h.QuotaHandler.GetQuotas)
+		r.Delete("/quota", h.QuotaHandler.DeleteQuota)
+	})
+
+	// Возобновляемая загрузка больших файлов по протоколу tus, готовая загрузка передается в форму как upload_id
+	r.Route("/upload", func(r chi.Router) {
+		r.Options("/", h.UploadHandler.Options)
+
+		r.Group(func(r chi.Router) {
+			r.Use(h.AuthnHandler.Identity)
+
+			r.Post("/", h.UploadHandler.Create)
+
+			r.Head("/{id}", h.UploadHandler.Head)
+			r.Patch("/{id}", h.UploadHandler.Patch)
+			r.Delete("/{id}", h.UploadHandler.Delete)
+		})
+	})
+
+	// Скачивание по подписанным ссылкам без заголовка Authorization, ссылки выдаются вместе с данными практических
+	r.Route("/link", func(r chi.Router) {
+		r.Get("/issued", h.LinkHandler.IssuedPractice)
+		r.Get("/solved", h.LinkHandler.SolvedPractice)
+
+		r.With(h.AuthnHandler.Identity).Delete("/", h.LinkHandler.Revoke)
+	})
+
 	r.Route("/login", func(r chi.Router) {
 		r.Post("/", h.AuthnHandler.Login)
 	})
@@ -170,7 +367,10 @@ func (h Handler) Init() *chi.Mux {
 	})
 
 	r.Route("/permissions", func(r chi.Router) {
+		r.Use(h.AuthnHandler.Identity)
+
 		r.Post("/", h.RBACHandler.AddPermission)
+		r.Put("/", h.RBACHandler.ReplacePermission)
 	})
 
 	r.Route("/practice", func(r chi.Router) {
@@ -181,8 +381,21 @@ func (h Handler) Init() *chi.Mux {
 
 			r.Get("/", h.IssuedPracticeHandler.PracticeById)
 			r.Get("/download", h.IssuedPracticeHandler.Download)
+			r.Get("/preview", h.IssuedPracticeHandler.Preview)
 			r.Get("/params", h.IssuedPracticeHandler.PracticeByParams)
+			r.Get("/search", h.IssuedPracticeHandler.Search)
+
+			r.Patch("/", h.IssuedPracticeHandler.Update)
+			r.Delete("/", h.IssuedPracticeHandler.Delete)
+			r.Post("/restore", h.IssuedPracticeHandler.Restore)
+
+			r.Post("/clone", h.IssuedPracticeHandler.Clone)
+			r.Post("/clone/discipline", h.IssuedPracticeHandler.CloneDiscipline)
+
+			r.Get("/{id}/submissions.zip", h.IssuedPracticeHandler.Submissions)
 
+			r.Get("/{id}/similarity", h.PlagiarismHandler.Report)
+			r.Post("/{id}/similarity", h.PlagiarismHandler.Reanalyze)
 		})
 		r.Route("/solved", func(r chi.Router) {
 			r.Use(h.AuthnHandler.Identity)
@@ -190,8 +403,12 @@ func (h Handler) Init() *chi.Mux {
 			r.Post("/", h.SolvedPracticeHandler.Upload)
 
 			r.Post("/mark", h.SolvedPracticeHandler.SetMark)
+			r.Post("/mark/import", h.SolvedPracticeHandler.ImportMarks)
+			r.Get("/mark/history", h.SolvedPracticeHandler.MarkHistory)
 
 			r.Get("/", h.SolvedPracticeHandler.PracticeById)
+			r.Get("/download", h.SolvedPracticeHandler.Download)
+			r.Get("/preview", h.SolvedPracticeHandler.Preview)
 		})
 	})
 
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/transport/rest"
+	"time"
+)
+
+// checkTimeout ограничивает проверки готовности, чтобы зависшая база не задерживала ответ пробе
+const checkTimeout = 2 * time.Second
+
+type Service interface {
+	Readiness(ctx context.Context) domain.Readiness
+	Version(ctx context.Context) domain.BuildInfo
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+}
+
+func NewHealthHandler(service Service, logger *zap.Logger) Handler {
+	return Handler{
+		l: logger,
+		s: service,
+	}
+}
+
+// Healthz отвечает, пока процесс жив и обрабатывает запросы, зависимости не проверяются
+func (h Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, rest.Health{Status: rest.StatusOk})
+}
+
+// Readyz отвечает 503, если хотя бы одна проверка готовности не пройдена, чтобы балансировщик
+// не направлял запросы в экземпляр без базы данных или с непримененными миграциями
+func (h Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	readiness := h.s.Readiness(ctx)
+
+	if !readiness.Ready() {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+
+	render.JSON(w, r, rest.Readiness{}.DomainToResponse(readiness))
+}
+
+// Version отдает коммит и время сборки, версию Go и версию миграций базы данных
+func (h Handler) Version(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	render.JSON(w, r, rest.Version{}.DomainToResponse(h.s.Version(ctx)))
+}
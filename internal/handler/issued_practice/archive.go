@@ -0,0 +1,85 @@
+package issued_practice
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"strconv"
+)
+
+func (h Handler) Submissions(w http.ResponseWriter, r *http.Request) {
+	// Архив пишется по мере чтения файлов, поэтому таймаут рассчитан на выгрузку целой группы
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.DownloadSubmissionsArchive),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		l.Warn("ошибка декодирования данных", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.DownloadSubmissionsArchive,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int),
+		domain.SolvedPracticeObject, domain.GetAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DownloadSubmissionsArchive,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.DownloadSubmissionsArchive,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	archive, err := h.s.Submissions(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.DownloadSubmissionsArchive, err)
+		return
+	}
+
+	// Размер архива заранее неизвестен, ответ уходит частями без Content-Length
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="practice_%d_submissions.zip"`, id))
+	w.Header().Set("Cache-Control", "private")
+	w.Header().Set("Pragma", "private")
+	w.WriteHeader(http.StatusOK)
+
+	// После начала передачи ответить ошибкой уже нельзя, обрыв архива виден клиенту
+	err = h.s.WriteSubmissionsArchive(ctx, archive, metrics.NewCountingWriter(w, metrics.FilesSubmissionZip))
+	if err != nil {
+		l.Warn("ошибка выдачи архива", zap.Error(err))
+		return
+	}
+
+	l.Info("архив работ выгружен",
+		zap.Int("id задания", id),
+		zap.Int("кол-во работ", len(archive.Submissions)),
+	)
+}
@@ -0,0 +1,115 @@
+package issued_practice
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+)
+
+func (h Handler) Clone(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.CloneIssuedPractice),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	var req dto.ClonePracticeReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.CloneIssuedPractice,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !h.canIssue(w, r, l, operation.CloneIssuedPractice) {
+		return
+	}
+
+	practice, err := h.s.Clone(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.CloneIssuedPractice, err)
+		return
+	}
+
+	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice))
+	return
+}
+
+func (h Handler) CloneDiscipline(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Long)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.CloneDisciplinePractices),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	var req dto.CloneDisciplineReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.CloneDisciplinePractices,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !h.canIssue(w, r, l, operation.CloneDisciplinePractices) {
+		return
+	}
+
+	practices, err := h.s.CloneDiscipline(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.CloneDisciplinePractices, err)
+		return
+	}
+
+	l.Info("задания дисциплины склонированы", zap.Int("кол-во", len(practices)))
+
+	render.JSON(w, r, rest.IssuedPractices{}.DomainToResponse(practices))
+	return
+}
+
+// canIssue проверяет право на выдачу заданий и при его отсутствии отвечает ошибкой
+func (h Handler) canIssue(w http.ResponseWriter, r *http.Request, l *zap.Logger, op string) bool {
+	hasAccess, err := h.accountMediator.HasAccess(r.Context(), r.Context().Value("AccountId").(int),
+		domain.IssuedPracticeObject, domain.AddAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return false
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeForbidden,
+		})
+		return false
+	}
+
+	return true
+}
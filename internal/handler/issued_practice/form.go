@@ -0,0 +1,133 @@
+package issued_practice
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/formutils"
+	"strings"
+	"time"
+)
+
+// formFile файл задания из формы: поток части file или завершенная возобновляемая загрузка
+type formFile struct {
+	Content io.Reader
+	Name    string
+
+	// UploadId загрузка, которая удаляется после сохранения файла
+	UploadId string
+
+	close func()
+}
+
+func (f formFile) Close() {
+	if f.close != nil {
+		f.close()
+	}
+}
+
+// readForm читает поля формы до файла. Файл передается последним полем file или ссылкой upload_id
+// на завершенную загрузку. При ошибке ответ уже отправлен и возвращается false, файл равен nil, если его нет в форме
+func (h Handler) readForm(ctx context.Context, w http.ResponseWriter, r *http.Request, l *zap.Logger, op string) (url.Values, *formFile, bool) {
+	values, part, err := formutils.ReadUntilFile(w, r, "file", h.uploads.MaxSize())
+	if err != nil {
+		l.Warn("ошибка чтения формы", zap.Error(err))
+
+		if errors.Is(err, formutils.ErrTooLarge) {
+			apperr.New(w, r, http.StatusRequestEntityTooLarge, apperr.AppError{
+				Action: op,
+				Code:   domain.CodeFileTooLarge,
+			})
+			return nil, nil, false
+		}
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeFormUnreadable,
+		})
+		return nil, nil, false
+	}
+
+	if part != nil {
+		return values, &formFile{Content: part, Name: part.FileName()}, true
+	}
+
+	uploadId := values.Get("upload_id")
+	if uploadId == "" {
+		return values, nil, true
+	}
+
+	file, err := h.uploads.Open(ctx, dto.UploadId{Id: uploadId})
+	if err != nil {
+		l.Warn("ошибка открытия загрузки", zap.String("id загрузки", uploadId), zap.Error(err))
+
+		apperr.Write(w, r, op, err)
+		return nil, nil, false
+	}
+
+	return values, &formFile{
+		Content:  file.Content,
+		Name:     file.Name,
+		UploadId: uploadId,
+		close:    func() { _ = file.Content.Close() },
+	}, true
+}
+
+// finishUpload удаляет использованную загрузку. Неудаленная загрузка удалится по истечении срока
+func (h Handler) finishUpload(ctx context.Context, l *zap.Logger, file *formFile) {
+	if file == nil || file.UploadId == "" {
+		return
+	}
+
+	err := h.uploads.Delete(ctx, dto.UploadId{Id: file.UploadId})
+	if err != nil {
+		l.Warn("ошибка удаления использованной загрузки", zap.String("id загрузки", file.UploadId), zap.Error(err))
+	}
+}
+
+// formValue возвращает значение поля формы или nil, если поле не передано
+func formValue(values url.Values, key string) *string {
+	if !values.Has(key) {
+		return nil
+	}
+
+	value := values.Get(key)
+
+	return &value
+}
+
+// formDeadline возвращает срок сдачи из формы в формате RFC3339 или nil, если он не передан
+func formDeadline(values url.Values) (*time.Time, error) {
+	value := formValue(values, "deadline")
+	if value == nil {
+		return nil, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deadline, nil
+}
+
+// documentExt возвращает расширение файла задания. Файл без расширения считается документом docx
+func documentExt(filename string) (string, bool) {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	switch ext {
+	case "":
+		return ".docx", true
+	case ".docx", ".pdf", ".odt":
+		return ext, true
+	default:
+		return "", false
+	}
+}
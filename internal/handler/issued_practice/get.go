@@ -2,12 +2,10 @@ package issued_practice
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
-	"io"
 	"net/http"
-	"os"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
@@ -15,17 +13,17 @@ import (
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apiutils"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"strings"
-	"time"
 )
 
 func (h Handler) PracticeById(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetIssuedPracticeInfoById),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -37,42 +35,27 @@ func (h Handler) PracticeById(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetIssuedPracticeInfoById,
-			Error:  "Преобразование запроса на получение практического задания",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	practice, err := h.s.ById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetIssuedPracticeInfoById,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetIssuedPracticeInfoById,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetIssuedPracticeInfoById, err)
+		return
 	}
 
-	link := r.Host + r.URL.String()
-	link = strings.Replace(link, "?", "/download?", 1)
-
-	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice).WithDownloadLink(link))
+	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice).WithDownloadLink(h.downloadLink(ctx, r, l, practice.Id)))
 	return
 }
 
+// Download выдает файл задания с поддержкой докачки и условных запросов
 func (h Handler) Download(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.DownloadIssuedPractice),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -84,72 +67,32 @@ func (h Handler) Download(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.DownloadIssuedPractice,
-			Error:  "Преобразование запроса на получение практического задания",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
-	practice, err := h.s.ById(ctx, dto.EntityId{Id: id})
-	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.DownloadIssuedPractice,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.DownloadIssuedPractice,
-				Error:  err.Error(),
-			})
-			return
-		}
-	}
-
-	path := practice.Path
-
-	f, err := os.Open(path)
-	defer f.Close()
+	file, err := h.s.File(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		l.Warn("ошибка открытия файла", zap.Error(err))
-		apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-			Action: operation.DownloadIssuedPractice,
-			Error:  "не удалось найти файл",
-		})
-		return
-	}
+		l.Warn("ошибка получения файла задания", zap.Error(err))
 
-	i, err := f.Stat()
-	if err != nil {
-		l.Warn("ошибка чтения метаинформации файла", zap.Error(err))
-		apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-			Action: operation.DownloadIssuedPractice,
-			Error:  "не удалось найти файл",
-		})
+		apperr.Write(w, r, operation.DownloadIssuedPractice, err)
 		return
 	}
+	defer file.Content.Close()
 
-	apiutils.SetDownloadHeaders(w, path, strconv.Itoa(int(i.Size())))
-	w.WriteHeader(http.StatusOK)
+	apiutils.SetDownloadHeaders(w, file.Name, file.Hash)
 
-	_, err = io.Copy(w, f)
-	if err != nil {
-		l.Warn("ошибка выдачи файла", zap.Error(err))
-		apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-			Action: operation.GetIssuedPracticeInfoById,
-			Error:  "не удалось выгрузить файл",
-		})
-		return
-	}
+	// ServeContent отвечает 206 на запросы диапазонов, чтобы клиент мог докачать файл,
+	// и 304 на условные запросы по ETag и времени изменения
+	http.ServeContent(metrics.NewCountingWriter(w, metrics.FilesIssued), r, file.Name, file.ModTime, file.Content)
 }
 
 func (h Handler) PracticeByParams(w http.ResponseWriter, r *http.Request) {
-	_, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetIssuedPracticeInfoByParams),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -159,26 +102,48 @@ func (h Handler) PracticeByParams(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		l.Warn("ошибка получени параметров запроса", zap.Error(err))
 
-		apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetIssuedPracticeInfoByParams,
-			Error:  "Неправильные параметры запроса",
+			Code:   domain.CodeBadParams,
 		})
 		return
 	}
 
-	practiceParams := getPracticeParams(r, defaultParams)
+	practiceParams, err := getPracticeParams(r, defaultParams)
+	if err != nil {
+		l.Warn("ошибка получени параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.GetIssuedPracticeInfoByParams,
+			Code:    domain.CodeInvalidParam,
+			Details: map[string]any{"field": "term_id"},
+		})
+		return
+	}
 
 	l.Info("попытка получить практические задания",
 		zap.Int("id аккаунта", r.Context().Value("AccountId").(int)),
 		zap.Int("лимит", practiceParams.Limit),
 		zap.Int("оффсет", practiceParams.Offset),
 		zap.String("статус решения", practiceParams.IsSolved),
+		zap.Int("id семестра", practiceParams.TermId),
 	)
 
+	practices, err := h.s.ByParams(ctx, practiceParams)
+	if err != nil {
+		apperr.Write(w, r, operation.GetIssuedPracticeInfoByParams, err)
+		return
+	}
+
+	l.Info("практические задания успешно отданы", zap.Int("кол-во", len(practices)))
+
+	render.JSON(w, r, rest.IssuedPractices{}.DomainToResponse(practices))
+	return
 }
 
-func getPracticeParams(r *http.Request, defaultParams params.Default) params.IssuedPractice {
+func getPracticeParams(r *http.Request, defaultParams params.Default) (params.IssuedPractice, error) {
 	var isSolved string
+	var termId int
 
 	v := r.URL.Query().Get("solved")
 
@@ -194,8 +159,19 @@ func getPracticeParams(r *http.Request, defaultParams params.Default) params.Iss
 		isSolved = "no"
 	}
 
+	// по умолчанию отдаются задания текущего семестра
+	if v := r.URL.Query().Get("term_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return params.IssuedPractice{}, err
+		}
+
+		termId = id
+	}
+
 	return params.IssuedPractice{
 		IsSolved: isSolved,
+		TermId:   termId,
 		Default:  defaultParams,
-	}
+	}, nil
 }
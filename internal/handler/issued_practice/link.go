@@ -0,0 +1,31 @@
+package issued_practice
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/handler/link"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apiutils"
+)
+
+// downloadLink выпускает подписанную ссылку на файл с id, доступ к которому уже проверен.
+// С параметром single_use=true ссылка действует на одно скачивание. Если выпустить ссылку не удалось,
+// данные отдаются без нее
+func (h Handler) downloadLink(ctx context.Context, r *http.Request, l *zap.Logger, id int) rest.DownloadLink {
+	downloadLink, err := h.links.Mint(ctx, dto.NewDownloadLinkReq{
+		Object:     domain.IssuedPracticeObject,
+		ResourceId: id,
+		SingleUse:  r.URL.Query().Get("single_use") == "true",
+	})
+	if err != nil {
+		l.Warn("ошибка выпуска ссылки на скачивание", zap.Error(err))
+		return rest.DownloadLink{}
+	}
+
+	url := apiutils.BaseURL(r) + link.IssuedPracticePath + "?" + downloadLink.Query
+
+	return rest.DownloadLink{}.DomainToResponse(url, downloadLink)
+}
@@ -3,23 +3,67 @@ package issued_practice
 import (
 	"context"
 	"go.uber.org/zap"
+	"io"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type IssuedPracticeService interface {
 	Save(ctx context.Context, req dto.NewIssuedPracticeReq) (domain.IssuedPractice, error)
 	ById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+	File(ctx context.Context, req dto.EntityId) (domain.File, error)
+	Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error)
+	ByParams(ctx context.Context, p params.IssuedPractice) ([]domain.IssuedPractice, error)
+	Search(ctx context.Context, p params.PracticeSearch) ([]domain.PracticeSearchHit, error)
+
+	Update(ctx context.Context, req dto.UpdateIssuedPracticeReq) (domain.IssuedPractice, error)
+	DeleteById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+	RestoreById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+
+	Clone(ctx context.Context, req dto.ClonePracticeReq) (domain.IssuedPractice, error)
+	CloneDiscipline(ctx context.Context, req dto.CloneDisciplineReq) ([]domain.IssuedPractice, error)
+
+	Submissions(ctx context.Context, req dto.EntityId) (domain.SubmissionArchive, error)
+	WriteSubmissionsArchive(ctx context.Context, archive domain.SubmissionArchive, w io.Writer) error
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+// UploadService выдает завершенные возобновляемые загрузки, которые передаются в форме вместо файла
+type UploadService interface {
+	MaxSize() int64
+
+	Open(ctx context.Context, req dto.UploadId) (domain.File, error)
+	Delete(ctx context.Context, req dto.UploadId) error
+}
+
+// LinkService выпускает подписанные ссылки на скачивание файлов
+type LinkService interface {
+	Mint(ctx context.Context, req dto.NewDownloadLinkReq) (domain.DownloadLink, error)
 }
 
 type Handler struct {
 	l *zap.Logger
 	s IssuedPracticeService
+
+	uploads         UploadService
+	links           LinkService
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
 }
 
-func NewIssuedPracticeHandler(service IssuedPracticeService, logger *zap.Logger) Handler {
+func NewIssuedPracticeHandler(service IssuedPracticeService, uploads UploadService, links LinkService, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	return Handler{
-		s: service,
-		l: logger,
+		s:               service,
+		l:               logger,
+		uploads:         uploads,
+		links:           links,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
 	}
 }
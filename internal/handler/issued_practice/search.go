@@ -0,0 +1,70 @@
+package issued_practice
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/queryutils"
+)
+
+// Search ищет задания по запросу из параметра q в формате websearch: слова, "фразы" и -исключения
+func (h Handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.SearchIssuedPractice),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	defaultParams, err := queryutils.DefaultParams(r, 10, 0)
+	if err != nil {
+		l.Warn("ошибка получени параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.SearchIssuedPractice,
+			Code:   domain.CodeBadParams,
+		})
+		return
+	}
+
+	searchParams := params.PracticeSearch{
+		Query:   r.URL.Query().Get("q"),
+		Default: defaultParams,
+	}
+
+	if searchParams.Query == "" {
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.SearchIssuedPractice,
+			Code:   domain.CodeEmptyQuery,
+		})
+		return
+	}
+
+	l.Info("попытка поиска практических заданий",
+		zap.Int("id аккаунта", r.Context().Value("AccountId").(int)),
+		zap.String("запрос", searchParams.Query),
+		zap.Int("лимит", searchParams.Limit),
+		zap.Int("оффсет", searchParams.Offset),
+	)
+
+	hits, err := h.s.Search(ctx, searchParams)
+	if err != nil {
+		apperr.Write(w, r, operation.SearchIssuedPractice, err)
+		return
+	}
+
+	l.Info("результаты поиска успешно отданы", zap.Int("кол-во", len(hits)))
+
+	render.JSON(w, r, rest.PracticeSearchResult{}.DomainToResponse(hits))
+	return
+}
@@ -0,0 +1,183 @@
+package issued_practice
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+// Update изменяет задание. Поля, не переданные в форме, остаются без изменений,
+// при передаче файла предыдущий сохраняется как старая версия
+func (h Handler) Update(w http.ResponseWriter, r *http.Request) {
+	// Файл читается из тела запроса во время сохранения, поэтому таймаут рассчитан на медленную сеть
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.UpdateIssuedPracticeOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	// Право проверяется до чтения формы, чтобы файл без права на изменение не принимался
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.IssuedPracticeObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.UpdateIssuedPracticeOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.UpdateIssuedPracticeOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	values, file, ok := h.readForm(ctx, w, r, l, operation.UpdateIssuedPracticeOperation)
+	if !ok {
+		return
+	}
+
+	if file != nil {
+		defer file.Close()
+	}
+
+	id, err := strconv.Atoi(values.Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.UpdateIssuedPracticeOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	deadline, err := formDeadline(values)
+	if err != nil {
+		l.Warn("ошибка чтения срока сдачи из формы", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.UpdateIssuedPracticeOperation,
+			Code:    domain.CodeInvalidDate,
+			Details: map[string]any{"field": "deadline", "format": "RFC3339"},
+		})
+		return
+	}
+
+	req := dto.UpdateIssuedPracticeReq{
+		Deadline:     deadline,
+		Id:           id,
+		TargetGroups: values["target_groups"],
+		Title:        formValue(values, "title"),
+		Theme:        formValue(values, "theme"),
+		Major:        formValue(values, "major"),
+	}
+
+	if file != nil {
+		ext, ok := documentExt(file.Name)
+		if !ok {
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action:  operation.UpdateIssuedPracticeOperation,
+				Code:    domain.CodeUnsupportedFile,
+				Details: map[string]any{"formats": "docx, pdf, odt"},
+			})
+			return
+		}
+
+		req.File = file.Content
+		req.Ext = ext
+		req.FileName = file.Name
+		req.Resumable = file.UploadId != ""
+	}
+
+	practice, err := h.s.Update(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.UpdateIssuedPracticeOperation, err)
+		return
+	}
+
+	h.finishUpload(ctx, l, file)
+
+	l.Info("практическое задание успешно изменено", zap.Int("id задания", practice.Id))
+
+	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice))
+	return
+}
+
+func (h Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, operation.DeleteIssuedPracticeOperation, domain.DeleteAction, h.s.DeleteById)
+}
+
+func (h Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, operation.RestoreIssuedPractice, domain.EditAction, h.s.RestoreById)
+}
+
+// changeState проверяет право на действие с заданием и применяет изменение к заданию из параметра id
+func (h Handler) changeState(w http.ResponseWriter, r *http.Request, op, action string,
+	change func(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, op),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  op,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.IssuedPracticeObject, action)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	practice, err := change(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, op, err)
+		return
+	}
+
+	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice))
+	return
+}
@@ -2,58 +2,89 @@ package issued_practice
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
+	"strconv"
 )
 
+// Upload загружает задание. Поля формы передаются до файла: файл сохраняется прямо из тела запроса
 func (h Handler) Upload(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.UploadIssuedPracticeOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
 	)
 
-	// Максимальный размер файла - 10 мб
-	err := r.ParseMultipartForm(10 << 20)
-	if err != nil {
-		l.Warn("попытка загрузить большой файл", zap.Error(err))
+	values, file, ok := h.readForm(ctx, w, r, l, operation.UploadIssuedPracticeOperation)
+	if !ok {
+		return
+	}
 
+	if file == nil {
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.UploadIssuedPracticeOperation,
-			Error:  "Слишком большой файл",
+			Code:   domain.CodeFileUnreadable,
 		})
 		return
 	}
+	defer file.Close()
 
-	file, _, err := r.FormFile("file")
+	ext, ok := documentExt(file.Name)
+	if !ok {
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.UploadIssuedPracticeOperation,
+			Code:    domain.CodeUnsupportedFile,
+			Details: map[string]any{"formats": "docx, pdf, odt"},
+		})
+		return
+	}
+
+	disciplineId, err := strconv.Atoi(values.Get("discipline_id"))
 	if err != nil {
-		l.Warn("ошибка чтения файла из формы", zap.Error(err))
+		l.Warn("ошибка чтения дисциплины из формы", zap.Error(err))
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
-			Action: operation.UploadIssuedPracticeOperation,
-			Error:  "Ошибка чтения файла",
+			Action:  operation.UploadIssuedPracticeOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "discipline_id"},
+		})
+		return
+	}
+
+	deadline, err := formDeadline(values)
+	if err != nil {
+		l.Warn("ошибка чтения срока сдачи из формы", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.UploadIssuedPracticeOperation,
+			Code:    domain.CodeInvalidDate,
+			Details: map[string]any{"field": "deadline", "format": "RFC3339"},
 		})
 		return
 	}
-	defer file.Close()
 
 	req := dto.NewIssuedPracticeReq{
-		TargetGroups: r.MultipartForm.Value["target_groups"],
-		Title:        r.FormValue("title"),
-		Theme:        r.FormValue("theme"),
-		Major:        r.FormValue("major"),
-		File:         &file,
+		DisciplineId: disciplineId,
+		Deadline:     deadline,
+		TargetGroups: values["target_groups"],
+		Title:        values.Get("title"),
+		Theme:        values.Get("theme"),
+		Major:        values.Get("major"),
+		File:         file.Content,
+		Ext:          ext,
+		FileName:     file.Name,
+		Resumable:    file.UploadId != "",
 	}
 
 	l.Info("попытка загрузить практическое задание",
@@ -63,22 +94,11 @@ func (h Handler) Upload(w http.ResponseWriter, r *http.Request) {
 
 	practice, err := h.s.Save(ctx, req)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetRoleOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else if err != nil {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetRoleOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetRoleOperation, err)
+		return
 	}
+	h.finishUpload(ctx, l, file)
+
 	l.Info("практическое задание успешно загружено")
 
 	render.JSON(w, r, rest.IssuedPractice{}.DomainToResponse(practice))
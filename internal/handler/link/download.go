@@ -0,0 +1,69 @@
+package link
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+)
+
+// IssuedPractice выдает файл задания по подписанной ссылке без заголовка Authorization
+func (h Handler) IssuedPractice(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, domain.IssuedPracticeObject, operation.DownloadIssuedPractice, metrics.FilesIssued, h.issued)
+}
+
+// SolvedPractice выдает файл работы по подписанной ссылке без заголовка Authorization
+func (h Handler) SolvedPractice(w http.ResponseWriter, r *http.Request) {
+	h.download(w, r, domain.SolvedPracticeObject, operation.DownloadSolvedPractice, metrics.FilesSolved, h.solved)
+}
+
+// download проверяет ссылку и выдает файл от имени аккаунта, для которого она выпущена, поэтому
+// права аккаунта проверяются так же, как при обычном скачивании. Одноразовая ссылка не подходит для докачки,
+// так как каждый запрос диапазона использует ее заново
+func (h Handler) download(w http.ResponseWriter, r *http.Request, object, op, kind string, files FileService) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	// В адресе ссылки подпись, поэтому в журнал пишется только путь
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.URL.Path),
+		zap.String(operation.Operation, op),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	link, err := h.s.Redeem(ctx, object, r.URL.Query())
+	if err != nil {
+		l.Warn("ссылка на скачивание отклонена", zap.Error(err))
+
+		apperr.Write(w, r, op, err)
+		return
+	}
+
+	ctx = context.WithValue(ctx, "AccountId", link.AccountId)
+	ctx = logger.WithFields(ctx, zap.Int(operation.AccountId, link.AccountId))
+
+	file, err := files.File(ctx, dto.EntityId{Id: link.ResourceId})
+	if err != nil {
+		l.Warn("ошибка получения файла по ссылке", zap.String("id ссылки", link.Id), zap.Error(err))
+
+		apperr.Write(w, r, op, err)
+		return
+	}
+	defer file.Content.Close()
+
+	l.Info("файл выдан по ссылке",
+		zap.String("id ссылки", link.Id),
+		zap.Int("id аккаунта", link.AccountId),
+		zap.Int("id записи", link.ResourceId),
+	)
+
+	apiutils.SetDownloadHeaders(w, file.Name, file.Hash)
+	http.ServeContent(metrics.NewCountingWriter(w, kind), r.WithContext(ctx), file.Name, file.ModTime, file.Content)
+}
@@ -0,0 +1,84 @@
+package link
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+)
+
+// Пути скачивания по подписанным ссылкам
+const (
+	IssuedPracticePath = "/link/issued"
+	SolvedPracticePath = "/link/solved"
+)
+
+type Service interface {
+	Redeem(ctx context.Context, object string, query url.Values) (domain.DownloadLink, error)
+	Revoke(ctx context.Context, req dto.LinkId) error
+}
+
+// FileService открывает файл записи, проверяя доступ аккаунта из контекста
+type FileService interface {
+	File(ctx context.Context, req dto.EntityId) (domain.File, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	issued FileService
+	solved FileService
+
+	timeouts apiutils.Timeouts
+}
+
+func NewLinkHandler(service Service, issued, solved FileService, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:        logger,
+		s:        service,
+		issued:   issued,
+		solved:   solved,
+		timeouts: timeouts,
+	}
+}
+
+// Revoke отзывает подписанную ссылку текущего аккаунта до истечения срока ее действия
+func (h Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.URL.Path),
+		zap.String(operation.Operation, operation.RevokeDownloadLinkOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		l.Warn(operation.DecodeError)
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.RevokeDownloadLinkOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	err := h.s.Revoke(ctx, dto.LinkId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.RevokeDownloadLinkOperation, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
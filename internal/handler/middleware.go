@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/tracing"
+	"strconv"
+	"time"
+	"unicode"
+)
+
+// maxRequestIdLen ограничивает id запроса от клиента, чтобы он не раздувал журналы
+const maxRequestIdLen = 128
+
+// RequestId принимает id запроса из X-Request-ID или выдает новый. Id попадает в контекст,
+// в ответ и во все логгеры, полученные из контекста запроса
+func (h Handler) RequestId(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(middleware.RequestIDHeader)
+		if !validRequestId(id) {
+			id = uuid.NewString()
+		}
+
+		ctx := context.WithValue(r.Context(), middleware.RequestIDKey, id)
+		ctx = logger.WithFields(ctx, zap.String(operation.RequestId, id))
+
+		w.Header().Set(middleware.RequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLen {
+		return false
+	}
+
+	for _, c := range id {
+		if c > unicode.MaxASCII || !unicode.IsPrint(c) || unicode.IsSpace(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AccessLog пишет одну строку журнала на каждый запрос с кодом ответа, временем обработки
+// и объемом ответа. Ответы с кодом 5xx пишутся как предупреждения с текстом ошибки из apperr.Write,
+// подробности со стеком записаны слоем, где ошибка произошла
+func (h Handler) AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := routePattern(r, r.URL.Path)
+
+		lvl := zapcore.InfoLevel
+		if status >= http.StatusInternalServerError {
+			lvl = zapcore.WarnLevel
+		}
+
+		logger.FromContext(r.Context(), h.l).Log(lvl, "запрос обработан",
+			zap.String(layer.Layer, layer.HTTPLayer),
+			zap.String("метод", r.Method),
+			zap.String("маршрут", route),
+			zap.String(layer.Endpoint, r.RequestURI),
+			zap.Int("код ответа", status),
+			zap.Int("байт отправлено", ww.BytesWritten()),
+			zap.Duration("время обработки", time.Since(start)),
+			zap.String("адрес клиента", r.RemoteAddr),
+		)
+	})
+}
+
+// Metrics учитывает время обработки и объем ответа по шаблону маршрута, а не по адресу,
+// чтобы id в пути не порождали новые ряды метрик
+func (h Handler) Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := routePattern(r, "unmatched")
+
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+		metrics.HTTPResponseBytes.WithLabelValues(r.Method, route).Add(float64(ww.BytesWritten()))
+	})
+}
+
+// Tracing начинает серверный спан запроса, продолжая трассу из заголовка traceparent, если он есть.
+// Спан называется по шаблону маршрута, который известен только после маршрутизации. Id трассы
+// попадает во все логгеры, полученные из контекста запроса
+func (h Handler) Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logger.WithFields(ctx, zap.String(operation.TraceId, sc.TraceID().String()))
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		route := routePattern(r, "unmatched")
+
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
+
+// routePattern возвращает шаблон маршрута, по которому был обработан запрос, или fallback,
+// если маршрут не найден
+func routePattern(r *http.Request, fallback string) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+
+	return fallback
+}
+
+// Recover перехватывает панику обработчика, пишет ее в журнал со стеком и отвечает
+// внутренней ошибкой, если ответ еще не начат. Прерывание ответа через http.ErrAbortHandler
+// пробрасывается дальше, как ожидает net/http
+func (h Handler) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww, ok := w.(middleware.WrapResponseWriter)
+		if !ok {
+			ww = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		}
+
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+
+			if err, ok := rvr.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rvr)
+			}
+
+			logger.FromContext(r.Context(), h.l).Error("паника при обработке запроса",
+				zap.String(layer.Layer, layer.HTTPLayer),
+				zap.String(layer.Endpoint, r.RequestURI),
+				zap.Any("паника", rvr),
+				zap.StackSkip("стек", 2),
+			)
+
+			if ww.Status() != 0 {
+				return
+			}
+
+			apperr.New(ww, r, http.StatusInternalServerError, apperr.AppError{
+				Action: operation.HTTPRequestOperation,
+				Code:   apperr.CodeInternal,
+			})
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/queryutils"
+	"strconv"
+)
+
+type Service interface {
+	NotificationsByAccountId(ctx context.Context, req dto.EntityId, p params.Default) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, req dto.EntityId) error
+}
+
+type Handler struct {
+	l        *zap.Logger
+	s        Service
+	timeouts apiutils.Timeouts
+}
+
+func NewNotificationHandler(service Service, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:        logger,
+		s:        service,
+		timeouts: timeouts,
+	}
+}
+
+// GetNotifications отдает уведомления текущего аккаунта, права не требуются
+func (h Handler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetNotificationsOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	defaultParams, err := queryutils.DefaultParams(r, 10, 0)
+	if err != nil {
+		l.Warn("ошибка получение параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.GetNotificationsOperation,
+			Code:   domain.CodeBadParams,
+		})
+		return
+	}
+
+	notifications, err := h.s.NotificationsByAccountId(ctx, dto.EntityId{Id: ctx.Value("AccountId").(int)}, defaultParams)
+	if err != nil {
+		apperr.Write(w, r, operation.GetNotificationsOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.Notifications{}.DomainToResponse(notifications))
+	return
+}
+
+func (h Handler) ReadNotification(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.ReadNotificationOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.ReadNotificationOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	err = h.s.MarkRead(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.ReadNotificationOperation, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,159 @@
+package plagiarism
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+type Service interface {
+	Report(ctx context.Context, req dto.SimilarityReportReq) ([]domain.SimilarityPair, error)
+	Reanalyze(ctx context.Context, req dto.EntityId) error
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
+}
+
+func NewPlagiarismHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:               logger,
+		s:               service,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
+	}
+}
+
+// Report отдает пары похожих работ по заданию. Параметр min_score от 0 до 1, по умолчанию 0.5
+func (h Handler) Report(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Long)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetSimilarityReport),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.GetSimilarityReport,
+			Code:    domain.CodeInvalidParam,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	var minScore float64
+
+	if v := r.URL.Query().Get("min_score"); v != "" {
+		minScore, err = strconv.ParseFloat(v, 64)
+		if err != nil || minScore < 0 || minScore > 1 {
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action:  operation.GetSimilarityReport,
+				Code:    domain.CodeValueOutOfRange,
+				Details: map[string]any{"field": "min_score", "min": 0, "max": 1},
+			})
+			return
+		}
+	}
+
+	if !h.canView(w, r, l, operation.GetSimilarityReport) {
+		return
+	}
+
+	pairs, err := h.s.Report(ctx, dto.SimilarityReportReq{IssuedPracticeId: id, MinScore: minScore})
+	if err != nil {
+		apperr.Write(w, r, operation.GetSimilarityReport, err)
+		return
+	}
+
+	l.Info("отчет о схожести работ отдан", zap.Int("id задания", id), zap.Int("кол-во пар", len(pairs)))
+
+	render.JSON(w, r, rest.SimilarityReport{}.DomainToResponse(pairs))
+	return
+}
+
+// Reanalyze ставит задание в очередь на повторное сравнение работ
+func (h Handler) Reanalyze(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.AnalyzeSimilarityOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.AnalyzeSimilarityOperation,
+			Code:    domain.CodeInvalidParam,
+			Details: map[string]any{"field": "id"},
+		})
+		return
+	}
+
+	if !h.canView(w, r, l, operation.AnalyzeSimilarityOperation) {
+		return
+	}
+
+	err = h.s.Reanalyze(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.AnalyzeSimilarityOperation, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// canView проверяет право на просмотр выполненных работ и при его отсутствии отвечает ошибкой
+func (h Handler) canView(w http.ResponseWriter, r *http.Request, l *zap.Logger, op string) bool {
+	hasAccess, err := h.accountMediator.HasAccess(r.Context(), r.Context().Value("AccountId").(int),
+		domain.SolvedPracticeObject, domain.GetAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return false
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeForbidden,
+		})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,173 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+type Service interface {
+	SetQuota(ctx context.Context, req dto.SetQuotaReq) (domain.StorageQuota, error)
+	Quotas(ctx context.Context) ([]domain.StorageQuota, error)
+	DeleteQuota(ctx context.Context, req dto.EntityId) error
+
+	Usage(ctx context.Context, req dto.EntityId) (domain.AccountStorage, error)
+	TopConsumers(ctx context.Context, req dto.TopConsumersReq) ([]domain.StorageUsage, error)
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
+}
+
+func NewQuotaHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:               logger,
+		s:               service,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
+	}
+}
+
+func (h Handler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.SetQuotaOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	var req dto.SetQuotaReq
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.SetQuotaOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !h.canManage(w, r, l, domain.EditAction, operation.SetQuotaOperation) {
+		return
+	}
+
+	quota, err := h.s.SetQuota(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.SetQuotaOperation, err)
+		return
+	}
+
+	l.Info("квота установлена", zap.Int("id квоты", quota.Id), zap.Int64("лимит", quota.LimitBytes))
+
+	render.JSON(w, r, rest.StorageQuota{}.DomainToResponse(quota))
+	return
+}
+
+func (h Handler) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetQuotasOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	if !h.canManage(w, r, l, domain.GetAction, operation.GetQuotasOperation) {
+		return
+	}
+
+	quotas, err := h.s.Quotas(ctx)
+	if err != nil {
+		apperr.Write(w, r, operation.GetQuotasOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.StorageQuotas{}.DomainToResponse(quotas))
+	return
+}
+
+func (h Handler) DeleteQuota(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.DeleteQuotaOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.DeleteQuotaOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	if !h.canManage(w, r, l, domain.DeleteAction, operation.DeleteQuotaOperation) {
+		return
+	}
+
+	err = h.s.DeleteQuota(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.DeleteQuotaOperation, err)
+		return
+	}
+
+	l.Info("квота удалена", zap.Int("id квоты", id))
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// canManage проверяет право на действие с хранилищем и при его отсутствии отвечает ошибкой
+func (h Handler) canManage(w http.ResponseWriter, r *http.Request, l *zap.Logger, action, op string) bool {
+	hasAccess, err := h.accountMediator.HasAccess(r.Context(), r.Context().Value("AccountId").(int),
+		domain.StorageObject, action)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return false
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeForbidden,
+		})
+		return false
+	}
+
+	return true
+}
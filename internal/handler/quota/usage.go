@@ -0,0 +1,82 @@
+package quota
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+// GetUsage возвращает занятое текущим аккаунтом и его группой место и действующие лимиты
+func (h Handler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	accountId := ctx.Value("AccountId").(int)
+
+	storage, err := h.s.Usage(ctx, dto.EntityId{Id: accountId})
+	if err != nil {
+		apperr.Write(w, r, operation.GetUsageOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.AccountStorage{}.DomainToResponse(storage))
+	return
+}
+
+// TopConsumers возвращает аккаунты или группы (параметр by), занимающие больше всего места
+func (h Handler) TopConsumers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetTopConsumersOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	req := dto.TopConsumersReq{
+		By:    r.URL.Query().Get("by"),
+		Limit: 10,
+	}
+
+	if req.By == "" {
+		req.By = domain.UsageByAccount
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			l.Warn("ошибка получени параметров запроса", zap.Error(err))
+
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action: operation.GetTopConsumersOperation,
+				Code:   domain.CodeBadParams,
+			})
+			return
+		}
+	}
+
+	if !h.canManage(w, r, l, domain.GetAction, operation.GetTopConsumersOperation) {
+		return
+	}
+
+	usage, err := h.s.TopConsumers(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.GetTopConsumersOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.TopConsumers{}.DomainToResponse(usage))
+	return
+}
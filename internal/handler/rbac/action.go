@@ -3,7 +3,6 @@ package rbac
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -13,18 +12,18 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"time"
 )
 
 func (h AccessHandler) AddAction(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var addingAction dto.NewRBACReq
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.AddActionOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -36,8 +35,7 @@ func (h AccessHandler) AddAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.AddActionOperation,
-
-			Error: "Преобразование запроса на добавление действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -48,7 +46,7 @@ func (h AccessHandler) AddAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -56,26 +54,15 @@ func (h AccessHandler) AddAction(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	added, err := h.s.NewAction(ctx, addingAction)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.AddActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.AddActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.AddActionOperation, err)
+		return
 	}
 
 	l.Info("действие успешно добавлено", zap.String("название действия", added.Name))
@@ -85,10 +72,10 @@ func (h AccessHandler) AddAction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) DeleteAction(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.SoftDeleteActionById),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -100,7 +87,7 @@ func (h AccessHandler) DeleteAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetActionOperation,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -111,7 +98,7 @@ func (h AccessHandler) DeleteAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -119,28 +106,15 @@ func (h AccessHandler) DeleteAction(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	deletedAction, err := h.s.DeleteActionById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
 	render.JSON(w, r, rest.RBACPartDomainToResponse(deletedAction))
@@ -148,12 +122,12 @@ func (h AccessHandler) DeleteAction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var req dto.EntityId
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetActionOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -165,7 +139,7 @@ func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetActionOperation,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -176,7 +150,7 @@ func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -184,7 +158,7 @@ func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
@@ -193,21 +167,8 @@ func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
 
 	action, err := h.s.ActionById(ctx, req)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
 	l.Info("действие успешно отдано", zap.String("название действия", action.Name))
@@ -217,10 +178,10 @@ func (h AccessHandler) GetAction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetActions(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetActionsOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
@@ -232,7 +193,7 @@ func (h AccessHandler) GetActions(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetActionsOperation,
-			Error:  "Неправильные параметры запроса",
+			Code:   domain.CodeBadParams,
 		})
 		return
 	}
@@ -245,7 +206,7 @@ func (h AccessHandler) GetActions(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -253,25 +214,14 @@ func (h AccessHandler) GetActions(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	actions, err := h.s.ActionsByParams(ctx, stateParams)
-	if errors.Is(err, context.DeadlineExceeded) {
-		apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-			Action: operation.GetActionsOperation,
-			Error:  "неправильные параметры запроса",
-		})
-		return
-	} else if err != nil {
-		code := http.StatusInternalServerError
-
-		apperr.New(w, r, code, apperr.AppError{
-			Action: operation.GetActionsOperation,
-			Error:  err.Error(),
-		})
+	if err != nil {
+		apperr.Write(w, r, operation.GetActionsOperation, err)
 		return
 	}
 
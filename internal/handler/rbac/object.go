@@ -3,7 +3,6 @@ package rbac
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -13,18 +12,18 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"time"
 )
 
 func (h AccessHandler) AddObject(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var addingObject dto.NewRBACReq
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.AddObjectOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -36,26 +35,15 @@ func (h AccessHandler) AddObject(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.AddObjectOperation,
-			Error:  "Преобразование запроса на добавление объекта",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	added, err := h.s.NewObject(ctx, addingObject)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.AddObjectOperation,
-				Error:  "таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.AddObjectOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.AddObjectOperation, err)
+		return
 	}
 
 	l.Info("объект действия успешно добавлен", zap.String("название объекта", added.Name))
@@ -65,10 +53,10 @@ func (h AccessHandler) AddObject(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) DeleteObject(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.SoftDeleteObjectById),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -80,7 +68,7 @@ func (h AccessHandler) DeleteObject(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.SoftDeleteObjectById,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -91,7 +79,7 @@ func (h AccessHandler) DeleteObject(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.SoftDeleteObjectById,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -99,28 +87,15 @@ func (h AccessHandler) DeleteObject(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.SoftDeleteObjectById,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	deletedObject, err := h.s.DeleteObjectById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.SoftDeleteObjectById,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.SoftDeleteObjectById,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.SoftDeleteObjectById, err)
+		return
 	}
 
 	render.JSON(w, r, rest.RBACPartDomainToResponse(deletedObject))
@@ -128,10 +103,10 @@ func (h AccessHandler) DeleteObject(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetObject(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetObjectOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -143,28 +118,15 @@ func (h AccessHandler) GetObject(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetObjectOperation,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	object, err := h.s.ObjectById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetObjectOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else if err != nil {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetObjectOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetObjectOperation, err)
+		return
 	}
 
 	l.Info("объект успешно отдан", zap.Int("id объекта", object.ID))
@@ -174,10 +136,10 @@ func (h AccessHandler) GetObject(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetObjects(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetObjectsOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -189,7 +151,7 @@ func (h AccessHandler) GetObjects(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetObjectsOperation,
-			Error:  "Неправильные параметры запроса",
+			Code:   domain.CodeBadParams,
 		})
 		return
 	}
@@ -197,19 +159,8 @@ func (h AccessHandler) GetObjects(w http.ResponseWriter, r *http.Request) {
 	stateParams := queryutils.StateParams(r, defaultParams)
 
 	objects, err := h.s.ObjectsByParams(ctx, stateParams)
-	if errors.Is(err, context.DeadlineExceeded) {
-		apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-			Action: operation.GetObjectsOperation,
-			Error:  "Таймаут",
-		})
-		return
-	} else if err != nil {
-		code := http.StatusInternalServerError
-
-		apperr.New(w, r, code, apperr.AppError{
-			Action: operation.GetObjectsOperation,
-			Error:  err.Error(),
-		})
+	if err != nil {
+		apperr.Write(w, r, operation.GetObjectsOperation, err)
 		return
 	}
 
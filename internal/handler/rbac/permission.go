@@ -3,24 +3,24 @@ package rbac
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
 )
 
 func (h AccessHandler) AddPermission(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var addingPerm dto.SetPermissionReq
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.AddPermissionOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -32,26 +32,34 @@ func (h AccessHandler) AddPermission(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.AddPermissionOperation,
-			Error:  "Преобразование запроса на добавление доступа",
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.RBACObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddPermissionOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.AddPermissionOperation,
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	err = h.s.NewPermission(ctx, addingPerm)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.AddPermissionOperation,
-				Error:  "таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.AddPermissionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.AddPermissionOperation, err)
+		return
 	}
 
 	l.Info("доступы успешно назначены")
@@ -59,3 +67,56 @@ func (h AccessHandler) AddPermission(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, map[string]string{"result": "ok"})
 	return
 }
+
+func (h AccessHandler) ReplacePermission(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	var replacingPerm dto.SetPermissionReq
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.ReplacePermissionOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	err := json.NewDecoder(r.Body).Decode(&replacingPerm)
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.ReplacePermissionOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.RBACObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.ReplacePermissionOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.ReplacePermissionOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	err = h.s.ReplacePermission(ctx, replacingPerm)
+	if err != nil {
+		apperr.Write(w, r, operation.ReplacePermissionOperation, err)
+		return
+	}
+
+	l.Info("доступы успешно заменены")
+
+	render.JSON(w, r, map[string]string{"result": "ok"})
+}
@@ -6,6 +6,7 @@ import (
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type RBACService interface {
@@ -25,6 +26,7 @@ type RBACService interface {
 	NewObject(ctx context.Context, addingObject dto.NewRBACReq) (domain.Object, error)
 	NewRole(ctx context.Context, addingRole dto.NewRBACReq) (domain.Role, error)
 	NewPermission(ctx context.Context, req dto.SetPermissionReq) error
+	ReplacePermission(ctx context.Context, req dto.SetPermissionReq) error
 }
 
 type AccountMediator interface {
@@ -35,12 +37,14 @@ type AccessHandler struct {
 	l               *zap.Logger
 	s               RBACService
 	accountMediator AccountMediator
+	timeouts        apiutils.Timeouts
 }
 
-func NewAccessHandler(service RBACService, accountMediator AccountMediator, logger *zap.Logger) AccessHandler {
+func NewAccessHandler(service RBACService, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) AccessHandler {
 	return AccessHandler{
 		l:               logger,
 		s:               service,
 		accountMediator: accountMediator,
+		timeouts:        timeouts,
 	}
 }
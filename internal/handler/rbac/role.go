@@ -3,7 +3,6 @@ package rbac
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -13,18 +12,18 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"time"
 )
 
 func (h AccessHandler) AddRole(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var addingRole dto.NewRBACReq
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.AddRoleOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -36,26 +35,15 @@ func (h AccessHandler) AddRole(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.AddRoleOperation,
-			Error:  "Преобразование запроса на создание роли",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	role, err := h.s.NewRole(ctx, addingRole)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.AddRoleOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			apperr.New(w, r, http.StatusInternalServerError, apperr.AppError{
-				Action: operation.AddRoleOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.AddRoleOperation, err)
+		return
 	}
 
 	l.Info("роль успешно добавлена", zap.String("название роли", role.Name))
@@ -65,10 +53,10 @@ func (h AccessHandler) AddRole(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.SoftDeleteRoleById),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -80,7 +68,7 @@ func (h AccessHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.SoftDeleteRoleById,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -91,7 +79,7 @@ func (h AccessHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.SoftDeleteRoleById,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -99,28 +87,15 @@ func (h AccessHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.SoftDeleteRoleById,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	deletedRole, err := h.s.DeleteRoleById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.SoftDeleteRoleById,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.SoftDeleteRoleById,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.SoftDeleteRoleById, err)
+		return
 	}
 
 	render.JSON(w, r, rest.RBACPartDomainToResponse(deletedRole))
@@ -128,10 +103,10 @@ func (h AccessHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetRole(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetRoleOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -143,28 +118,15 @@ func (h AccessHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetRoleOperation,
-			Error:  "Преобразование запроса на получение роли",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	role, err := h.s.RoleById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetRoleOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else if err != nil {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetRoleOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetRoleOperation, err)
+		return
 	}
 
 	l.Info("роль успешно отдана", zap.Int("id роли", role.ID))
@@ -174,10 +136,10 @@ func (h AccessHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h AccessHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetRolesOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -189,7 +151,7 @@ func (h AccessHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetRolesOperation,
-			Error:  "Неправильные параметры запроса",
+			Code:   domain.CodeBadParams,
 		})
 		return
 	}
@@ -198,21 +160,8 @@ func (h AccessHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
 
 	roles, err := h.s.RolesByParams(ctx, stateParams)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetRolesOperation,
-				Error:  "неправильные параметры запроса",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetRolesOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetRolesOperation, err)
+		return
 	}
 
 	l.Info("роли успешно отданы")
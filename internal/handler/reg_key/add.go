@@ -3,7 +3,6 @@ package reg_key
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -13,16 +12,16 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
 )
 
 func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var addingKey dto.NewKeyReq
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.NewKeyOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -34,7 +33,7 @@ func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.NewKeyOperation,
-			Error:  "Преобразование запроса",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -43,10 +42,7 @@ func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		l.Warn(operation.ValidateError, zap.Error(err))
 
-		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
-			Action: operation.NewKeyOperation,
-			Error:  err.Error(),
-		})
+		apperr.Write(w, r, operation.NewKeyOperation, err)
 		return
 	}
 
@@ -62,7 +58,7 @@ func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.NewKeyOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -70,33 +66,20 @@ func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.NewKeyOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	createdKey, err := h.s.NewKey(ctx, addingKey)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.NewKeyOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			status := http.StatusInternalServerError
-
-			apperr.New(w, r, status, apperr.AppError{
-				Action: operation.NewKeyOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.NewKeyOperation, err)
+		return
 	}
 
 	l.Info("ключ успешно создан",
 		zap.Int("id ключа", createdKey.Id),
-		zap.String("тело ключа", createdKey.Body),
+		logger.Fingerprint("тело ключа", createdKey.Body),
 		zap.Time("время создания", createdKey.CreatedAt),
 	)
 
@@ -106,15 +89,15 @@ func (h Handler) AddKey(w http.ResponseWriter, r *http.Request) {
 
 func validateAddKey(addingKey dto.NewKeyReq) error {
 	if addingKey.RoleId == 0 {
-		return errors.New("roleId не может быть пустым")
+		return apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "role_id"})
 	}
 
 	if addingKey.MaxCountUsages <= 0 {
-		return errors.New("maxCountUsages не может быть пустым")
+		return apperr.Validation(domain.CodeValueNotPositive).WithDetails(map[string]any{"field": "max_count_usages"})
 	}
 
 	if addingKey.GroupName == "" {
-		return errors.New("groupName не может быть пустым")
+		return apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "group_name"})
 	}
 
 	return nil
@@ -2,7 +2,6 @@ package reg_key
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -12,15 +11,15 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"strconv"
-	"time"
 )
 
 func (h Handler) DeleteKey(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.NewKeyOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -32,7 +31,7 @@ func (h Handler) DeleteKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetActionOperation,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -48,7 +47,7 @@ func (h Handler) DeleteKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeyByIdOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -56,28 +55,15 @@ func (h Handler) DeleteKey(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeyByIdOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	deletedKey, err := h.s.InvalidateKey(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.InvalidateKeyOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			status := http.StatusInternalServerError
-
-			apperr.New(w, r, status, apperr.AppError{
-				Action: operation.InvalidateKeyOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.InvalidateKeyOperation, err)
+		return
 	}
 
 	l.Info("ключ успешно инвалидирован",
@@ -2,7 +2,6 @@ package reg_key
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -12,16 +11,16 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"time"
 )
 
 func (h Handler) GetKey(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetKeyByIdOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -33,7 +32,7 @@ func (h Handler) GetKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetActionOperation,
-			Error:  "Преобразование запроса на получение действия",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -46,7 +45,7 @@ func (h Handler) GetKey(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeyByIdOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -54,41 +53,28 @@ func (h Handler) GetKey(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeyByIdOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	key, err := h.s.KeyById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
-	l.Info("ключ успешно получен", zap.String("тело", key.Body))
+	l.Info("ключ успешно получен", logger.Fingerprint("тело ключа", key.Body))
 
 	render.JSON(w, r, rest.Key{}.DomainToResponse(key))
 	return
 }
 
 func (h Handler) GetKeys(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 300000000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetKeysOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -100,7 +86,7 @@ func (h Handler) GetKeys(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetKeysOperation,
-			Error:  "Неправильные параметры запроса",
+			Code:   domain.CodeBadParams,
 		})
 		return
 	}
@@ -120,7 +106,7 @@ func (h Handler) GetKeys(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeysOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -128,29 +114,15 @@ func (h Handler) GetKeys(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetKeysOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	keys, err := h.s.KeysByParams(ctx, stateParams)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetKeysOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetKeysOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
-
+		apperr.Write(w, r, operation.GetKeysOperation, err)
+		return
 	}
 
 	l.Info("ключи регистрации успешно получены")
@@ -6,6 +6,7 @@ import (
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type Service interface {
@@ -25,12 +26,15 @@ type Handler struct {
 	s Service
 
 	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
 }
 
-func NewKeyHandler(service Service, accountMediator AccountMediator, logger *zap.Logger) Handler {
+func NewKeyHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	return Handler{
 		l:               logger,
 		s:               service,
 		accountMediator: accountMediator,
+		timeouts:        timeouts,
 	}
 }
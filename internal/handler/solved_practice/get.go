@@ -2,7 +2,6 @@ package solved_practice
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -10,17 +9,18 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apiutils"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
 	"strconv"
-	"strings"
-	"time"
 )
 
 func (h Handler) PracticeById(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetSolvedPracticeInfoById),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -32,33 +32,52 @@ func (h Handler) PracticeById(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetSolvedPracticeInfoById,
-			Error:  "Преобразование запроса на получение практической работы",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
 
 	practice, err := h.s.ById(ctx, dto.EntityId{Id: id})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetIssuedPracticeInfoById,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
+		apperr.Write(w, r, operation.GetIssuedPracticeInfoById, err)
+		return
+	}
+
+	render.JSON(w, r, rest.SolvedPractice{}.DomainToResponse(practice).WithDownloadLink(h.downloadLink(ctx, r, l, practice.Id)))
+	return
+}
+
+// Download выдает файл работы с поддержкой докачки и условных запросов
+func (h Handler) Download(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.DownloadSolvedPractice),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
 
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetIssuedPracticeInfoById,
-				Error:  err.Error(),
-			})
-			return
-		}
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn("ошибка получения параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.DownloadSolvedPractice,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
 	}
 
-	link := r.Host + r.URL.String()
-	link = strings.Replace(link, "?", "/download?", 1)
+	file, err := h.s.File(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		l.Warn("ошибка получения файла работы", zap.Error(err))
 
-	render.JSON(w, r, rest.SolvedPractice{}.DomainToResponse(practice).WithDownloadLink(link))
-	return
+		apperr.Write(w, r, operation.DownloadSolvedPractice, err)
+		return
+	}
+	defer file.Content.Close()
+
+	apiutils.SetDownloadHeaders(w, file.Name, file.Hash)
+	http.ServeContent(metrics.NewCountingWriter(w, metrics.FilesSolved), r, file.Name, file.ModTime, file.Content)
 }
@@ -0,0 +1,144 @@
+package solved_practice
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+// ImportMarks принимает таблицу оценок в поле file. Без confirm=true возвращает только список изменений
+func (h Handler) ImportMarks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Long)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.ImportMarksOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int),
+		domain.MarkObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.ImportMarksOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.ImportMarksOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	// Максимальный размер таблицы - 10 мб
+	err = r.ParseMultipartForm(10 << 20)
+	if err != nil {
+		l.Warn("попытка загрузить большой файл", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.ImportMarksOperation,
+			Code:   domain.CodeFileTooLarge,
+		})
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		l.Warn("ошибка чтения файла из формы", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.ImportMarksOperation,
+			Code:   domain.CodeFileUnreadable,
+		})
+		return
+	}
+	defer file.Close()
+
+	var confirm bool
+
+	if v := r.URL.Query().Get("confirm"); v != "" {
+		confirm, err = strconv.ParseBool(v)
+		if err != nil {
+			apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+				Action:  operation.ImportMarksOperation,
+				Code:    domain.CodeInvalidParam,
+				Details: map[string]any{"field": "confirm"},
+			})
+			return
+		}
+	}
+
+	req := dto.MarkImportReq{
+		File:    &file,
+		Size:    header.Size,
+		Ext:     filepath.Ext(header.Filename),
+		Confirm: confirm,
+	}
+
+	l.Info("попытка импортировать оценки",
+		zap.String("файл", header.Filename),
+		zap.Bool("подтверждение", confirm),
+	)
+
+	result, err := h.s.ImportMarks(ctx, req)
+	if err != nil {
+		apperr.Write(w, r, operation.ImportMarksOperation, err)
+		return
+	}
+
+	// Подтвержденный импорт с ошибками не применяется, клиент получает строки с ошибками
+	if confirm && !result.Applied && result.Errors > 0 {
+		render.Status(r, http.StatusUnprocessableEntity)
+	}
+
+	render.JSON(w, r, rest.MarkImport{}.DomainToResponse(result))
+	return
+}
+
+func (h Handler) MarkHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetMarkHistoryOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn("ошибка декодирования данных", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.GetMarkHistoryOperation,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	history, err := h.s.MarkHistory(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, operation.GetMarkHistoryOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.MarkHistory{}.DomainToResponse(history))
+	return
+}
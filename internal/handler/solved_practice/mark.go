@@ -3,7 +3,6 @@ package solved_practice
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -12,14 +11,14 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
-	"time"
+	"practice_vgpek/pkg/logger"
 )
 
 func (h Handler) SetMark(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.SetMarkSolvedPractice),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -32,7 +31,7 @@ func (h Handler) SetMark(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.SetMarkSolvedPractice,
-			Error:  "Преобразование запроса",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -44,21 +43,8 @@ func (h Handler) SetMark(w http.ResponseWriter, r *http.Request) {
 
 	practice, err := h.s.SetMark(ctx, markBody)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.SetMarkSolvedPractice,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			status := http.StatusInternalServerError
-
-			apperr.New(w, r, status, apperr.AppError{
-				Action: operation.SetMarkSolvedPractice,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.SetMarkSolvedPractice, err)
+		return
 	}
 
 	l.Info("оценка успешно выставлена", zap.Int("id работы", practice.Mark))
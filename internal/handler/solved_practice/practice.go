@@ -5,6 +5,7 @@ import (
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type SolvedPracticeService interface {
@@ -12,16 +13,48 @@ type SolvedPracticeService interface {
 	SetMark(ctx context.Context, req dto.MarkPracticeReq) (domain.SolvedPractice, error)
 
 	ById(ctx context.Context, req dto.EntityId) (domain.SolvedPractice, error)
+	File(ctx context.Context, req dto.EntityId) (domain.File, error)
+	Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error)
+
+	ImportMarks(ctx context.Context, req dto.MarkImportReq) (domain.MarkImport, error)
+	MarkHistory(ctx context.Context, req dto.EntityId) ([]domain.MarkHistory, error)
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+// UploadService выдает завершенные возобновляемые загрузки, которые передаются в форме вместо файла
+type UploadService interface {
+	MaxSize() int64
+
+	Open(ctx context.Context, req dto.UploadId) (domain.File, error)
+	Delete(ctx context.Context, req dto.UploadId) error
+}
+
+// LinkService выпускает подписанные ссылки на скачивание файлов
+type LinkService interface {
+	Mint(ctx context.Context, req dto.NewDownloadLinkReq) (domain.DownloadLink, error)
 }
 
 type Handler struct {
 	l *zap.Logger
 	s SolvedPracticeService
+
+	uploads         UploadService
+	links           LinkService
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
 }
 
-func NewCompletedPracticeHandler(service SolvedPracticeService, logger *zap.Logger) Handler {
+func NewCompletedPracticeHandler(service SolvedPracticeService, uploads UploadService, links LinkService, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	return Handler{
-		l: logger,
-		s: service,
+		l:               logger,
+		s:               service,
+		uploads:         uploads,
+		links:           links,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
 	}
 }
@@ -0,0 +1,67 @@
+package solved_practice
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"strconv"
+)
+
+// Preview показывает файл работы в браузере: kind=pdf - документ в PDF, kind=thumbnail - изображение первой страницы.
+// Первое обращение ждет преобразования документа, дальше предпросмотр выдается готовым
+func (h Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.PreviewSolvedPractice),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn("ошибка получения параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.PreviewSolvedPractice,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+
+	switch kind {
+	case "":
+		kind = domain.PreviewPDF
+	case domain.PreviewPDF, domain.PreviewThumbnail:
+	default:
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.PreviewSolvedPractice,
+			Code:    domain.CodeValueNotAllowed,
+			Details: map[string]any{"field": "kind", "allowed": domain.PreviewPDF + ", " + domain.PreviewThumbnail},
+		})
+		return
+	}
+
+	file, err := h.s.Preview(ctx, dto.PreviewReq{Id: id, Kind: kind})
+	if err != nil {
+		l.Warn("ошибка получения предпросмотра", zap.Error(err))
+
+		apperr.Write(w, r, operation.PreviewSolvedPractice, err)
+		return
+	}
+	defer file.Content.Close()
+
+	apiutils.SetPreviewHeaders(w, file.Name)
+	http.ServeContent(metrics.NewCountingWriter(w, metrics.FilesSolvedPreview), r, file.Name, file.ModTime, file.Content)
+}
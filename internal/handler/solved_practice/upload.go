@@ -6,56 +6,56 @@ import (
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/formutils"
+	"practice_vgpek/pkg/logger"
 	"strconv"
-	"time"
 )
 
+// Upload загружает выполненную работу. Поля формы передаются до файла: файл сохраняется прямо из тела запроса
 func (h Handler) Upload(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	// Файл читается из тела запроса во время сохранения, поэтому таймаут рассчитан на медленную сеть
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
 	defer cancel()
 
-	l := h.l.With(
+	l := logger.FromContext(ctx, h.l).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.UploadSolvedPracticeOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
 	)
 
-	// Максимальный размер файла - 10 мб
-	err := r.ParseMultipartForm(10 << 20)
+	values, part, err := formutils.ReadUntilFile(w, r, "file", h.uploads.MaxSize())
 	if err != nil {
-		l.Warn("попытка загрузить большой файл", zap.Error(err))
+		l.Warn("ошибка чтения формы", zap.Error(err))
 
-		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
-			Action: operation.UploadSolvedPracticeOperation,
-			Error:  "Слишком большой файл",
-		})
-		return
-	}
-
-	file, _, err := r.FormFile("file")
-	if err != nil {
-		l.Warn("ошибка чтения файла из формы", zap.Error(err))
+		if errors.Is(err, formutils.ErrTooLarge) {
+			apperr.New(w, r, http.StatusRequestEntityTooLarge, apperr.AppError{
+				Action: operation.UploadSolvedPracticeOperation,
+				Code:   domain.CodeFileTooLarge,
+			})
+			return
+		}
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.UploadSolvedPracticeOperation,
-			Error:  "Ошибка чтения файла",
+			Code:   domain.CodeFormUnreadable,
 		})
 		return
 	}
-	defer file.Close()
 
-	issuedId, err := strconv.Atoi(r.FormValue("issued_practice_id"))
+	issuedId, err := strconv.Atoi(values.Get("issued_practice_id"))
 	if err != nil {
 		l.Warn("некоректный id решаемой работы", zap.Error(err))
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
-			Action: operation.UploadSolvedPracticeOperation,
-			Error:  "Некорректный id практического задания",
+			Action:  operation.UploadSolvedPracticeOperation,
+			Code:    domain.CodeInvalidParam,
+			Details: map[string]any{"field": "issued_practice_id"},
 		})
 		return
 	}
@@ -63,7 +63,34 @@ func (h Handler) Upload(w http.ResponseWriter, r *http.Request) {
 	req := dto.NewSolvedPracticeReq{
 		PerformedAccountId: ctx.Value("AccountId").(int),
 		IssuedPracticeId:   issuedId,
-		File:               &file,
+	}
+
+	// Вместо файла можно передать завершенную возобновляемую загрузку
+	uploadId := values.Get("upload_id")
+
+	switch {
+	case part != nil:
+		req.File = part
+		req.FileName = part.FileName()
+	case uploadId != "":
+		file, err := h.uploads.Open(ctx, dto.UploadId{Id: uploadId})
+		if err != nil {
+			l.Warn("ошибка открытия загрузки", zap.String("id загрузки", uploadId), zap.Error(err))
+
+			apperr.Write(w, r, operation.UploadSolvedPracticeOperation, err)
+			return
+		}
+		defer file.Content.Close()
+
+		req.File = file.Content
+		req.FileName = file.Name
+		req.Resumable = true
+	default:
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.UploadSolvedPracticeOperation,
+			Code:   domain.CodeFileUnreadable,
+		})
+		return
 	}
 
 	l.Info("попытка загрузить практическое задание",
@@ -72,20 +99,15 @@ func (h Handler) Upload(w http.ResponseWriter, r *http.Request) {
 
 	practice, err := h.s.Save(ctx, req)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.UploadSolvedPracticeOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else if err != nil {
-			code := http.StatusInternalServerError
+		apperr.Write(w, r, operation.UploadSolvedPracticeOperation, err)
+		return
+	}
 
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.UploadSolvedPracticeOperation,
-				Error:  err.Error(),
-			})
-			return
+	if part == nil {
+		// Неудаленная загрузка удалится по истечении срока
+		err = h.uploads.Delete(ctx, dto.UploadId{Id: uploadId})
+		if err != nil {
+			l.Warn("ошибка удаления использованной загрузки", zap.String("id загрузки", uploadId), zap.Error(err))
 		}
 	}
 
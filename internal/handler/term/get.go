@@ -0,0 +1,82 @@
+package term
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/queryutils"
+)
+
+// GetCurrentTerm доступен любому авторизованному пользователю, в том числе студентам
+func (h Handler) GetCurrentTerm(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	term, err := h.s.CurrentTerm(ctx)
+	if err != nil {
+		apperr.Write(w, r, operation.GetCurrentTermOperation, err)
+		return
+	}
+
+	render.JSON(w, r, rest.AcademicTerm{}.DomainToResponse(term))
+	return
+}
+
+func (h Handler) GetTerms(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.GetTermsOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	defaultParams, err := queryutils.DefaultParams(r, 10, 0)
+	if err != nil {
+		l.Warn("ошибка получение параметров запроса", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: operation.GetTermsOperation,
+			Code:   domain.CodeBadParams,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.TermObject, domain.GetAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetTermsOperation,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: operation.GetTermsOperation,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	terms, err := h.s.TermsByParams(ctx, defaultParams)
+	if err != nil {
+		apperr.Write(w, r, operation.GetTermsOperation, err)
+		return
+	}
+
+	l.Info("семестры успешно отданы", zap.Int("кол-во", len(terms)))
+
+	render.JSON(w, r, rest.AcademicTerms{}.DomainToResponse(terms))
+	return
+}
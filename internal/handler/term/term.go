@@ -0,0 +1,41 @@
+package term
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
+)
+
+type Service interface {
+	NewTerm(ctx context.Context, req dto.NewTermReq) (domain.AcademicTerm, error)
+	CurrentTerm(ctx context.Context) (domain.AcademicTerm, error)
+	TermsByParams(ctx context.Context, p params.Default) ([]domain.AcademicTerm, error)
+
+	SetCurrentTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error)
+	CloseTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error)
+}
+
+type AccountMediator interface {
+	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
+}
+
+type Handler struct {
+	l *zap.Logger
+	s Service
+
+	accountMediator AccountMediator
+
+	timeouts apiutils.Timeouts
+}
+
+func NewTermHandler(service Service, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:               logger,
+		s:               service,
+		accountMediator: accountMediator,
+		timeouts:        timeouts,
+	}
+}
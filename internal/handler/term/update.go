@@ -0,0 +1,76 @@
+package term
+
+import (
+	"context"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/transport/rest"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+func (h Handler) SetCurrentTerm(w http.ResponseWriter, r *http.Request) {
+	h.editTerm(w, r, operation.SetCurrentTermOperation, h.s.SetCurrentTerm)
+}
+
+func (h Handler) CloseTerm(w http.ResponseWriter, r *http.Request) {
+	h.editTerm(w, r, operation.CloseTermOperation, h.s.CloseTerm)
+}
+
+// editTerm проверяет право на изменение семестров и применяет изменение к семестру из параметра id
+func (h Handler) editTerm(w http.ResponseWriter, r *http.Request, op string,
+	edit func(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error)) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, op),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		l.Warn(operation.DecodeError, zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeBadRequest,
+		})
+		return
+	}
+
+	hasAccess, err := h.accountMediator.HasAccess(ctx, ctx.Value("AccountId").(int), domain.TermObject, domain.EditAction)
+	if err != nil {
+		l.Warn("ошибка проверки доступа", zap.Error(err))
+
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeAccessCheckFailed,
+		})
+		return
+	}
+
+	if !hasAccess {
+		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
+			Action: op,
+			Code:   apperr.CodeForbidden,
+		})
+		return
+	}
+
+	term, err := edit(ctx, dto.EntityId{Id: id})
+	if err != nil {
+		apperr.Write(w, r, op, err)
+		return
+	}
+
+	render.JSON(w, r, rest.AcademicTerm{}.DomainToResponse(term))
+	return
+}
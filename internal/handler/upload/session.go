@@ -0,0 +1,175 @@
+package upload
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+)
+
+// Options сообщает клиенту версию протокола, поддерживаемые расширения и допустимый размер файла
+func (h Handler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Resumable", tusVersion)
+	w.Header().Set("Tus-Version", tusVersion)
+	w.Header().Set("Tus-Extension", tusExtensions)
+	w.Header().Set("Tus-Max-Size", strconv.FormatInt(h.s.ResumableMaxSize(), 10))
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// Create создает загрузку размера Upload-Length и возвращает ее адрес в заголовке Location
+func (h Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.CreateUploadOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	if !checkVersion(w, r, operation.CreateUploadOperation) {
+		return
+	}
+
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		l.Warn("ошибка чтения размера загрузки", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.CreateUploadOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "Upload-Length"},
+		})
+		return
+	}
+
+	fileName, err := metadataFileName(r.Header.Get("Upload-Metadata"))
+	if err != nil {
+		l.Warn("ошибка чтения метаданных загрузки", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.CreateUploadOperation,
+			Code:    domain.CodeInvalidParam,
+			Details: map[string]any{"field": "Upload-Metadata"},
+		})
+		return
+	}
+
+	session, err := h.s.Create(ctx, dto.NewUploadReq{
+		FileName: fileName,
+		Length:   length,
+	})
+	if err != nil {
+		apperr.Write(w, r, operation.CreateUploadOperation, err)
+		return
+	}
+
+	setSessionHeaders(w, session)
+	w.Header().Set("Location", "/upload/"+session.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	return
+}
+
+// Head возвращает принятый размер загрузки, с которого клиент продолжает передачу
+func (h Handler) Head(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	if !checkVersion(w, r, operation.GetUploadOperation) {
+		return
+	}
+
+	session, err := h.s.Session(ctx, dto.UploadId{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		apperr.Write(w, r, operation.GetUploadOperation, err)
+		return
+	}
+
+	setSessionHeaders(w, session)
+	w.Header().Set("Upload-Length", strconv.FormatInt(session.Length, 10))
+
+	w.WriteHeader(http.StatusOK)
+	return
+}
+
+// Patch дописывает тело запроса в загрузку со смещения Upload-Offset
+func (h Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.File)
+	defer cancel()
+
+	l := logger.FromContext(ctx, h.l).With(
+		zap.String(layer.Endpoint, r.RequestURI),
+		zap.String(operation.Operation, operation.AppendUploadOperation),
+		zap.String(layer.Layer, layer.HTTPLayer),
+	)
+
+	if !checkVersion(w, r, operation.AppendUploadOperation) {
+		return
+	}
+
+	if r.Header.Get("Content-Type") != offsetContentType {
+		apperr.New(w, r, http.StatusUnsupportedMediaType, apperr.AppError{
+			Action:  operation.AppendUploadOperation,
+			Code:    domain.CodeMediaType,
+			Details: map[string]any{"content_type": offsetContentType},
+		})
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		l.Warn("ошибка чтения смещения загрузки", zap.Error(err))
+
+		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
+			Action:  operation.AppendUploadOperation,
+			Code:    domain.CodeFieldRequired,
+			Details: map[string]any{"field": "Upload-Offset"},
+		})
+		return
+	}
+
+	session, err := h.s.Append(ctx, dto.UploadChunkReq{
+		Id:     chi.URLParam(r, "id"),
+		Offset: offset,
+		Body:   r.Body,
+	})
+	if err != nil {
+		// Принятая до ошибки часть данных сохранена, клиент узнает новое смещение запросом HEAD
+		apperr.Write(w, r, operation.AppendUploadOperation, err)
+		return
+	}
+
+	setSessionHeaders(w, session)
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// Delete прерывает загрузку и удаляет принятые данные
+func (h Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
+	defer cancel()
+
+	if !checkVersion(w, r, operation.DeleteUploadOperation) {
+		return
+	}
+
+	err := h.s.Delete(ctx, dto.UploadId{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		apperr.Write(w, r, operation.DeleteUploadOperation, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
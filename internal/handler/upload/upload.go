@@ -0,0 +1,90 @@
+package upload
+
+import (
+	"context"
+	"encoding/base64"
+	"go.uber.org/zap"
+	"net/http"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/apiutils"
+	"practice_vgpek/pkg/apperr"
+	"strconv"
+	"strings"
+)
+
+// Возобновляемая загрузка реализует часть протокола tus 1.0: расширения creation, termination и expiration
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination,expiration"
+
+	// offsetContentType тип тела запроса с частью загрузки
+	offsetContentType = "application/offset+octet-stream"
+)
+
+type Service interface {
+	ResumableMaxSize() int64
+
+	Create(ctx context.Context, req dto.NewUploadReq) (domain.UploadSession, error)
+	Session(ctx context.Context, req dto.UploadId) (domain.UploadSession, error)
+	Append(ctx context.Context, req dto.UploadChunkReq) (domain.UploadSession, error)
+	Delete(ctx context.Context, req dto.UploadId) error
+}
+
+type Handler struct {
+	l        *zap.Logger
+	s        Service
+	timeouts apiutils.Timeouts
+}
+
+func NewUploadHandler(service Service, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
+	return Handler{
+		l:        logger,
+		s:        service,
+		timeouts: timeouts,
+	}
+}
+
+// checkVersion проверяет версию протокола клиента и при несовпадении отвечает ошибкой
+func checkVersion(w http.ResponseWriter, r *http.Request, op string) bool {
+	w.Header().Set("Tus-Resumable", tusVersion)
+
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		w.Header().Set("Tus-Version", tusVersion)
+
+		apperr.New(w, r, http.StatusPreconditionFailed, apperr.AppError{
+			Action: op,
+			Code:   domain.CodeUploadVersion,
+		})
+		return false
+	}
+
+	return true
+}
+
+// setSessionHeaders передает клиенту состояние загрузки
+func setSessionHeaders(w http.ResponseWriter, session domain.UploadSession) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	w.Header().Set("Upload-Expires", session.ExpiresAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// metadataFileName возвращает имя файла из заголовка Upload-Metadata: пары "ключ значение-в-base64" через запятую
+func metadataFileName(metadata string) (string, error) {
+	for _, pair := range strings.Split(metadata, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+
+		if key != "filename" && key != "name" {
+			continue
+		}
+
+		name, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", err
+		}
+
+		return string(name), nil
+	}
+
+	return "", nil
+}
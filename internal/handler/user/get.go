@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"errors"
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
 	"net/http"
@@ -12,18 +11,18 @@ import (
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/transport/rest"
 	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/queryutils"
 	"strconv"
-	"time"
 )
 
 func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 300000*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
 	var req dto.EntityId
 
-	l := h.logger.With(
+	l := logger.FromContext(ctx, h.logger).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetAccountOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -35,7 +34,7 @@ func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Преобразование запроса на получение аккаунта",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -48,7 +47,7 @@ func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -56,28 +55,15 @@ func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	account, err := h.AccountService.EntityAccountById(ctx, req)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
 	l.Info("аккаунт успешно отдан", zap.Int("id аккаунта", account.Id))
@@ -87,10 +73,10 @@ func (h Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h Handler) GetAccountsByParam(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.logger.With(
+	l := logger.FromContext(ctx, h.logger).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetAccountsByParamsOperation),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -102,7 +88,7 @@ func (h Handler) GetAccountsByParam(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Преобразование запроса на получение аккаунта",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -115,7 +101,7 @@ func (h Handler) GetAccountsByParam(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -123,28 +109,15 @@ func (h Handler) GetAccountsByParam(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	accounts, err := h.AccountService.EntityAccountByParam(ctx, stateParams)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
 	l.Info("аккаунт успешно отдан", zap.Int("кол-во аккаунтов", len(accounts)))
@@ -154,10 +127,10 @@ func (h Handler) GetAccountsByParam(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h Handler) GetPersonsByParam(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeouts.Request)
 	defer cancel()
 
-	l := h.logger.With(
+	l := logger.FromContext(ctx, h.logger).With(
 		zap.String(layer.Endpoint, r.RequestURI),
 		zap.String(operation.Operation, operation.GetPersonsByParams),
 		zap.String(layer.Layer, layer.HTTPLayer),
@@ -169,7 +142,7 @@ func (h Handler) GetPersonsByParam(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusBadRequest, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Преобразование запроса на получение аккаунта",
+			Code:   apperr.CodeBadRequest,
 		})
 		return
 	}
@@ -182,7 +155,7 @@ func (h Handler) GetPersonsByParam(w http.ResponseWriter, r *http.Request) {
 
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Ошибка проверки доступа",
+			Code:   domain.CodeAccessCheckFailed,
 		})
 		return
 	}
@@ -190,28 +163,15 @@ func (h Handler) GetPersonsByParam(w http.ResponseWriter, r *http.Request) {
 	if !hasAccess {
 		apperr.New(w, r, http.StatusForbidden, apperr.AppError{
 			Action: operation.GetAccountOperation,
-			Error:  "Недостаточно прав",
+			Code:   apperr.CodeForbidden,
 		})
 		return
 	}
 
 	persons, err := h.PersonService.EntityPersonByParam(ctx, stateParams)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			apperr.New(w, r, http.StatusRequestTimeout, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  "Таймаут",
-			})
-			return
-		} else {
-			code := http.StatusInternalServerError
-
-			apperr.New(w, r, code, apperr.AppError{
-				Action: operation.GetActionOperation,
-				Error:  err.Error(),
-			})
-			return
-		}
+		apperr.Write(w, r, operation.GetActionOperation, err)
+		return
 	}
 
 	l.Info("пользователи успешно отданы", zap.Int("кол-во пользователей", len(persons)))
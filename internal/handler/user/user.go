@@ -6,6 +6,7 @@ import (
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apiutils"
 )
 
 type AccountService interface {
@@ -28,9 +29,11 @@ type Handler struct {
 	PersonService
 
 	AccountMediator
+
+	timeouts apiutils.Timeouts
 }
 
-func New(accountService AccountService, personService PersonService, accountMediator AccountMediator, logger *zap.Logger) Handler {
+func New(accountService AccountService, personService PersonService, accountMediator AccountMediator, timeouts apiutils.Timeouts, logger *zap.Logger) Handler {
 	return Handler{
 		logger: logger,
 
@@ -38,5 +41,6 @@ func New(accountService AccountService, personService PersonService, accountMedi
 		PersonService:  personService,
 
 		AccountMediator: accountMediator,
+		timeouts:        timeouts,
 	}
 }
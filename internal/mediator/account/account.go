@@ -5,6 +5,7 @@ import (
 	"errors"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/pkg/metrics"
 )
 
 type KeyService interface {
@@ -58,8 +59,6 @@ func (m Mediator) RoleByAccountId(ctx context.Context, id int) (domain.Role, err
 }
 
 func (m Mediator) HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error) {
-	var hasObject, hasAction bool
-
 	role, err := m.RoleByAccountId(ctx, accountId)
 	if err != nil {
 		return false, err
@@ -71,17 +70,19 @@ func (m Mediator) HasAccess(ctx context.Context, accountId int, objectName, acti
 	}
 
 	if len(perms) == 0 {
+		metrics.RBACDenials.WithLabelValues(objectName, actionName).Inc()
 		return false, errors.New("no result")
 	}
 
+	// Действие должно быть разрешено именно над этим объектом. Раньше действие и объект проверялись
+	// по отдельности, и роль с GET над DISCIPLINE и ADD над MARK получала ADD над DISCIPLINE
 	for _, perm := range perms {
-		if perm.Object.Name == objectName {
-			hasObject = true
-		}
-		if perm.Action.Name == actionName {
-			hasAction = true
+		if perm.Object.Name == objectName && perm.Action.Name == actionName {
+			return true, nil
 		}
 	}
 
-	return hasAction && hasObject, nil
+	metrics.RBACDenials.WithLabelValues(objectName, actionName).Inc()
+
+	return false, nil
 }
@@ -0,0 +1,104 @@
+package account
+
+import (
+	"context"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"testing"
+)
+
+// Роли тестов: у аккаунта id совпадает с id его ключа и роли
+const (
+	teacherRoleId = 1
+	emptyRoleId   = 2
+)
+
+type fakeAccounts struct{}
+
+func (fakeAccounts) AccountById(_ context.Context, req dto.EntityId) (domain.Account, error) {
+	return domain.Account{KeyId: req.Id}, nil
+}
+
+type fakeKeys struct{}
+
+func (fakeKeys) KeyById(_ context.Context, req dto.EntityId) (domain.Key, error) {
+	return domain.Key{Id: req.Id, RoleId: req.Id}, nil
+}
+
+type fakeRoles struct{}
+
+func (fakeRoles) RoleById(_ context.Context, req dto.EntityId) (domain.Role, error) {
+	return domain.Role{ID: req.Id}, nil
+}
+
+// fakePerms разрешения по id роли
+type fakePerms map[int][]domain.Permissions
+
+func (p fakePerms) ByRoleId(_ context.Context, req dto.EntityId) ([]domain.Permissions, error) {
+	return p[req.Id], nil
+}
+
+func permission(object, action string) domain.Permissions {
+	return domain.Permissions{
+		Object: domain.Object{Name: object},
+		Action: domain.Action{Name: action},
+	}
+}
+
+func TestHasAccess(t *testing.T) {
+	m := NewAccountMediator(fakeAccounts{}, fakeKeys{}, fakeRoles{}, fakePerms{
+		teacherRoleId: {
+			permission(domain.DisciplineObject, domain.GetAction),
+			permission(domain.MarkObject, domain.AddAction),
+		},
+	})
+
+	tests := []struct {
+		name      string
+		accountId int
+		object    string
+		action    string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "разрешенная пара объекта и действия",
+			accountId: teacherRoleId,
+			object:    domain.DisciplineObject,
+			action:    domain.GetAction,
+			want:      true,
+		},
+		{
+			name:      "действие разрешено только над другим объектом",
+			accountId: teacherRoleId,
+			object:    domain.DisciplineObject,
+			action:    domain.AddAction,
+		},
+		{
+			name:      "объект без разрешений роли",
+			accountId: teacherRoleId,
+			object:    domain.KeyObject,
+			action:    domain.GetAction,
+		},
+		{
+			name:      "роль без разрешений",
+			accountId: emptyRoleId,
+			object:    domain.DisciplineObject,
+			action:    domain.GetAction,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.HasAccess(context.Background(), tt.accountId, tt.object, tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидается ошибка: %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("доступ к %s %s: %v, ожидается %v", tt.action, tt.object, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package practice
 import (
 	"context"
 	"practice_vgpek/internal/model/entity"
+	"time"
 )
 
 type AccountDAO interface {
@@ -13,25 +14,38 @@ type IssuedPracticeDAO interface {
 	ById(ctx context.Context, id int) (entity.IssuedPractice, error)
 }
 
-type KeyDAO interface {
-	ById(ctx context.Context, id int) (entity.Key, error)
+type GroupDAO interface {
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
+}
+
+type AssignmentDAO interface {
+	ByAccountId(ctx context.Context, accountId int) ([]entity.TeachingAssignment, error)
+}
+
+type TermDAO interface {
+	ById(ctx context.Context, id int) (entity.AcademicTerm, error)
 }
 
 type Mediator struct {
 	accountDAO        AccountDAO
 	issuedPracticeDAO IssuedPracticeDAO
-	keyDAO            KeyDAO
+	groupDAO          GroupDAO
+	assignmentDAO     AssignmentDAO
+	termDAO           TermDAO
 }
 
-func NewIssuedPracticeMediator(accountDAO AccountDAO, issuedPracticeDAO IssuedPracticeDAO, keyDAO KeyDAO) Mediator {
+func NewIssuedPracticeMediator(accountDAO AccountDAO, issuedPracticeDAO IssuedPracticeDAO,
+	groupDAO GroupDAO, assignmentDAO AssignmentDAO, termDAO TermDAO) Mediator {
 	return Mediator{
 		accountDAO:        accountDAO,
 		issuedPracticeDAO: issuedPracticeDAO,
-		keyDAO:            keyDAO,
+		groupDAO:          groupDAO,
+		assignmentDAO:     assignmentDAO,
+		termDAO:           termDAO,
 	}
 }
 
-// IssuedGroupMatch проверяет, что группа ключа совпадает с группой практики
+// IssuedGroupMatch проверяет, что текущая группа аккаунта совпадает с группой практики
 func (m Mediator) IssuedGroupMatch(ctx context.Context, accountId, practiceId int) (bool, error) {
 	var match bool
 
@@ -40,18 +54,23 @@ func (m Mediator) IssuedGroupMatch(ctx context.Context, accountId, practiceId in
 		return false, err
 	}
 
+	// Выпущенные и отчисленные студенты не имеют доступа к практическим
+	if !acc.IsActive {
+		return false, nil
+	}
+
 	practice, err := m.issuedPracticeDAO.ById(ctx, practiceId)
 	if err != nil {
 		return false, err
 	}
 
-	key, err := m.keyDAO.ById(ctx, acc.KeyId)
+	membership, err := m.groupDAO.CurrentByAccountId(ctx, acc.Id, time.Now())
 	if err != nil {
 		return false, err
 	}
 
 	for _, group := range practice.TargetGroups {
-		if group == key.GroupName {
+		if group == membership.GroupName {
 			match = true
 			break
 		}
@@ -59,3 +78,104 @@ func (m Mediator) IssuedGroupMatch(ctx context.Context, accountId, practiceId in
 
 	return match, nil
 }
+
+// TeacherAssigned проверяет, что преподаватель назначен на дисциплину в каждой из переданных групп
+// в указанном семестре. Пустой семестр означает назначение в любом семестре.
+// Роль аккаунта не учитывается: администратор без назначений не считается назначенным
+// и, чтобы выдавать задания и оценивать работы, назначает себя на дисциплину
+func (m Mediator) TeacherAssigned(ctx context.Context, accountId, disciplineId int, term string, groups []string) (bool, error) {
+	if len(groups) == 0 {
+		return false, nil
+	}
+
+	assignments, err := m.assignmentDAO.ByAccountId(ctx, accountId)
+	if err != nil {
+		return false, err
+	}
+
+	assigned := make(map[string]bool, len(assignments))
+
+	for _, assignment := range assignments {
+		if assignment.DisciplineId == disciplineId && (term == "" || assignment.Term == term) {
+			assigned[assignment.GroupName] = true
+		}
+	}
+
+	for _, group := range groups {
+		if !assigned[group] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// TermOpen проверяет, что семестр практического задания не закрыт.
+// Задания, выданные до появления семестров, считаются открытыми
+func (m Mediator) TermOpen(ctx context.Context, practiceId int) (bool, error) {
+	practice, err := m.issuedPracticeDAO.ById(ctx, practiceId)
+	if err != nil {
+		return false, err
+	}
+
+	if practice.AcademicTermId == nil {
+		return true, nil
+	}
+
+	term, err := m.termDAO.ById(ctx, *practice.AcademicTermId)
+	if err != nil {
+		return false, err
+	}
+
+	return term.ClosedAt == nil, nil
+}
+
+// TeacherGroups возвращает целевые группы задания, с работами которых может работать аккаунт:
+// автору доступны все группы, остальным - группы, где они ведут дисциплину задания в его семестре
+func (m Mediator) TeacherGroups(ctx context.Context, accountId int, practice entity.IssuedPractice) (map[string]bool, error) {
+	groups := make(map[string]bool, len(practice.TargetGroups))
+
+	if practice.AccountId == accountId {
+		for _, group := range practice.TargetGroups {
+			groups[group] = true
+		}
+
+		return groups, nil
+	}
+
+	if practice.DisciplineId == nil {
+		return groups, nil
+	}
+
+	var term string
+
+	if practice.AcademicTermId != nil {
+		termEntity, err := m.termDAO.ById(ctx, *practice.AcademicTermId)
+		if err != nil {
+			return nil, err
+		}
+
+		term = termEntity.Name
+	}
+
+	assignments, err := m.assignmentDAO.ByAccountId(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[string]bool, len(assignments))
+
+	for _, assignment := range assignments {
+		if assignment.DisciplineId == *practice.DisciplineId && (term == "" || assignment.Term == term) {
+			assigned[assignment.GroupName] = true
+		}
+	}
+
+	for _, group := range practice.TargetGroups {
+		if assigned[group] {
+			groups[group] = true
+		}
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,97 @@
+package practice
+
+import (
+	"context"
+	"practice_vgpek/internal/model/entity"
+	"testing"
+)
+
+const (
+	teacherId = 1
+	// adminId администратор, не назначенный ни на одну дисциплину
+	adminId = 2
+
+	disciplineId = 10
+)
+
+// fakeAssignments назначения преподавателей по id аккаунта
+type fakeAssignments map[int][]entity.TeachingAssignment
+
+func (a fakeAssignments) ByAccountId(_ context.Context, accountId int) ([]entity.TeachingAssignment, error) {
+	return a[accountId], nil
+}
+
+func TestTeacherAssigned(t *testing.T) {
+	m := NewIssuedPracticeMediator(nil, nil, nil, fakeAssignments{
+		teacherId: {
+			{AccountId: teacherId, DisciplineId: disciplineId, GroupName: "ИС-21", Term: "2024-1"},
+			{AccountId: teacherId, DisciplineId: disciplineId, GroupName: "ИС-22", Term: "2024-1"},
+			{AccountId: teacherId, DisciplineId: disciplineId + 1, GroupName: "ИС-23", Term: "2024-1"},
+		},
+	}, nil)
+
+	tests := []struct {
+		name       string
+		accountId  int
+		discipline int
+		term       string
+		groups     []string
+		want       bool
+	}{
+		{
+			name:       "назначен во всех группах",
+			accountId:  teacherId,
+			discipline: disciplineId,
+			term:       "2024-1",
+			groups:     []string{"ИС-21", "ИС-22"},
+			want:       true,
+		},
+		{
+			name:       "не назначен в одной из групп",
+			accountId:  teacherId,
+			discipline: disciplineId,
+			term:       "2024-1",
+			groups:     []string{"ИС-21", "ИС-23"},
+		},
+		{
+			name:       "назначен в другом семестре",
+			accountId:  teacherId,
+			discipline: disciplineId,
+			term:       "2024-2",
+			groups:     []string{"ИС-21"},
+		},
+		{
+			name:       "пустой семестр - назначение в любом семестре",
+			accountId:  teacherId,
+			discipline: disciplineId,
+			groups:     []string{"ИС-21"},
+			want:       true,
+		},
+		{
+			name:       "без групп",
+			accountId:  teacherId,
+			discipline: disciplineId,
+			term:       "2024-1",
+		},
+		{
+			name:       "администратор без назначений не считается назначенным",
+			accountId:  adminId,
+			discipline: disciplineId,
+			term:       "2024-1",
+			groups:     []string{"ИС-21"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.TeacherAssigned(context.Background(), tt.accountId, tt.discipline, tt.term, tt.groups)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("назначение в группах %v: %v, ожидается %v", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
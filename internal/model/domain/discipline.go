@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type Discipline struct {
+	Id int
+
+	Name        string
+	Description string
+
+	CreatedAt time.Time
+
+	IsDeleted bool
+	DeletedAt *time.Time
+}
+
+type TeachingAssignment struct {
+	Id int
+
+	AccountId   int
+	TeacherName string
+
+	DisciplineId   int
+	DisciplineName string
+
+	GroupName string
+	Term      string
+
+	CreatedAt time.Time
+}
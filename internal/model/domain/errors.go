@@ -0,0 +1,70 @@
+package domain
+
+// Коды ошибок логики. Код стабилен и не зависит от текста сообщения,
+// общие коды для каждого вида ошибки объявлены в apperr. Тексты на всех
+// языках - в messages.go
+const (
+	CodeFileInfected    = "file_infected"
+	CodeFileNotScanned  = "file_not_scanned"
+	CodeFileTooLarge    = "file_too_large"
+	CodeFileUnreadable  = "file_unreadable"
+	CodeFormUnreadable  = "form_unreadable"
+	CodeUnsupportedFile = "unsupported_file_type"
+	CodeQuotaExceeded   = "quota_exceeded"
+	CodeQuotaTarget     = "quota_target_ambiguous"
+
+	CodeLinkInvalid = "link_invalid"
+	CodeLinkExpired = "link_expired"
+	CodeLinkRevoked = "link_revoked"
+	CodeLinkUsed    = "link_used"
+
+	CodePreviewUnsupported = "preview_unsupported"
+	CodePreviewFailed      = "preview_failed"
+
+	CodeUploadNotFound   = "upload_not_found"
+	CodeUploadOffset     = "upload_offset_mismatch"
+	CodeUploadBusy       = "upload_busy"
+	CodeUploadIncomplete = "upload_incomplete"
+	CodeUploadVersion    = "upload_version_unsupported"
+
+	CodeRegKeyInvalid      = "reg_key_invalid"
+	CodeRegKeyExhausted    = "reg_key_exhausted"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeTokenInvalid       = "token_invalid"
+	CodeAuthHeaderMissing  = "auth_header_missing"
+	CodeAuthHeaderInvalid  = "auth_header_invalid"
+	CodeAccessCheckFailed  = "access_check_failed"
+
+	CodeAccountInactive      = "account_inactive"
+	CodeTermClosed           = "term_closed"
+	CodeClosedTermCurrent    = "closed_term_current"
+	CodeInvalidTermDates     = "invalid_term_dates"
+	CodeSameTerm             = "same_term"
+	CodeNothingToClone       = "nothing_to_clone"
+	CodePracticeDeleted      = "issued_practice_deleted"
+	CodePracticeNotDeleted   = "issued_practice_not_deleted"
+	CodePracticeNoDiscipline = "issued_practice_no_discipline"
+	CodeDisciplineDeleted    = "discipline_deleted"
+	CodeNotAuthor            = "not_author"
+	CodeNotAssigned          = "teacher_not_assigned"
+	CodeNotTargetGroup       = "not_target_group"
+	CodeGroupMismatch        = "group_mismatch"
+	CodeEmptyGroup           = "empty_group"
+	CodeMarkChanged          = "mark_changed"
+	CodeEmptyTable           = "empty_table"
+	CodeMissingColumns       = "missing_columns"
+	CodeQueueFull            = "queue_full"
+
+	// Ошибки проверки запроса, в текст подставляются детали ошибки
+	CodeBadParams        = "bad_query_params"
+	CodeFieldRequired    = "field_required"
+	CodeFieldsRequired   = "fields_required"
+	CodeInvalidParam     = "invalid_param"
+	CodeInvalidDate      = "invalid_date"
+	CodeValueOutOfRange  = "value_out_of_range"
+	CodeValueNotPositive = "value_not_positive"
+	CodeValueNotAllowed  = "value_not_allowed"
+	CodeMediaType        = "unsupported_media_type"
+	CodeInvalidFilter    = "invalid_filter"
+	CodeEmptyQuery       = "empty_search_query"
+)
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"io"
+	"practice_vgpek/pkg/apperr"
+	"time"
+)
+
+var (
+	// ErrFileInfected файл заражен и не может быть сохранен или выдан
+	ErrFileInfected = apperr.Forbidden(CodeFileInfected)
+	// ErrFileNotScanned файл не удалось проверить антивирусом
+	ErrFileNotScanned = apperr.Unavailable(CodeFileNotScanned)
+	// ErrFileTooLarge файл больше допустимого размера загрузки
+	ErrFileTooLarge = apperr.TooLarge(CodeFileTooLarge)
+)
+
+// События журнала аудита
+const (
+	AuditFileInfected  = "file_infected"
+	AuditFileScanError = "file_scan_error"
+)
+
+// File сохраненный файл, открытый для выдачи
+type File struct {
+	// Name имя файла для выдачи, по умолчанию - имя без каталога хранилища
+	Name string
+
+	Size    int64
+	ModTime time.Time
+
+	// Hash sha256 содержимого в hex, пусто для файлов вне хранилища
+	Hash string
+
+	Content io.ReadSeekCloser
+}
+
+// StoredFile файл, сохраненный в хранилище. Размер и хэш считаются при записи
+type StoredFile struct {
+	Path string
+	Size int64
+
+	// Hash sha256 содержимого файла в hex
+	Hash string
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+const (
+	RegistrationReason = "регистрация"
+	TransferReason     = "перевод"
+	PromotionReason    = "перевод на следующий курс"
+	GraduationReason   = "выпуск"
+)
+
+type GroupMembership struct {
+	AccountId int
+	GroupName string
+
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+
+	Reason string
+}
+
+type Cohort struct {
+	GroupName    string
+	NewGroupName string
+
+	IsGraduated bool
+	EffectiveAt time.Time
+
+	// Members аккаунты, затронутые переводом
+	Members []GroupMembership
+}
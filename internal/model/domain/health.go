@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// HealthCheck результат одной проверки готовности: база данных, миграции, каталог файлов
+type HealthCheck struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+type Readiness struct {
+	Checks []HealthCheck
+}
+
+// Ready сообщает, прошли ли все проверки
+func (r Readiness) Ready() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+type BuildInfo struct {
+	Commit    string
+	BuildTime string
+	Modified  bool
+	GoVersion string
+
+	// MigrationVersion версия миграций в базе данных, nil - если базу не удалось опросить
+	MigrationVersion         *int64
+	ExpectedMigrationVersion int64
+}
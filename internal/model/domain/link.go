@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"practice_vgpek/pkg/apperr"
+	"time"
+)
+
+var (
+	// ErrLinkInvalid ссылка повреждена, подделана или не существует
+	ErrLinkInvalid = apperr.Forbidden(CodeLinkInvalid)
+	// ErrLinkExpired срок действия ссылки истек
+	ErrLinkExpired = apperr.Gone(CodeLinkExpired)
+	// ErrLinkRevoked ссылка отозвана
+	ErrLinkRevoked = apperr.Gone(CodeLinkRevoked)
+	// ErrLinkUsed одноразовая ссылка уже использована
+	ErrLinkUsed = apperr.Gone(CodeLinkUsed)
+)
+
+// События журнала аудита для подписанных ссылок
+const (
+	AuditLinkInvalid    = "download_link_invalid"
+	AuditLinkExpired    = "download_link_expired"
+	AuditLinkRevoked    = "download_link_revoked"
+	AuditLinkRevokedUse = "download_link_revoked_use"
+	AuditLinkReused     = "download_link_reused"
+)
+
+// DownloadLink подписанная ссылка на скачивание файла
+type DownloadLink struct {
+	Id        string
+	AccountId int
+
+	Object     string
+	ResourceId int
+
+	SingleUse bool
+	ExpiresAt time.Time
+
+	// Query подписанные параметры ссылки, которые добавляются к адресу скачивания
+	Query string
+}
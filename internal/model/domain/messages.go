@@ -0,0 +1,238 @@
+package domain
+
+import "practice_vgpek/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.Catalogue{
+		CodeFileInfected: {
+			i18n.Ru: "Файл заражен",
+			i18n.En: "The file is infected",
+		},
+		CodeFileNotScanned: {
+			i18n.Ru: "Файл не проверен антивирусом",
+			i18n.En: "The file has not been scanned for viruses yet",
+		},
+		CodeFileTooLarge: {
+			i18n.Ru: "Слишком большой файл",
+			i18n.En: "The file is too large",
+		},
+		CodeFileUnreadable: {
+			i18n.Ru: "Ошибка чтения файла",
+			i18n.En: "Failed to read the file",
+		},
+		CodeFormUnreadable: {
+			i18n.Ru: "Ошибка чтения формы",
+			i18n.En: "Failed to read the form",
+		},
+		CodeUnsupportedFile: {
+			i18n.Ru: "Поддерживаются только файлы {formats}",
+			i18n.En: "Only {formats} files are supported",
+		},
+		CodeQuotaExceeded: {
+			i18n.Ru: "Превышена квота на хранение файлов",
+			i18n.En: "Storage quota exceeded",
+		},
+		CodeQuotaTarget: {
+			i18n.Ru: "Нужно указать либо роль, либо группу",
+			i18n.En: "Specify either a role or a group",
+		},
+
+		CodeLinkInvalid: {
+			i18n.Ru: "Недействительная ссылка",
+			i18n.En: "The link is invalid",
+		},
+		CodeLinkExpired: {
+			i18n.Ru: "Срок действия ссылки истек",
+			i18n.En: "The link has expired",
+		},
+		CodeLinkRevoked: {
+			i18n.Ru: "Ссылка отозвана",
+			i18n.En: "The link has been revoked",
+		},
+		CodeLinkUsed: {
+			i18n.Ru: "Ссылка уже использована",
+			i18n.En: "The link has already been used",
+		},
+
+		CodePreviewUnsupported: {
+			i18n.Ru: "Предпросмотр файла недоступен",
+			i18n.En: "Preview is not available for this file",
+		},
+		CodePreviewFailed: {
+			i18n.Ru: "Не удалось подготовить предпросмотр файла",
+			i18n.En: "Failed to prepare the file preview",
+		},
+
+		CodeUploadNotFound: {
+			i18n.Ru: "Загрузка не найдена",
+			i18n.En: "Upload not found",
+		},
+		CodeUploadOffset: {
+			i18n.Ru: "Смещение не совпадает с размером принятых данных",
+			i18n.En: "The offset does not match the received size",
+		},
+		CodeUploadBusy: {
+			i18n.Ru: "Загрузка уже принимает данные",
+			i18n.En: "The upload is already receiving data",
+		},
+		CodeUploadIncomplete: {
+			i18n.Ru: "Загрузка не завершена",
+			i18n.En: "The upload is not complete",
+		},
+		CodeUploadVersion: {
+			i18n.Ru: "Неподдерживаемая версия протокола загрузки",
+			i18n.En: "Unsupported upload protocol version",
+		},
+
+		CodeRegKeyInvalid: {
+			i18n.Ru: "Невалидный ключ регистрации",
+			i18n.En: "The registration key is invalid",
+		},
+		CodeRegKeyExhausted: {
+			i18n.Ru: "Превышено кол-во регистраций по ключу",
+			i18n.En: "The registration key has no uses left",
+		},
+		CodeInvalidCredentials: {
+			i18n.Ru: "Неправильный логин или пароль",
+			i18n.En: "Invalid login or password",
+		},
+		CodeTokenInvalid: {
+			i18n.Ru: "Недействительный токен",
+			i18n.En: "The token is invalid",
+		},
+		CodeAuthHeaderMissing: {
+			i18n.Ru: "Пустой заголовок Authorization",
+			i18n.En: "The Authorization header is empty",
+		},
+		CodeAuthHeaderInvalid: {
+			i18n.Ru: "Невалидный заголовок Authorization",
+			i18n.En: "The Authorization header is malformed",
+		},
+		CodeAccessCheckFailed: {
+			i18n.Ru: "Ошибка проверки доступа",
+			i18n.En: "Failed to check permissions",
+		},
+
+		CodeAccountInactive: {
+			i18n.Ru: "Аккаунт деактивирован",
+			i18n.En: "The account is deactivated",
+		},
+		CodeTermClosed: {
+			i18n.Ru: "Семестр закрыт, изменения недоступны",
+			i18n.En: "The term is closed and read-only",
+		},
+		CodeClosedTermCurrent: {
+			i18n.Ru: "Закрытый семестр не может быть текущим",
+			i18n.En: "A closed term cannot be the current one",
+		},
+		CodeInvalidTermDates: {
+			i18n.Ru: "Некорректные даты семестра",
+			i18n.En: "Invalid term dates",
+		},
+		CodeSameTerm: {
+			i18n.Ru: "Исходный и целевой семестр совпадают",
+			i18n.En: "The source and target terms are the same",
+		},
+		CodeNothingToClone: {
+			i18n.Ru: "В исходном семестре нет заданий по дисциплине",
+			i18n.En: "The source term has no assignments for the discipline",
+		},
+		CodePracticeDeleted: {
+			i18n.Ru: "Практическое задание удалено",
+			i18n.En: "The assignment has been deleted",
+		},
+		CodePracticeNotDeleted: {
+			i18n.Ru: "Практическое задание не удалено",
+			i18n.En: "The assignment is not deleted",
+		},
+		CodePracticeNoDiscipline: {
+			i18n.Ru: "У задания не указана дисциплина",
+			i18n.En: "The assignment has no discipline",
+		},
+		CodeDisciplineDeleted: {
+			i18n.Ru: "Дисциплина удалена",
+			i18n.En: "The discipline has been deleted",
+		},
+		CodeNotAuthor: {
+			i18n.Ru: "Изменять задание может только его автор",
+			i18n.En: "Only the author can change the assignment",
+		},
+		CodeNotAssigned: {
+			i18n.Ru: "Преподаватель не ведет дисциплину в указанных группах",
+			i18n.En: "The teacher does not teach the discipline in these groups",
+		},
+		CodeNotTargetGroup: {
+			i18n.Ru: "Задание не выдано группе студента",
+			i18n.En: "The assignment is not issued to the student's group",
+		},
+		CodeGroupMismatch: {
+			i18n.Ru: "Нет доступа к работам другой группы",
+			i18n.En: "No access to submissions of another group",
+		},
+		CodeEmptyGroup: {
+			i18n.Ru: "В группе нет студентов",
+			i18n.En: "The group has no students",
+		},
+		CodeMarkChanged: {
+			i18n.Ru: "Оценка работы изменилась",
+			i18n.En: "The mark has been changed by someone else",
+		},
+		CodeEmptyTable: {
+			i18n.Ru: "Таблица оценок пуста",
+			i18n.En: "The marks table is empty",
+		},
+		CodeMissingColumns: {
+			i18n.Ru: "В таблице должны быть столбцы {columns}",
+			i18n.En: "The table must have the columns {columns}",
+		},
+		CodeQueueFull: {
+			i18n.Ru: "Очередь сравнения работ переполнена, повторите позже",
+			i18n.En: "The similarity check queue is full, try again later",
+		},
+
+		CodeBadParams: {
+			i18n.Ru: "Неправильные параметры запроса",
+			i18n.En: "Invalid query parameters",
+		},
+		CodeFieldRequired: {
+			i18n.Ru: "Не заполнено поле {field}",
+			i18n.En: "The field {field} is required",
+		},
+		CodeFieldsRequired: {
+			i18n.Ru: "Не заполнены поля {fields}",
+			i18n.En: "The fields {fields} are required",
+		},
+		CodeInvalidParam: {
+			i18n.Ru: "Некорректное значение {field}",
+			i18n.En: "Invalid value of {field}",
+		},
+		CodeInvalidDate: {
+			i18n.Ru: "Неправильный формат даты в {field}, ожидается {format}",
+			i18n.En: "Invalid date in {field}, expected {format}",
+		},
+		CodeValueOutOfRange: {
+			i18n.Ru: "Значение {field} должно быть от {min} до {max}",
+			i18n.En: "The value of {field} must be between {min} and {max}",
+		},
+		CodeValueNotPositive: {
+			i18n.Ru: "Значение {field} должно быть больше нуля",
+			i18n.En: "The value of {field} must be greater than zero",
+		},
+		CodeValueNotAllowed: {
+			i18n.Ru: "Допустимые значения {field}: {allowed}",
+			i18n.En: "Allowed values of {field}: {allowed}",
+		},
+		CodeMediaType: {
+			i18n.Ru: "Данные передаются как {content_type}",
+			i18n.En: "The request body must be {content_type}",
+		},
+		CodeInvalidFilter: {
+			i18n.Ru: "Некорректный фильтр",
+			i18n.En: "Invalid filter",
+		},
+		CodeEmptyQuery: {
+			i18n.Ru: "Пустой поисковый запрос",
+			i18n.En: "The search query is empty",
+		},
+	})
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type Notification struct {
+	Id int
+
+	AccountId int
+	Message   string
+
+	CreatedAt time.Time
+
+	IsRead bool
+	ReadAt *time.Time
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"practice_vgpek/pkg/apperr"
+	"time"
+)
 
 type IssuedPractice struct {
 	Id int
@@ -10,11 +13,20 @@ type IssuedPractice struct {
 
 	TargetGroups []string
 
+	DisciplineId *int
+
+	AcademicTermId *int
+
+	Deadline   *time.Time
+	ClonedFrom *int
+
 	Title string
 	Theme string
 	Major string
 
 	Path string
+	// FileName имя загруженного файла, пустое для заданий, загруженных до его сохранения
+	FileName string
 
 	UploadAt time.Time
 
@@ -22,6 +34,17 @@ type IssuedPractice struct {
 	DeletedAt *time.Time
 }
 
+// PracticeSearchHit результат поиска по заданиям. Совпадения в TitleHeadline и Snippet
+// выделены тегами <b></b>, остальной текст экранирован для HTML
+type PracticeSearchHit struct {
+	Practice IssuedPractice
+
+	Rank float64
+
+	TitleHeadline string
+	Snippet       string
+}
+
 type SolvedPractice struct {
 	Id               int
 	IssuedPracticeId int
@@ -33,13 +56,131 @@ type SolvedPractice struct {
 	AuthorName string
 	AuthorId   int
 
+	// GroupName - группа автора в момент сдачи работы
+	GroupName string
+
+	// DuplicateOf - работа другого студента с побайтно совпадающим файлом
+	DuplicateOf *int
+
 	Mark     int
 	MarkTime *time.Time
 
 	SolvedTime time.Time
 
 	Path string
+	// FileName имя загруженного файла, пустое для работ, сданных до его сохранения
+	FileName string
 
 	IsDeleted bool
 	DeletedAt *time.Time
 }
+
+// Submission работа студента в архиве выгрузки по заданию
+type Submission struct {
+	SolvedPracticeId int
+
+	LastName  string
+	FirstName string
+	GroupName string
+
+	// Version - порядковый номер сдачи работы студентом, начиная с 1
+	Version int
+
+	SolvedTime time.Time
+	IsLate     bool
+
+	Mark int
+
+	Path string
+}
+
+// SubmissionArchive набор работ по заданию, из которого собирается архив
+type SubmissionArchive struct {
+	Practice    IssuedPractice
+	Submissions []Submission
+}
+
+// Шкала оценок
+const (
+	MinMark = 2
+	MaxMark = 5
+)
+
+// ErrMarkChanged оценка работы изменилась после проверки изменения
+var ErrMarkChanged = apperr.Conflict(CodeMarkChanged)
+
+// Источники изменения оценки
+const (
+	MarkSourceManual = "manual"
+	MarkSourceImport = "import"
+)
+
+// Статусы строки импорта оценок
+const (
+	MarkImportChange    = "change"
+	MarkImportUnchanged = "unchanged"
+	MarkImportError     = "error"
+)
+
+type MarkHistory struct {
+	Id               int
+	SolvedPracticeId int
+
+	OldMark int
+	NewMark int
+
+	// ChangedBy - ФИО того, кто изменил оценку
+	ChangedBy   string
+	ChangedById int
+	ChangedAt   time.Time
+
+	Source string
+}
+
+// MarkImportRow результат проверки одной строки таблицы оценок
+type MarkImportRow struct {
+	// Row - номер строки в таблице, начиная с 1 с учетом заголовка
+	Row int
+
+	IssuedPracticeId int
+	SolvedPracticeId int
+
+	// Student - студент так, как он указан в таблице
+	Student   string
+	GroupName string
+
+	OldMark int
+	NewMark int
+
+	Status string
+	Error  string
+}
+
+// MarkImport результат импорта оценок. Applied - изменения сохранены в базе данных
+type MarkImport struct {
+	Rows []MarkImportRow
+
+	Changes int
+	Errors  int
+
+	Applied bool
+}
+
+// SimilarityPair пара похожих работ по заданию
+type SimilarityPair struct {
+	FirstSolvedId  int
+	FirstStudent   string
+	FirstGroupName string
+
+	SecondSolvedId  int
+	SecondStudent   string
+	SecondGroupName string
+
+	Score       float64
+	IsIdentical bool
+
+	// Fragments - совпадающие фрагменты текста первой работы
+	Fragments []string
+
+	ComputedAt time.Time
+}
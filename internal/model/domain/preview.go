@@ -0,0 +1,18 @@
+package domain
+
+import "practice_vgpek/pkg/apperr"
+
+var (
+	// ErrPreviewUnsupported предпросмотр не настроен или недоступен для формата файла
+	ErrPreviewUnsupported = apperr.Validation(CodePreviewUnsupported)
+	// ErrPreviewFailed не удалось преобразовать файл для предпросмотра
+	ErrPreviewFailed = apperr.Internal(CodePreviewFailed)
+)
+
+// Виды предпросмотра файла
+const (
+	// PreviewPDF документ целиком в PDF
+	PreviewPDF = "pdf"
+	// PreviewThumbnail уменьшенное изображение первой страницы в PNG
+	PreviewThumbnail = "thumbnail"
+)
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"practice_vgpek/pkg/apperr"
+	"time"
+)
+
+// ErrQuotaExceeded файл не помещается в квоту аккаунта или его группы
+var ErrQuotaExceeded = apperr.TooLarge(CodeQuotaExceeded)
+
+const (
+	UsageByAccount = "account"
+	UsageByGroup   = "group"
+)
+
+type StorageQuota struct {
+	Id int
+
+	RoleId    *int
+	GroupName *string
+
+	LimitBytes int64
+
+	UpdatedAt time.Time
+}
+
+type StorageUsage struct {
+	AccountId *int
+	Login     *string
+	GroupName *string
+
+	UsedBytes int64
+	FileCount int
+}
+
+// AccountStorage занятое аккаунтом место и действующие ограничения. Limit nil - без ограничения
+type AccountStorage struct {
+	UsedBytes  int64
+	LimitBytes *int64
+
+	GroupName       string
+	GroupUsedBytes  int64
+	GroupLimitBytes *int64
+}
@@ -16,9 +16,16 @@ const (
 )
 
 const (
-	AccountObject = "ACCOUNT"
-	KeyObject     = "KEY"
-	RBACObject    = "RBAC"
+	AccountObject        = "ACCOUNT"
+	KeyObject            = "KEY"
+	RBACObject           = "RBAC"
+	MarkObject           = "MARK"
+	IssuedPracticeObject = "ISSUED_PRACTICE"
+	SolvedPracticeObject = "SOLVED_PRACTICE"
+	TermObject           = "TERM"
+	DisciplineObject     = "DISCIPLINE"
+	StorageObject        = "STORAGE"
+	GroupObject          = "GROUP"
 )
 
 type Permissions struct {
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+type AcademicTerm struct {
+	Id int
+
+	Name string
+
+	StartDate time.Time
+	EndDate   time.Time
+
+	IsCurrent bool
+
+	CreatedAt time.Time
+
+	IsClosed bool
+	ClosedAt *time.Time
+}
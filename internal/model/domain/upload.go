@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"practice_vgpek/pkg/apperr"
+	"time"
+)
+
+var (
+	// ErrUploadNotFound загрузка не существует, истекла или принадлежит другому аккаунту
+	ErrUploadNotFound = apperr.NotFound(CodeUploadNotFound)
+	// ErrUploadOffset смещение части не совпадает с уже принятым размером загрузки
+	ErrUploadOffset = apperr.Conflict(CodeUploadOffset)
+	// ErrUploadBusy в загрузку уже пишется другая часть
+	ErrUploadBusy = apperr.Conflict(CodeUploadBusy)
+	// ErrUploadIncomplete загрузка еще не получила все данные
+	ErrUploadIncomplete = apperr.Conflict(CodeUploadIncomplete)
+)
+
+// UploadSession возобновляемая загрузка файла по частям
+type UploadSession struct {
+	Id string
+
+	FileName string
+
+	Length int64
+	Offset int64
+
+	ExpiresAt time.Time
+}
+
+// Complete сообщает, что все данные загрузки получены
+func (u UploadSession) Complete() bool {
+	return u.Offset == u.Length
+}
@@ -0,0 +1,14 @@
+package dto
+
+type NewDisciplineReq struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NewAssignmentReq описывает назначение преподавателя на дисциплину в группе
+type NewAssignmentReq struct {
+	AccountId    int    `json:"account_id"`
+	DisciplineId int    `json:"discipline_id"`
+	GroupName    string `json:"group_name"`
+	Term         string `json:"term"`
+}
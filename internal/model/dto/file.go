@@ -0,0 +1,27 @@
+package dto
+
+type NewFileScan struct {
+	Path string
+
+	IsInfected bool
+	Signature  string
+
+	Scanner string
+}
+
+type NewAuditLog struct {
+	// AccountId 0 для системных событий
+	AccountId int
+
+	Event   string
+	Object  string
+	Details string
+}
+
+type NewFileDigest struct {
+	Path string
+
+	// Hash sha256 содержимого в hex
+	Hash string
+	Size int64
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// TransferReq описывает перевод одного или нескольких аккаунтов в другую группу
+type TransferReq struct {
+	AccountIds []int  `json:"account_ids"`
+	GroupName  string `json:"group_name"`
+
+	// EffectiveAt дата, с которой перевод вступает в силу, если не указана - текущее время
+	EffectiveAt *time.Time `json:"effective_at"`
+}
+
+// PromoteCohortReq описывает перевод всей группы на следующий курс или ее выпуск
+type PromoteCohortReq struct {
+	GroupName string `json:"group_name"`
+
+	// NewGroupName новое название группы, игнорируется при выпуске
+	NewGroupName string `json:"new_group_name"`
+
+	// Graduate если true - студенты группы выпускаются, а их аккаунты деактивируются
+	Graduate bool `json:"graduate"`
+
+	EffectiveAt *time.Time `json:"effective_at"`
+}
+
+// NewMembership вспомогательная структура, передающаяся на DAO слой для создания записи в БД
+type NewMembership struct {
+	AccountId int
+	GroupName string
+
+	EffectiveFrom time.Time
+
+	Reason string
+}
+
+// GroupMembersReq описывает запрос на получение состава группы на определенный момент
+type GroupMembersReq struct {
+	GroupName string
+	At        time.Time
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type NewDownloadLink struct {
+	Id        string
+	AccountId int
+
+	Object     string
+	ResourceId int
+
+	SingleUse bool
+	ExpiresAt time.Time
+}
+
+// NewDownloadLinkReq выпуск ссылки на файл объекта Object с id ResourceId для текущего аккаунта
+type NewDownloadLinkReq struct {
+	Object     string
+	ResourceId int
+
+	// SingleUse ссылка перестает действовать после первого скачивания
+	SingleUse bool
+}
+
+// LinkId идентификатор подписанной ссылки
+type LinkId struct {
+	Id string
+}
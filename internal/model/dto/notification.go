@@ -0,0 +1,6 @@
+package dto
+
+type NewNotification struct {
+	AccountId int
+	Message   string
+}
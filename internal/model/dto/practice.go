@@ -1,38 +1,99 @@
 package dto
 
 import (
+	"io"
 	"mime/multipart"
 	"time"
 )
 
 type NewIssuedPracticeReq struct {
 	TargetGroups []string `json:"target_groups"`
+	DisciplineId int      `json:"discipline_id"`
+
+	Deadline *time.Time `json:"deadline"`
 
 	Title string `json:"title"`
 	Theme string `json:"theme"`
 	Major string `json:"major"`
 
-	File *multipart.File `json:"file"`
+	// File поток файла задания, читается при сохранении
+	File io.Reader `json:"-"`
+	// Ext расширение загруженного документа: .docx, .pdf или .odt
+	Ext string `json:"-"`
+	// FileName имя загруженного файла, отдается при скачивании
+	FileName string `json:"-"`
+	// Resumable файл передан возобновляемой загрузкой, для него действует ее допустимый размер
+	Resumable bool `json:"-"`
 }
 
 type NewIssuedPractice struct {
 	AccountId int
 
 	TargetGroups []string
+	DisciplineId int
+
+	AcademicTermId int
 
 	Title string
 	Theme string
 	Major string
 
 	Path     string
+	FileName string
 	UploadAt time.Time
+
+	Deadline   *time.Time
+	ClonedFrom *int
+}
+
+// ClonePracticeReq клонирование задания в новые группы и семестр, по умолчанию - текущий
+type ClonePracticeReq struct {
+	PracticeId int `json:"practice_id"`
+
+	TargetGroups []string `json:"target_groups"`
+	TermId       int      `json:"term_id"`
+
+	Deadline *time.Time `json:"deadline"`
+}
+
+// CloneDisciplineReq клонирование всех заданий дисциплины из одного семестра в другой
+type CloneDisciplineReq struct {
+	DisciplineId int `json:"discipline_id"`
+	FromTermId   int `json:"from_term_id"`
+
+	TargetGroups []string `json:"target_groups"`
+	TermId       int      `json:"term_id"`
+
+	Deadline *time.Time `json:"deadline"`
+}
+
+// UpdateIssuedPracticeReq изменение задания, не переданные поля остаются без изменений
+type UpdateIssuedPracticeReq struct {
+	Id int
+
+	TargetGroups []string
+	Deadline     *time.Time
+
+	Title *string
+	Theme *string
+	Major *string
+
+	File      io.Reader
+	Ext       string
+	FileName  string
+	Resumable bool
 }
 
 type NewSolvedPracticeReq struct {
 	PerformedAccountId int `json:"performed_account_id"`
 	IssuedPracticeId   int `json:"issued_practice_id"`
 
-	File *multipart.File `json:"file"`
+	// File поток файла работы, читается при сохранении
+	File io.Reader `json:"-"`
+	// FileName имя загруженного файла, отдается при скачивании
+	FileName string `json:"-"`
+	// Resumable файл передан возобновляемой загрузкой, для него действует ее допустимый размер
+	Resumable bool `json:"-"`
 }
 
 type NewSolvedPractice struct {
@@ -44,9 +105,30 @@ type NewSolvedPractice struct {
 
 	SolvedTime *time.Time
 
-	Path string
+	Path     string
+	FileName string
 
 	IsDeleted *time.Time
+
+	GroupName string
+
+	// ContentHash sha256 содержимого файла в hex
+	ContentHash string
+	DuplicateOf *int
+}
+
+// NewSimilarity результат сравнения двух работ по одному заданию
+type NewSimilarity struct {
+	IssuedPracticeId int
+
+	FirstSolvedId  int
+	SecondSolvedId int
+
+	Score       float64
+	IsIdentical bool
+	Fragments   []string
+
+	ComputedAt time.Time
 }
 
 type MarkPracticeReq struct {
@@ -54,6 +136,31 @@ type MarkPracticeReq struct {
 	Mark             int `json:"mark"`
 }
 
+// MarkChange изменение оценки работы, сохраняемое вместе с записью в истории оценок
+type MarkChange struct {
+	SolvedPracticeId int
+
+	// OldMark оценка, которая была у работы при проверке изменения
+	OldMark int
+	NewMark int
+
+	ChangedBy int
+	ChangedAt time.Time
+
+	Source string
+}
+
+// MarkImportReq таблица оценок. Без Confirm импорт только проверяется и возвращает список изменений
+type MarkImportReq struct {
+	File *multipart.File
+	Size int64
+
+	// Ext расширение файла: .csv или .xlsx
+	Ext string
+
+	Confirm bool
+}
+
 type MarkPractice struct {
 	// SolvedPracticeId id практической работы, которую необходимо оценить
 	SolvedPracticeId int
@@ -61,3 +168,11 @@ type MarkPractice struct {
 	Mark     int
 	MarkTime time.Time
 }
+
+// SimilarityReportReq запрос отчета о схожести работ по заданию
+type SimilarityReportReq struct {
+	IssuedPracticeId int
+
+	// MinScore минимальная схожесть пары от 0 до 1
+	MinScore float64
+}
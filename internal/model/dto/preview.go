@@ -0,0 +1,7 @@
+package dto
+
+// PreviewReq предпросмотр файла записи с id Id, Kind - domain.PreviewPDF или domain.PreviewThumbnail
+type PreviewReq struct {
+	Id   int
+	Kind string
+}
@@ -0,0 +1,25 @@
+package dto
+
+type NewStoredFile struct {
+	Path string
+
+	AccountId int
+	// GroupName пусто, если аккаунт не состоит в группе
+	GroupName string
+
+	Size int64
+}
+
+// SetQuotaReq задание квоты: указывается либо роль, либо группа
+type SetQuotaReq struct {
+	RoleId    int    `json:"role_id"`
+	GroupName string `json:"group_name"`
+
+	LimitBytes int64 `json:"limit_bytes"`
+}
+
+type TopConsumersReq struct {
+	// By "account" или "group"
+	By    string
+	Limit int
+}
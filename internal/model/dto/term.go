@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type NewTermReq struct {
+	Name string `json:"name"`
+
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
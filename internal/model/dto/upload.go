@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+type NewUploadSession struct {
+	Id        string
+	AccountId int
+
+	FileName string
+	Length   int64
+
+	ExpiresAt time.Time
+}
+
+// NewUploadReq создание возобновляемой загрузки файла заранее известного размера
+type NewUploadReq struct {
+	FileName string
+	Length   int64
+}
+
+// UploadChunkReq часть файла, дописываемая в загрузку с переданного смещения
+type UploadChunkReq struct {
+	Id     string
+	Offset int64
+
+	Body io.Reader
+}
+
+// UploadId идентификатор возобновляемой загрузки
+type UploadId struct {
+	Id string
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type Discipline struct {
+	Id int `db:"discipline_id"`
+
+	Name        string `db:"discipline_name"`
+	Description string `db:"description"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	IsDeleted *time.Time `db:"is_deleted"`
+}
+
+// TeachingAssignment назначение преподавателя на дисциплину в группе на определенный семестр
+type TeachingAssignment struct {
+	Id int `db:"teaching_assignment_id"`
+
+	AccountId    int    `db:"account_id"`
+	DisciplineId int    `db:"discipline_id"`
+	GroupName    string `db:"group_name"`
+	Term         string `db:"term"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	IsDeleted *time.Time `db:"is_deleted"`
+}
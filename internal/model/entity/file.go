@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// FileScan вердикт антивируса по сохраненному файлу
+type FileScan struct {
+	Path string `db:"file_path"`
+
+	IsInfected bool    `db:"is_infected"`
+	Signature  *string `db:"signature"`
+
+	Scanner   string    `db:"scanner"`
+	ScannedAt time.Time `db:"scanned_at"`
+}
+
+// AuditLog запись журнала событий безопасности
+type AuditLog struct {
+	Id int `db:"audit_log_id"`
+
+	// AccountId аккаунт, действие которого вызвало событие, nil для системных событий
+	AccountId *int `db:"account_id"`
+
+	Event   string `db:"event"`
+	Object  string `db:"object"`
+	Details string `db:"details"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// FileDigest хэш содержимого сохраненного файла
+type FileDigest struct {
+	Path string `db:"file_path"`
+
+	Hash string `db:"content_hash"`
+	Size int64  `db:"size_bytes"`
+
+	ComputedAt time.Time `db:"computed_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// AccountGroup запись о членстве аккаунта в группе за период [EffectiveFrom, EffectiveTo)
+type AccountGroup struct {
+	Id int `db:"account_group_id"`
+
+	AccountId int    `db:"account_id"`
+	GroupName string `db:"group_name"`
+
+	EffectiveFrom time.Time  `db:"effective_from"`
+	EffectiveTo   *time.Time `db:"effective_to"`
+
+	Reason string `db:"reason"`
+}
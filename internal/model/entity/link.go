@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// DownloadLink подписанная ссылка на скачивание файла
+type DownloadLink struct {
+	Id        string `db:"link_id"`
+	AccountId int    `db:"account_id"`
+
+	Object     string `db:"object"`
+	ResourceId int    `db:"resource_id"`
+
+	SingleUse bool `db:"single_use"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+
+	RevokedAt *time.Time `db:"revoked_at"`
+	RevokedBy *int       `db:"revoked_by"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Notification struct {
+	Id int `db:"notification_id"`
+
+	AccountId int    `db:"account_id"`
+	Message   string `db:"message"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	ReadAt    *time.Time `db:"read_at"`
+}
@@ -14,9 +14,58 @@ type IssuedPractice struct {
 	Major string `db:"major"`
 
 	Path string `db:"practice_path"`
+	// FileName имя файла, с которым задание было загружено
+	FileName *string `db:"file_name"`
 
 	UploadAt  time.Time  `db:"upload_at"`
 	DeletedAt *time.Time `db:"deleted_at"`
+
+	DisciplineId *int `db:"discipline_id"`
+
+	// AcademicTermId семестр, в котором было выдано задание
+	AcademicTermId *int `db:"academic_term_id"`
+
+	Deadline *time.Time `db:"deadline"`
+
+	// ClonedFrom исходное задание, если задание было склонировано
+	ClonedFrom *int `db:"cloned_from"`
+}
+
+// IssuedPracticeUpdate структура для обновления задания. Если поле nil - поле в запрос не попадает
+type IssuedPracticeUpdate struct {
+	Id int
+
+	TargetGroups []string
+	Deadline     *time.Time
+
+	Title *string
+	Theme *string
+	Major *string
+
+	Path     *string
+	FileName *string
+}
+
+// IssuedPracticeFileVersion предыдущая версия файла задания, замененная преподавателем
+type IssuedPracticeFileVersion struct {
+	Id int `db:"file_version_id"`
+
+	IssuedPracticeId int    `db:"issued_practice_id"`
+	Path             string `db:"practice_path"`
+
+	ReplacedBy int       `db:"replaced_by"`
+	ReplacedAt time.Time `db:"replaced_at"`
+}
+
+// IssuedPracticeSearchHit задание, найденное полнотекстовым поиском
+type IssuedPracticeSearchHit struct {
+	IssuedPractice
+
+	Rank float64 `db:"rank"`
+
+	// TitleHeadline и Snippet - название и фрагмент текста файла с выделенными совпадениями
+	TitleHeadline string `db:"title_headline"`
+	Snippet       string `db:"snippet"`
 }
 
 type SolvedPractice struct {
@@ -32,6 +81,16 @@ type SolvedPractice struct {
 
 	Path      string `db:"path"`
 	IsDeleted *time.Time
+
+	// FileName имя файла, с которым работа была загружена
+	FileName *string `db:"file_name"`
+
+	// GroupName группа студента в момент сдачи работы
+	GroupName string `db:"group_name"`
+
+	ContentHash *string `db:"content_hash"`
+	// DuplicateOf работа другого студента с тем же содержимым файла
+	DuplicateOf *int `db:"duplicate_of"`
 }
 
 // SolvedPracticeUpdate структура для обновления записи. Если поле nil - поле в запрос не попадает
@@ -49,3 +108,34 @@ type SolvedPracticeUpdate struct {
 	Path      *string
 	IsDeleted *time.Time
 }
+
+// MarkHistory запись об изменении оценки работы
+type MarkHistory struct {
+	Id int `db:"mark_history_id"`
+
+	SolvedPracticeId int `db:"solved_practice_id"`
+
+	OldMark int `db:"old_mark"`
+	NewMark int `db:"new_mark"`
+
+	ChangedBy int       `db:"changed_by"`
+	ChangedAt time.Time `db:"changed_at"`
+
+	Source string `db:"source"`
+}
+
+// Similarity схожесть двух работ по одному заданию
+type Similarity struct {
+	Id int `db:"similarity_id"`
+
+	IssuedPracticeId int `db:"issued_practice_id"`
+
+	FirstSolvedId  int `db:"first_solved_id"`
+	SecondSolvedId int `db:"second_solved_id"`
+
+	Score       float64  `db:"score"`
+	IsIdentical bool     `db:"is_identical"`
+	Fragments   []string `db:"fragments"`
+
+	ComputedAt time.Time `db:"computed_at"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// StorageQuota ограничение места для роли или группы, задано ровно одно из RoleId и GroupName
+type StorageQuota struct {
+	Id int `db:"storage_quota_id"`
+
+	RoleId    *int    `db:"internal_role_id"`
+	GroupName *string `db:"group_name"`
+
+	LimitBytes int64 `db:"limit_bytes"`
+
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// StorageUsage занятое место аккаунтом или группой
+type StorageUsage struct {
+	// AccountId и Login пусты при подсчете по группам
+	AccountId *int    `db:"account_id"`
+	Login     *string `db:"login"`
+	GroupName *string `db:"group_name"`
+
+	UsedBytes int64 `db:"used_bytes"`
+	FileCount int   `db:"file_count"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// AcademicTerm учебный семестр, закрытый семестр доступен только для чтения
+type AcademicTerm struct {
+	Id int `db:"academic_term_id"`
+
+	Name string `db:"term_name"`
+
+	StartDate time.Time `db:"start_date"`
+	EndDate   time.Time `db:"end_date"`
+
+	IsCurrent bool `db:"is_current"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	ClosedAt  *time.Time `db:"closed_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// UploadSession возобновляемая загрузка файла по частям
+type UploadSession struct {
+	Id        string `db:"upload_id"`
+	AccountId int    `db:"account_id"`
+
+	FileName string `db:"file_name"`
+
+	Length int64 `db:"upload_length"`
+	Offset int64 `db:"upload_offset"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
@@ -12,9 +12,29 @@ const (
 	ValidateError   = "ошибка валидации"
 )
 
+// Логирование middleware
+const (
+	RequestId            = "id запроса"
+	AccountId            = "id аккаунта"
+	HTTPRequestOperation = "обработка HTTP запроса"
+	// TraceId латиницей, как его ищут системы сопоставления журналов с трассами
+	TraceId = "trace_id"
+)
+
 // Логирование методов DAO заданных практических
 const (
 	SaveIssuedPracticeDAO = "сохранение заданного практического задания в базе данных"
+
+	UpdateIssuedPracticeDAO              = "обновление практического задания в базе данных"
+	SoftDeleteIssuedPracticeDAO          = "мягкое удаление практического задания в базе данных"
+	RestoreIssuedPracticeDAO             = "восстановление практического задания в базе данных"
+	SaveIssuedPracticeFileVersionDAO     = "сохранение предыдущей версии файла задания в базе данных"
+	SelectIssuedPracticeFileVersionsDAO  = "получение версий файла задания из базы данных"
+	SelectIssuedPracticesByDisciplineDAO = "получение заданий дисциплины в семестре из базы данных"
+	GetSolvedPracticesByIssuedIdDAO      = "получение работ по id практического задания из базы данных"
+	SaveIssuedPracticeTextDAO            = "сохранение текста файла задания в базе данных"
+	CopyIssuedPracticeTextDAO            = "копирование текста файла задания в базе данных"
+	SearchIssuedPracticesDAO             = "полнотекстовый поиск заданий в базе данных"
 )
 
 // Логирование методов DAO решенных практических
@@ -22,12 +42,67 @@ const (
 	SaveSolvedPracticeDAO        = "сохранение решенного практического задания в базе данных"
 	GetSolvedPracticeInfoByIdDAO = "получение решенного практического задания по id в базе данных"
 	UpdateSolvedPracticeDAO      = "обновление решенного практического задания в базе данных"
+	SetMarksDAO                  = "выставление оценок работам в базе данных"
+	SelectMarkHistoryDAO         = "получение истории оценок работы из базы данных"
+	GetSolvedPracticesByHashDAO  = "получение работ с тем же содержимым из базы данных"
+)
+
+// Логирование методов DAO схожести работ
+const (
+	ReplaceSimilarityDAO = "замена результатов сравнения работ в базе данных"
+	SelectSimilarityDAO  = "получение результатов сравнения работ из базы данных"
+)
+
+// Логирование методов DAO проверки файлов и журнала аудита
+const (
+	SaveFileScanDAO     = "сохранение вердикта антивируса в базе данных"
+	SelectFileScanDAO   = "получение вердикта антивируса из базы данных"
+	SaveAuditLogDAO     = "сохранение события в журнал аудита"
+	SaveFileDigestDAO   = "сохранение хэша файла в базе данных"
+	SelectFileDigestDAO = "получение хэша файла из базы данных"
+)
+
+// Логирование методов DAO квот
+const (
+	SaveStoredFileDAO     = "учет сохраненного файла в базе данных"
+	ReleaseStoredFilesDAO = "освобождение места файлов в базе данных"
+	RestoreStoredFilesDAO = "возврат в учет места файлов в базе данных"
+	SelectUsageDAO        = "получение занятого места из базы данных"
+	SelectTopUsageDAO     = "получение крупнейших потребителей места из базы данных"
+	SaveQuotaDAO          = "сохранение квоты в базе данных"
+	SelectQuotaDAO        = "получение квоты из базы данных"
+	DeleteQuotaDAO        = "удаление квоты из базы данных"
+)
+
+// Логирование методов DAO возобновляемых загрузок
+const (
+	SaveUploadSessionDAO    = "сохранение загрузки в базе данных"
+	SelectUploadSessionDAO  = "получение загрузки из базы данных"
+	UpdateUploadOffsetDAO   = "изменение смещения загрузки в базе данных"
+	DeleteUploadSessionDAO  = "удаление загрузки из базы данных"
+	DeleteExpiredUploadsDAO = "удаление истекших загрузок из базы данных"
+)
+
+// Логирование методов DAO подписанных ссылок
+const (
+	SaveDownloadLinkDAO          = "сохранение ссылки в базе данных"
+	SelectDownloadLinkDAO        = "получение ссылки из базы данных"
+	UseDownloadLinkDAO           = "отметка использования ссылки в базе данных"
+	RevokeDownloadLinkDAO        = "отзыв ссылки в базе данных"
+	DeleteExpiredDownloadLinkDAO = "удаление истекших ссылок из базы данных"
+)
+
+// Логирование методов DAO состояния сервиса
+const (
+	PingDBDAO                 = "проверка соединения с базой данных"
+	SelectMigrationVersionDAO = "получение версии миграций из базы данных"
 )
 
 // Логирование методов DAO доступов
 const (
 	SavePermissionsDAO    = "сохранение доступа в базе данных"
 	SelectPermByRoleIdDAO = "получение доступов по id роли из базы данных"
+	DeletePermissionsDAO  = "удаление доступов роли к объекту из базы данных"
 )
 
 // Логирование методов DAO пользователя
@@ -46,6 +121,49 @@ const (
 	SelectAccountByLoginDAO   = "получение аккаунта по логину из базы данных"
 	SoftDeleteAccountByIdDAO  = "мягкое удаление аккаунта по id"
 	HardDeleteAccountByIdDAO  = "жесткое удаление аккаунта по id"
+	DeactivateAccountByIdDAO  = "деактивация аккаунта по id"
+)
+
+// Логирование методов DAO дисциплин
+const (
+	SaveDisciplineDAO         = "сохранение дисциплины в базу данных"
+	SelectDisciplineById      = "получение дисциплины из базы данных по id"
+	SelectDisciplinesByParams = "получение дисциплин из базы данных по параметрам"
+	SoftDeleteDisciplineById  = "мягкое удаление дисциплины по id"
+)
+
+// Логирование методов DAO назначений преподавателей
+const (
+	SaveAssignmentDAO            = "сохранение назначения преподавателя в базу данных"
+	SelectAssignmentById         = "получение назначения преподавателя из базы данных по id"
+	SelectAssignmentsByAccountId = "получение назначений преподавателя из базы данных по id аккаунта"
+	SoftDeleteAssignmentById     = "мягкое удаление назначения преподавателя по id"
+)
+
+// Логирование методов DAO семестров
+const (
+	SaveTermDAO         = "сохранение семестра в базу данных"
+	SelectTermById      = "получение семестра из базы данных по id"
+	SelectTermByName    = "получение семестра из базы данных по названию"
+	SelectCurrentTerm   = "получение текущего семестра из базы данных"
+	SelectTermsByParams = "получение семестров из базы данных по параметрам"
+	SetCurrentTermDAO   = "смена текущего семестра в базе данных"
+	CloseTermDAO        = "закрытие семестра в базе данных"
+)
+
+// Логирование методов DAO уведомлений
+const (
+	SaveNotificationDAO               = "сохранение уведомления в базу данных"
+	SelectNotificationsByAccountIdDAO = "получение уведомлений аккаунта из базы данных"
+	MarkNotificationReadDAO           = "отметка о прочтении уведомления в базе данных"
+)
+
+// Логирование методов DAO групп
+const (
+	SaveMembershipDAO              = "сохранение членства в группе в базу данных"
+	SelectMembershipByAccountIdDAO = "получение группы аккаунта из базы данных"
+	SelectMembersByGroupDAO        = "получение членов группы из базы данных"
+	CloseMembershipDAO             = "завершение членства в группе"
 )
 
 // Логирование методов DAO действий
@@ -93,6 +211,7 @@ const (
 	Insert               = "вставка"
 	Select               = "получение"
 	Update               = "обновление"
+	Delete               = "удаление"
 	ExecuteError         = "ошибка выполнения запроса"
 	CollectError         = "ошибка приведения к структуре"
 	SuccessfullyRecorded = "успешно сохранено в базу данных"
@@ -121,6 +240,7 @@ const (
 // Операции с доступами
 const (
 	AddPermissionOperation      = "добавление права действия в системе"
+	ReplacePermissionOperation  = "замена прав действия в системе"
 	GetPermissionOperation      = "получение доступов у роли"
 	DeletePermissionOperation   = "удаление права действия в системе"
 	GetPermByAccountIdOperation = "получение доступов по id аккаунта"
@@ -165,10 +285,94 @@ const (
 	GetIssuedPracticeInfoById     = "получение по id информации по практическому заданию"
 	GetIssuedPracticeInfoByParams = "получение по параметрам информации по практическими заданиям"
 	DownloadIssuedPractice        = "получение ссылки для загрузки практического задания"
+	PreviewIssuedPractice         = "получение предпросмотра практического задания"
+	UpdateIssuedPracticeOperation = "изменение практического задания"
+	DeleteIssuedPracticeOperation = "удаление практического задания"
+	RestoreIssuedPractice         = "восстановление практического задания"
+	CloneIssuedPractice           = "клонирование практического задания"
+	DownloadSubmissionsArchive    = "выгрузка архива выполненных работ по заданию"
+	CloneDisciplinePractices      = "клонирование заданий дисциплины"
+	SearchIssuedPractice          = "поиск практических заданий"
 )
 
 const (
 	UploadSolvedPracticeOperation = "добавление выполненной практической работы"
 	GetSolvedPracticeInfoById     = "получение по id информации по выполненной практической работе"
+	DownloadSolvedPractice        = "получение файла выполненной практической работы"
+	PreviewSolvedPractice         = "получение предпросмотра выполненной практической работы"
 	SetMarkSolvedPractice         = "выставление оценки выполненному практическому заданию"
+	ImportMarksOperation          = "импорт оценок из таблицы"
+	GetMarkHistoryOperation       = "получение истории оценок работы"
+)
+
+// Операции проверки на заимствования
+const (
+	AnalyzeSimilarityOperation = "сравнение работ по заданию"
+	GetSimilarityReport        = "получение отчета о схожести работ"
+)
+
+// Операции с группами
+const (
+	TransferAccountsOperation = "перевод аккаунтов в другую группу"
+	PromoteCohortOperation    = "перевод группы на следующий курс"
+	GetGroupMembersOperation  = "получение членов группы"
+)
+
+// Операции с дисциплинами
+const (
+	AddDisciplineOperation    = "добавление дисциплины"
+	GetDisciplinesOperation   = "получение дисциплин"
+	DeleteDisciplineOperation = "удаление дисциплины"
+	AddAssignmentOperation    = "назначение преподавателя на дисциплину"
+	GetAssignmentsOperation   = "получение назначений преподавателя"
+	DeleteAssignmentOperation = "снятие назначения преподавателя"
+)
+
+// Операции с семестрами
+const (
+	AddTermOperation        = "добавление семестра"
+	GetTermsOperation       = "получение семестров"
+	GetCurrentTermOperation = "получение текущего семестра"
+	SetCurrentTermOperation = "смена текущего семестра"
+	CloseTermOperation      = "закрытие семестра"
+)
+
+// Операции с квотами
+const (
+	SetQuotaOperation        = "установка квоты"
+	GetQuotasOperation       = "получение квот"
+	DeleteQuotaOperation     = "удаление квоты"
+	GetUsageOperation        = "получение занятого места"
+	GetTopConsumersOperation = "получение крупнейших потребителей места"
+	CheckQuotaOperation      = "проверка квоты"
+)
+
+// Операции с возобновляемыми загрузками
+const (
+	CreateUploadOperation = "создание загрузки"
+	GetUploadOperation    = "получение состояния загрузки"
+	AppendUploadOperation = "прием части загрузки"
+	DeleteUploadOperation = "удаление загрузки"
+	CleanUploadsOperation = "удаление истекших загрузок"
+)
+
+// Операции с подписанными ссылками
+const (
+	CreateDownloadLinkOperation = "выпуск ссылки на скачивание"
+	RedeemDownloadLinkOperation = "скачивание по ссылке"
+	RevokeDownloadLinkOperation = "отзыв ссылки на скачивание"
+	CleanDownloadLinksOperation = "удаление истекших ссылок"
+)
+
+// Операции с уведомлениями
+const (
+	GetNotificationsOperation = "получение уведомлений"
+	ReadNotificationOperation = "прочтение уведомления"
+	NotifyStudentsOperation   = "уведомление студентов"
+)
+
+// Операции проверки состояния сервиса
+const (
+	ReadinessOperation = "проверка готовности"
+	VersionOperation   = "получение версии"
 )
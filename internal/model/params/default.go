@@ -19,5 +19,23 @@ type State struct {
 
 type IssuedPractice struct {
 	IsSolved string `json:"is_solved"`
+
+	// TermId семестр, по умолчанию - текущий
+	TermId int `json:"term_id"`
+
+	// GroupName и AccountId заполняются для студента: задания его группы и решенные им
+	GroupName string `json:"-"`
+	AccountId int    `json:"-"`
+
+	Default
+}
+
+type PracticeSearch struct {
+	// Query поисковый запрос в формате websearch: слова, "фразы" и -исключения
+	Query string `json:"q"`
+
+	// GroupName заполняется для студента: поиск только по заданиям его группы
+	GroupName string `json:"-"`
+
 	Default
 }
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type Discipline struct {
+	Id int `json:"id"`
+
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	IsDeleted bool       `json:"is_deleted"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+func (d Discipline) DomainToResponse(discipline domain.Discipline) Discipline {
+	return Discipline{
+		Id:          discipline.Id,
+		Name:        discipline.Name,
+		Description: discipline.Description,
+		CreatedAt:   discipline.CreatedAt,
+		IsDeleted:   discipline.IsDeleted,
+		DeletedAt:   discipline.DeletedAt,
+	}
+}
+
+type Disciplines struct {
+	Disciplines []Discipline `json:"disciplines"`
+}
+
+func (d Disciplines) DomainToResponse(disciplines []domain.Discipline) Disciplines {
+	d.Disciplines = make([]Discipline, 0, len(disciplines))
+
+	for _, discipline := range disciplines {
+		d.Disciplines = append(d.Disciplines, Discipline{}.DomainToResponse(discipline))
+	}
+
+	return d
+}
+
+type TeachingAssignment struct {
+	Id int `json:"id"`
+
+	AccountId   int    `json:"account_id"`
+	TeacherName string `json:"teacher_name"`
+
+	DisciplineId   int    `json:"discipline_id"`
+	DisciplineName string `json:"discipline_name"`
+
+	GroupName string `json:"group_name"`
+	Term      string `json:"term"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (a TeachingAssignment) DomainToResponse(assignment domain.TeachingAssignment) TeachingAssignment {
+	return TeachingAssignment{
+		Id:             assignment.Id,
+		AccountId:      assignment.AccountId,
+		TeacherName:    assignment.TeacherName,
+		DisciplineId:   assignment.DisciplineId,
+		DisciplineName: assignment.DisciplineName,
+		GroupName:      assignment.GroupName,
+		Term:           assignment.Term,
+		CreatedAt:      assignment.CreatedAt,
+	}
+}
+
+type TeachingAssignments struct {
+	Assignments []TeachingAssignment `json:"assignments"`
+}
+
+func (a TeachingAssignments) DomainToResponse(assignments []domain.TeachingAssignment) TeachingAssignments {
+	a.Assignments = make([]TeachingAssignment, 0, len(assignments))
+
+	for _, assignment := range assignments {
+		a.Assignments = append(a.Assignments, TeachingAssignment{}.DomainToResponse(assignment))
+	}
+
+	return a
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type GroupMembership struct {
+	AccountId int    `json:"account_id"`
+	GroupName string `json:"group_name"`
+
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+
+	Reason string `json:"reason"`
+}
+
+func (m GroupMembership) DomainToResponse(membership domain.GroupMembership) GroupMembership {
+	return GroupMembership{
+		AccountId:     membership.AccountId,
+		GroupName:     membership.GroupName,
+		EffectiveFrom: membership.EffectiveFrom,
+		EffectiveTo:   membership.EffectiveTo,
+		Reason:        membership.Reason,
+	}
+}
+
+type GroupMemberships struct {
+	Memberships []GroupMembership `json:"memberships"`
+}
+
+func (m GroupMemberships) DomainToResponse(memberships []domain.GroupMembership) GroupMemberships {
+	m.Memberships = make([]GroupMembership, 0, len(memberships))
+
+	for _, membership := range memberships {
+		m.Memberships = append(m.Memberships, GroupMembership{}.DomainToResponse(membership))
+	}
+
+	return m
+}
+
+type Cohort struct {
+	GroupName    string `json:"group_name"`
+	NewGroupName string `json:"new_group_name,omitempty"`
+
+	IsGraduated bool      `json:"is_graduated"`
+	EffectiveAt time.Time `json:"effective_at"`
+
+	Members []GroupMembership `json:"members"`
+}
+
+func (c Cohort) DomainToResponse(cohort domain.Cohort) Cohort {
+	return Cohort{
+		GroupName:    cohort.GroupName,
+		NewGroupName: cohort.NewGroupName,
+		IsGraduated:  cohort.IsGraduated,
+		EffectiveAt:  cohort.EffectiveAt,
+		Members:      GroupMemberships{}.DomainToResponse(cohort.Members).Memberships,
+	}
+}
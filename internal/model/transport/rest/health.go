@@ -0,0 +1,71 @@
+package rest
+
+import "practice_vgpek/internal/model/domain"
+
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+)
+
+type Health struct {
+	Status string `json:"status"`
+}
+
+type HealthCheck struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+type Readiness struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+func (r Readiness) DomainToResponse(readiness domain.Readiness) Readiness {
+	r.Status = StatusOk
+	if !readiness.Ready() {
+		r.Status = StatusFail
+	}
+
+	r.Checks = make([]HealthCheck, 0, len(readiness.Checks))
+
+	for _, check := range readiness.Checks {
+		c := HealthCheck{
+			Name:       check.Name,
+			Status:     StatusOk,
+			DurationMs: float64(check.Duration.Microseconds()) / 1000,
+		}
+
+		if check.Err != nil {
+			c.Status = StatusFail
+			c.Error = check.Err.Error()
+		}
+
+		r.Checks = append(r.Checks, c)
+	}
+
+	return r
+}
+
+type Version struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+
+	MigrationVersion         *int64 `json:"migration_version"`
+	ExpectedMigrationVersion int64  `json:"expected_migration_version"`
+}
+
+func (v Version) DomainToResponse(info domain.BuildInfo) Version {
+	return Version{
+		Commit:                   info.Commit,
+		BuildTime:                info.BuildTime,
+		Modified:                 info.Modified,
+		GoVersion:                info.GoVersion,
+		MigrationVersion:         info.MigrationVersion,
+		ExpectedMigrationVersion: info.ExpectedMigrationVersion,
+	}
+}
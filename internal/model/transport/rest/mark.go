@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type MarkHistoryEntry struct {
+	Id               int `json:"id"`
+	SolvedPracticeId int `json:"solved_practice_id"`
+
+	OldMark int `json:"old_mark"`
+	NewMark int `json:"new_mark"`
+
+	ChangedBy   string    `json:"changed_by"`
+	ChangedById int       `json:"changed_by_id"`
+	ChangedAt   time.Time `json:"changed_at"`
+
+	Source string `json:"source"`
+}
+
+type MarkHistory struct {
+	History []MarkHistoryEntry `json:"history"`
+}
+
+func (h MarkHistory) DomainToResponse(history []domain.MarkHistory) MarkHistory {
+	h.History = make([]MarkHistoryEntry, 0, len(history))
+
+	for _, entry := range history {
+		h.History = append(h.History, MarkHistoryEntry{
+			Id:               entry.Id,
+			SolvedPracticeId: entry.SolvedPracticeId,
+			OldMark:          entry.OldMark,
+			NewMark:          entry.NewMark,
+			ChangedBy:        entry.ChangedBy,
+			ChangedById:      entry.ChangedById,
+			ChangedAt:        entry.ChangedAt,
+			Source:           entry.Source,
+		})
+	}
+
+	return h
+}
+
+type MarkImportRow struct {
+	Row int `json:"row"`
+
+	IssuedPracticeId int `json:"issued_practice_id"`
+	SolvedPracticeId int `json:"solved_practice_id,omitempty"`
+
+	Student   string `json:"student"`
+	GroupName string `json:"group_name,omitempty"`
+
+	OldMark int `json:"old_mark"`
+	NewMark int `json:"new_mark"`
+
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type MarkImport struct {
+	Rows []MarkImportRow `json:"rows"`
+
+	Changes int `json:"changes"`
+	Errors  int `json:"errors"`
+
+	Applied bool `json:"applied"`
+}
+
+func (i MarkImport) DomainToResponse(result domain.MarkImport) MarkImport {
+	i.Rows = make([]MarkImportRow, 0, len(result.Rows))
+
+	for _, row := range result.Rows {
+		i.Rows = append(i.Rows, MarkImportRow{
+			Row:              row.Row,
+			IssuedPracticeId: row.IssuedPracticeId,
+			SolvedPracticeId: row.SolvedPracticeId,
+			Student:          row.Student,
+			GroupName:        row.GroupName,
+			OldMark:          row.OldMark,
+			NewMark:          row.NewMark,
+			Status:           row.Status,
+			Error:            row.Error,
+		})
+	}
+
+	i.Changes = result.Changes
+	i.Errors = result.Errors
+	i.Applied = result.Applied
+
+	return i
+}
@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type Notification struct {
+	Id int `json:"id"`
+
+	Message string `json:"message"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	IsRead bool       `json:"is_read"`
+	ReadAt *time.Time `json:"read_at,omitempty"`
+}
+
+type Notifications struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+func (n Notifications) DomainToResponse(notifications []domain.Notification) Notifications {
+	n.Notifications = make([]Notification, 0, len(notifications))
+
+	for _, notification := range notifications {
+		n.Notifications = append(n.Notifications, Notification{
+			Id:        notification.Id,
+			Message:   notification.Message,
+			CreatedAt: notification.CreatedAt,
+			IsRead:    notification.IsRead,
+			ReadAt:    notification.ReadAt,
+		})
+	}
+
+	return n
+}
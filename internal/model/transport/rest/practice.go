@@ -7,16 +7,20 @@ import (
 
 func (p IssuedPractice) DomainToResponse(practice domain.IssuedPractice) IssuedPractice {
 	return IssuedPractice{
-		Id:           practice.Id,
-		AuthorName:   practice.AuthorName,
-		AuthorId:     practice.AuthorId,
-		TargetGroups: practice.TargetGroups,
-		Title:        practice.Title,
-		Theme:        practice.Theme,
-		Major:        practice.Major,
-		UploadAt:     practice.UploadAt,
-		IsDeleted:    practice.IsDeleted,
-		DeletedAt:    practice.DeletedAt,
+		Id:             practice.Id,
+		AuthorName:     practice.AuthorName,
+		AuthorId:       practice.AuthorId,
+		TargetGroups:   practice.TargetGroups,
+		DisciplineId:   practice.DisciplineId,
+		AcademicTermId: practice.AcademicTermId,
+		Deadline:       practice.Deadline,
+		ClonedFrom:     practice.ClonedFrom,
+		Title:          practice.Title,
+		Theme:          practice.Theme,
+		Major:          practice.Major,
+		UploadAt:       practice.UploadAt,
+		IsDeleted:      practice.IsDeleted,
+		DeletedAt:      practice.DeletedAt,
 	}
 }
 
@@ -28,6 +32,13 @@ type IssuedPractice struct {
 
 	TargetGroups []string `json:"target_groups"`
 
+	DisciplineId *int `json:"discipline_id"`
+
+	AcademicTermId *int `json:"academic_term_id"`
+
+	Deadline   *time.Time `json:"deadline,omitempty"`
+	ClonedFrom *int       `json:"cloned_from,omitempty"`
+
 	Title string `json:"title"`
 	Theme string `json:"theme"`
 	Major string `json:"major"`
@@ -38,6 +49,48 @@ type IssuedPractice struct {
 	DeletedAt *time.Time `json:"deleted_at"`
 }
 
+type IssuedPractices struct {
+	Practices []IssuedPractice `json:"practices"`
+}
+
+func (p IssuedPractices) DomainToResponse(practices []domain.IssuedPractice) IssuedPractices {
+	p.Practices = make([]IssuedPractice, 0, len(practices))
+
+	for _, practice := range practices {
+		p.Practices = append(p.Practices, IssuedPractice{}.DomainToResponse(practice))
+	}
+
+	return p
+}
+
+type PracticeSearchHit struct {
+	Practice IssuedPractice `json:"practice"`
+
+	Rank float64 `json:"rank"`
+
+	TitleHeadline string `json:"title_headline"`
+	Snippet       string `json:"snippet"`
+}
+
+type PracticeSearchResult struct {
+	Hits []PracticeSearchHit `json:"hits"`
+}
+
+func (p PracticeSearchResult) DomainToResponse(hits []domain.PracticeSearchHit) PracticeSearchResult {
+	p.Hits = make([]PracticeSearchHit, 0, len(hits))
+
+	for _, hit := range hits {
+		p.Hits = append(p.Hits, PracticeSearchHit{
+			Practice:      IssuedPractice{}.DomainToResponse(hit.Practice),
+			Rank:          hit.Rank,
+			TitleHeadline: hit.TitleHeadline,
+			Snippet:       hit.Snippet,
+		})
+	}
+
+	return p
+}
+
 type SolvedPractice struct {
 	Id               int `json:"id"`
 	IssuedPracticeId int `json:"issued_practice_id"`
@@ -47,6 +100,10 @@ type SolvedPractice struct {
 	AuthorName string `json:"author_name"`
 	AuthorId   int    `json:"author_id"`
 
+	GroupName string `json:"group_name"`
+
+	DuplicateOf *int `json:"duplicate_of,omitempty"`
+
 	Mark     int        `json:"mark"`
 	MarkTime *time.Time `json:"mark_time,omitempty"`
 
@@ -57,27 +114,59 @@ type SolvedPractice struct {
 }
 
 func (p SolvedPractice) DomainToResponse(practice domain.SolvedPractice) SolvedPractice {
-	return SolvedPractice{}
+	return SolvedPractice{
+		Id:               practice.Id,
+		IssuedPracticeId: practice.IssuedPracticeId,
+		IssuerName:       practice.IssuerName,
+		AuthorName:       practice.AuthorName,
+		AuthorId:         practice.AuthorId,
+		GroupName:        practice.GroupName,
+		DuplicateOf:      practice.DuplicateOf,
+		Mark:             practice.Mark,
+		MarkTime:         practice.MarkTime,
+		SolvedTime:       practice.SolvedTime,
+		IsDeleted:        practice.IsDeleted,
+		DeletedAt:        practice.DeletedAt,
+	}
+}
+
+// DownloadLink подписанная ссылка на скачивание файла, которая открывается без заголовка Authorization
+type DownloadLink struct {
+	DownloadLink string `json:"download_link,omitempty"`
+
+	// DownloadLinkId нужен для отзыва ссылки до истечения срока ее действия
+	DownloadLinkId        string     `json:"download_link_id,omitempty"`
+	DownloadLinkExpiresAt *time.Time `json:"download_link_expires_at,omitempty"`
+	DownloadLinkSingleUse bool       `json:"download_link_single_use,omitempty"`
+}
+
+func (l DownloadLink) DomainToResponse(url string, link domain.DownloadLink) DownloadLink {
+	return DownloadLink{
+		DownloadLink:          url,
+		DownloadLinkId:        link.Id,
+		DownloadLinkExpiresAt: &link.ExpiresAt,
+		DownloadLinkSingleUse: link.SingleUse,
+	}
 }
 
 type IssuedPracticeWithLink struct {
 	IssuedPractice
-	DownloadLink string `json:"download_link"`
+	DownloadLink
 }
 
 type SolvedPracticeWithLink struct {
 	SolvedPractice
-	DownloadLink string `json:"download_link"`
+	DownloadLink
 }
 
-func (p IssuedPractice) WithDownloadLink(link string) IssuedPracticeWithLink {
+func (p IssuedPractice) WithDownloadLink(link DownloadLink) IssuedPracticeWithLink {
 	return IssuedPracticeWithLink{
 		IssuedPractice: p,
 		DownloadLink:   link,
 	}
 }
 
-func (p SolvedPractice) WithDownloadLink(link string) SolvedPracticeWithLink {
+func (p SolvedPractice) WithDownloadLink(link DownloadLink) SolvedPracticeWithLink {
 	return SolvedPracticeWithLink{
 		SolvedPractice: p,
 		DownloadLink:   link,
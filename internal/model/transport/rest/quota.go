@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type StorageQuota struct {
+	Id int `json:"id"`
+
+	RoleId    *int    `json:"role_id,omitempty"`
+	GroupName *string `json:"group_name,omitempty"`
+
+	LimitBytes int64 `json:"limit_bytes"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q StorageQuota) DomainToResponse(quota domain.StorageQuota) StorageQuota {
+	return StorageQuota{
+		Id:         quota.Id,
+		RoleId:     quota.RoleId,
+		GroupName:  quota.GroupName,
+		LimitBytes: quota.LimitBytes,
+		UpdatedAt:  quota.UpdatedAt,
+	}
+}
+
+type StorageQuotas struct {
+	Quotas []StorageQuota `json:"quotas"`
+}
+
+func (q StorageQuotas) DomainToResponse(quotas []domain.StorageQuota) StorageQuotas {
+	q.Quotas = make([]StorageQuota, 0, len(quotas))
+
+	for _, quota := range quotas {
+		q.Quotas = append(q.Quotas, StorageQuota{}.DomainToResponse(quota))
+	}
+
+	return q
+}
+
+type StorageUsage struct {
+	AccountId *int    `json:"account_id,omitempty"`
+	Login     *string `json:"login,omitempty"`
+	GroupName *string `json:"group_name,omitempty"`
+
+	UsedBytes int64 `json:"used_bytes"`
+	FileCount int   `json:"file_count"`
+}
+
+type TopConsumers struct {
+	Consumers []StorageUsage `json:"consumers"`
+}
+
+func (t TopConsumers) DomainToResponse(usage []domain.StorageUsage) TopConsumers {
+	t.Consumers = make([]StorageUsage, 0, len(usage))
+
+	for _, u := range usage {
+		t.Consumers = append(t.Consumers, StorageUsage{
+			AccountId: u.AccountId,
+			Login:     u.Login,
+			GroupName: u.GroupName,
+			UsedBytes: u.UsedBytes,
+			FileCount: u.FileCount,
+		})
+	}
+
+	return t
+}
+
+type AccountStorage struct {
+	UsedBytes  int64  `json:"used_bytes"`
+	LimitBytes *int64 `json:"limit_bytes"`
+
+	GroupName       string `json:"group_name,omitempty"`
+	GroupUsedBytes  int64  `json:"group_used_bytes,omitempty"`
+	GroupLimitBytes *int64 `json:"group_limit_bytes,omitempty"`
+}
+
+func (a AccountStorage) DomainToResponse(storage domain.AccountStorage) AccountStorage {
+	return AccountStorage{
+		UsedBytes:       storage.UsedBytes,
+		LimitBytes:      storage.LimitBytes,
+		GroupName:       storage.GroupName,
+		GroupUsedBytes:  storage.GroupUsedBytes,
+		GroupLimitBytes: storage.GroupLimitBytes,
+	}
+}
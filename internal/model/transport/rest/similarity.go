@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type SimilarityWork struct {
+	SolvedPracticeId int    `json:"solved_practice_id"`
+	Student          string `json:"student"`
+	GroupName        string `json:"group_name"`
+}
+
+type SimilarityPair struct {
+	First  SimilarityWork `json:"first"`
+	Second SimilarityWork `json:"second"`
+
+	Score       float64 `json:"score"`
+	IsIdentical bool    `json:"is_identical"`
+
+	Fragments []string `json:"fragments"`
+
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+type SimilarityReport struct {
+	Pairs []SimilarityPair `json:"pairs"`
+}
+
+func (r SimilarityReport) DomainToResponse(pairs []domain.SimilarityPair) SimilarityReport {
+	r.Pairs = make([]SimilarityPair, 0, len(pairs))
+
+	for _, pair := range pairs {
+		r.Pairs = append(r.Pairs, SimilarityPair{
+			First: SimilarityWork{
+				SolvedPracticeId: pair.FirstSolvedId,
+				Student:          pair.FirstStudent,
+				GroupName:        pair.FirstGroupName,
+			},
+			Second: SimilarityWork{
+				SolvedPracticeId: pair.SecondSolvedId,
+				Student:          pair.SecondStudent,
+				GroupName:        pair.SecondGroupName,
+			},
+			Score:       pair.Score,
+			IsIdentical: pair.IsIdentical,
+			Fragments:   pair.Fragments,
+			ComputedAt:  pair.ComputedAt,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"practice_vgpek/internal/model/domain"
+	"time"
+)
+
+type AcademicTerm struct {
+	Id int `json:"id"`
+
+	Name string `json:"name"`
+
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+
+	IsCurrent bool `json:"is_current"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	IsClosed bool       `json:"is_closed"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+}
+
+func (t AcademicTerm) DomainToResponse(term domain.AcademicTerm) AcademicTerm {
+	return AcademicTerm{
+		Id:        term.Id,
+		Name:      term.Name,
+		StartDate: term.StartDate,
+		EndDate:   term.EndDate,
+		IsCurrent: term.IsCurrent,
+		CreatedAt: term.CreatedAt,
+		IsClosed:  term.IsClosed,
+		ClosedAt:  term.ClosedAt,
+	}
+}
+
+type AcademicTerms struct {
+	Terms []AcademicTerm `json:"terms"`
+}
+
+func (t AcademicTerms) DomainToResponse(terms []domain.AcademicTerm) AcademicTerms {
+	t.Terms = make([]AcademicTerm, 0, len(terms))
+
+	for _, term := range terms {
+		t.Terms = append(t.Terms, AcademicTerm{}.DomainToResponse(term))
+	}
+
+	return t
+}
@@ -0,0 +1,74 @@
+package discipline
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+func (s Service) DeleteDisciplineById(ctx context.Context, req dto.EntityId) (domain.Discipline, error) {
+	ctx, span := tracing.Start(ctx, "discipline.DeleteDisciplineById")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DeleteDisciplineOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	info := dto.DeleteInfo{DeleteTime: time.Now()}
+
+	err := s.disciplineDAO.SoftDeleteById(ctx, req.Id, info)
+	if err != nil {
+		l.Warn("ошибка мягкого удаления дисциплины",
+			zap.Int("id дисциплины", req.Id),
+			zap.Time("время удаления", info.DeleteTime),
+		)
+
+		return domain.Discipline{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка удаления дисциплины")
+	}
+
+	deleted, err := s.disciplineDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.Discipline{}, ctxutils.Wrap(ctx, err, "Ошибка удаления дисциплины")
+	}
+
+	return disciplineEntityToDomain(deleted), nil
+}
+
+func (s Service) DeleteAssignmentById(ctx context.Context, req dto.EntityId) (domain.TeachingAssignment, error) {
+	ctx, span := tracing.Start(ctx, "discipline.DeleteAssignmentById")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DeleteAssignmentOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	assignmentEntity, err := s.assignmentDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Нет назначения с таким id")
+	}
+
+	info := dto.DeleteInfo{DeleteTime: time.Now()}
+
+	err = s.assignmentDAO.SoftDeleteById(ctx, req.Id, info)
+	if err != nil {
+		l.Warn("ошибка снятия назначения", zap.Int("id назначения", req.Id))
+
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка снятия назначения")
+	}
+
+	assignment, err := s.assignmentEntityToDomain(ctx, assignmentEntity)
+	if err != nil {
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Ошибка формирования назначения")
+	}
+
+	return assignment, nil
+}
@@ -0,0 +1,122 @@
+package discipline
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/params"
+)
+
+type DisciplineDAO interface {
+	Save(ctx context.Context, data dto.NewDisciplineReq) (entity.Discipline, error)
+	ById(ctx context.Context, id int) (entity.Discipline, error)
+	ByParams(ctx context.Context, p params.Default) ([]entity.Discipline, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+}
+
+type AssignmentDAO interface {
+	Save(ctx context.Context, data dto.NewAssignmentReq) (entity.TeachingAssignment, error)
+	ById(ctx context.Context, id int) (entity.TeachingAssignment, error)
+	ByAccountId(ctx context.Context, accountId int) ([]entity.TeachingAssignment, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+}
+
+type TermDAO interface {
+	ByName(ctx context.Context, name string) (entity.AcademicTerm, error)
+}
+
+type AccountDAO interface {
+	ById(ctx context.Context, id int) (entity.Account, error)
+}
+
+type PersonDAO interface {
+	ByAccountId(ctx context.Context, accountId int) (entity.Person, error)
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	disciplineDAO DisciplineDAO
+	assignmentDAO AssignmentDAO
+	accountDAO    AccountDAO
+	personDAO     PersonDAO
+	termDAO       TermDAO
+}
+
+func New(disciplineDAO DisciplineDAO, assignmentDAO AssignmentDAO, accountDAO AccountDAO,
+	personDAO PersonDAO, termDAO TermDAO, logger *zap.Logger) Service {
+	return Service{
+		logger:        logger,
+		disciplineDAO: disciplineDAO,
+		assignmentDAO: assignmentDAO,
+		accountDAO:    accountDAO,
+		personDAO:     personDAO,
+		termDAO:       termDAO,
+	}
+}
+
+func disciplineEntityToDomain(discipline entity.Discipline) domain.Discipline {
+	var isDeleted bool
+
+	if discipline.IsDeleted != nil {
+		isDeleted = true
+	}
+
+	return domain.Discipline{
+		Id:          discipline.Id,
+		Name:        discipline.Name,
+		Description: discipline.Description,
+		CreatedAt:   discipline.CreatedAt,
+		IsDeleted:   isDeleted,
+		DeletedAt:   discipline.IsDeleted,
+	}
+}
+
+// assignmentEntityToDomain дополняет назначение именем преподавателя и названием дисциплины
+func (s Service) assignmentEntityToDomain(ctx context.Context, assignment entity.TeachingAssignment) (domain.TeachingAssignment, error) {
+	person, err := s.personDAO.ByAccountId(ctx, assignment.AccountId)
+	if err != nil {
+		return domain.TeachingAssignment{}, err
+	}
+
+	discipline, err := s.disciplineDAO.ById(ctx, assignment.DisciplineId)
+	if err != nil {
+		return domain.TeachingAssignment{}, err
+	}
+
+	return domain.TeachingAssignment{
+		Id:             assignment.Id,
+		AccountId:      assignment.AccountId,
+		TeacherName:    fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName),
+		DisciplineId:   discipline.Id,
+		DisciplineName: discipline.Name,
+		GroupName:      assignment.GroupName,
+		Term:           assignment.Term,
+		CreatedAt:      assignment.CreatedAt,
+	}, nil
+}
+
+// filterDisciplines отбирает дисциплины по состоянию удаления
+func filterDisciplines(disciplines []domain.Discipline, state string) []domain.Discipline {
+	resp := make([]domain.Discipline, 0, len(disciplines))
+
+	for _, discipline := range disciplines {
+		switch state {
+		case params.All:
+			resp = append(resp, discipline)
+		case params.Deleted:
+			if discipline.IsDeleted {
+				resp = append(resp, discipline)
+			}
+		case params.NotDeleted:
+			if !discipline.IsDeleted {
+				resp = append(resp, discipline)
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,122 @@
+package discipline
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+func (s Service) DisciplineById(ctx context.Context, req dto.EntityId) (domain.Discipline, error) {
+	ctx, span := tracing.Start(ctx, "discipline.DisciplineById")
+	defer span.End()
+
+	disciplineEntity, err := s.disciplineDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.Discipline{}, ctxutils.Wrap(ctx, err, "Нет дисциплины с таким id")
+	}
+
+	return disciplineEntityToDomain(disciplineEntity), nil
+}
+
+func (s Service) DisciplinesByParams(ctx context.Context, p params.State) ([]domain.Discipline, error) {
+	ctx, span := tracing.Start(ctx, "discipline.DisciplinesByParams")
+	defer span.End()
+
+	disciplinesEntity, err := s.disciplineDAO.ByParams(ctx, p.Default)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения дисциплин")
+	}
+
+	disciplines := make([]domain.Discipline, 0, len(disciplinesEntity))
+
+	for _, disciplineEntity := range disciplinesEntity {
+		disciplines = append(disciplines, disciplineEntityToDomain(disciplineEntity))
+	}
+
+	return filterDisciplines(disciplines, p.State), nil
+}
+
+// DisciplinesByAccountId возвращает дисциплины, которые ведет преподаватель
+func (s Service) DisciplinesByAccountId(ctx context.Context, req dto.EntityId) ([]domain.Discipline, error) {
+	ctx, span := tracing.Start(ctx, "discipline.DisciplinesByAccountId")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetDisciplinesOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	assignments, err := s.assignmentDAO.ByAccountId(ctx, req.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения назначений преподавателя")
+	}
+
+	seen := make(map[int]bool, len(assignments))
+	disciplines := make([]domain.Discipline, 0, len(assignments))
+
+	for _, assignment := range assignments {
+		if seen[assignment.DisciplineId] {
+			continue
+		}
+
+		seen[assignment.DisciplineId] = true
+
+		disciplineEntity, err := s.disciplineDAO.ById(ctx, assignment.DisciplineId)
+		if err != nil {
+			l.Warn("ошибка получения дисциплины назначения",
+				zap.Int("id дисциплины", assignment.DisciplineId),
+				zap.Error(err),
+			)
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка получения дисциплин")
+		}
+
+		if disciplineEntity.IsDeleted != nil {
+			continue
+		}
+
+		disciplines = append(disciplines, disciplineEntityToDomain(disciplineEntity))
+	}
+
+	return disciplines, nil
+}
+
+func (s Service) AssignmentsByAccountId(ctx context.Context, req dto.EntityId) ([]domain.TeachingAssignment, error) {
+	ctx, span := tracing.Start(ctx, "discipline.AssignmentsByAccountId")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetAssignmentsOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	assignmentsEntity, err := s.assignmentDAO.ByAccountId(ctx, req.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения назначений преподавателя")
+	}
+
+	assignments := make([]domain.TeachingAssignment, 0, len(assignmentsEntity))
+
+	for _, assignmentEntity := range assignmentsEntity {
+		assignment, err := s.assignmentEntityToDomain(ctx, assignmentEntity)
+		if err != nil {
+			l.Warn("ошибка формирования назначения",
+				zap.Int("id назначения", assignmentEntity.Id),
+				zap.Error(err),
+			)
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка формирования назначений")
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, nil
+}
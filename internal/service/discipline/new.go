@@ -0,0 +1,101 @@
+package discipline
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+func (s Service) NewDiscipline(ctx context.Context, req dto.NewDisciplineReq) (domain.Discipline, error) {
+	ctx, span := tracing.Start(ctx, "discipline.NewDiscipline")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AddDisciplineOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.Name == "" {
+		l.Warn("пустое название дисциплины")
+
+		return domain.Discipline{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "name"})
+	}
+
+	saved, err := s.disciplineDAO.Save(ctx, req)
+	if err != nil {
+		return domain.Discipline{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка сохранения дисциплины")
+	}
+
+	return disciplineEntityToDomain(saved), nil
+}
+
+func (s Service) NewAssignment(ctx context.Context, req dto.NewAssignmentReq) (domain.TeachingAssignment, error) {
+	ctx, span := tracing.Start(ctx, "discipline.NewAssignment")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AddAssignmentOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.GroupName == "" || req.Term == "" {
+		return domain.TeachingAssignment{}, apperr.Validation(domain.CodeFieldsRequired).WithDetails(map[string]any{"fields": "group_name, term"})
+	}
+
+	term, err := s.termDAO.ByName(ctx, req.Term)
+	if err != nil {
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Нет семестра с таким названием")
+	}
+
+	if term.ClosedAt != nil {
+		return domain.TeachingAssignment{}, apperr.Conflict(domain.CodeTermClosed)
+	}
+
+	acc, err := s.accountDAO.ById(ctx, req.AccountId)
+	if err != nil {
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Нет аккаунта с таким id")
+	}
+
+	if !acc.IsActive {
+		return domain.TeachingAssignment{}, apperr.Conflict(domain.CodeAccountInactive)
+	}
+
+	discipline, err := s.disciplineDAO.ById(ctx, req.DisciplineId)
+	if err != nil {
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Нет дисциплины с таким id")
+	}
+
+	if discipline.IsDeleted != nil {
+		return domain.TeachingAssignment{}, apperr.Conflict(domain.CodeDisciplineDeleted)
+	}
+
+	saved, err := s.assignmentDAO.Save(ctx, req)
+	if err != nil {
+		l.Warn("ошибка сохранения назначения", zap.Error(err))
+
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Не удалось назначить преподавателя, возможно, назначение уже существует")
+	}
+
+	assignment, err := s.assignmentEntityToDomain(ctx, saved)
+	if err != nil {
+		l.Warn("ошибка формирования назначения", zap.Error(err))
+
+		return domain.TeachingAssignment{}, ctxutils.Wrap(ctx, err, "Ошибка формирования назначения")
+	}
+
+	l.Info("преподаватель назначен на дисциплину",
+		zap.Int("id аккаунта", assignment.AccountId),
+		zap.Int("id дисциплины", assignment.DisciplineId),
+		zap.String("группа", assignment.GroupName),
+		zap.String("семестр", assignment.Term),
+	)
+
+	return assignment, nil
+}
@@ -0,0 +1,42 @@
+package group
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+func (s Service) MembersByGroup(ctx context.Context, req dto.GroupMembersReq) ([]domain.GroupMembership, error) {
+	ctx, span := tracing.Start(ctx, "group.MembersByGroup")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetGroupMembersOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.GroupName == "" {
+		return nil, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "group_name"})
+	}
+
+	members, err := s.groupDAO.MembersByGroup(ctx, req.GroupName, req.At)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения состава группы")
+	}
+
+	resp := make([]domain.GroupMembership, 0, len(members))
+	for _, member := range members {
+		resp = append(resp, membershipEntityToDomain(member))
+	}
+
+	l.Info("состав группы получен", zap.String("группа", req.GroupName), zap.Int("кол-во", len(resp)))
+
+	return resp, nil
+}
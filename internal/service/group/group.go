@@ -0,0 +1,65 @@
+package group
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"time"
+)
+
+type GroupDAO interface {
+	Save(ctx context.Context, data dto.NewMembership) (entity.AccountGroup, error)
+
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
+	MembersByGroup(ctx context.Context, groupName string, at time.Time) ([]entity.AccountGroup, error)
+
+	Close(ctx context.Context, id int, at time.Time) error
+}
+
+type AccountDAO interface {
+	ById(ctx context.Context, id int) (entity.Account, error)
+	Deactivate(ctx context.Context, id int, at time.Time) error
+}
+
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	groupDAO   GroupDAO
+	accountDAO AccountDAO
+
+	tx TxManager
+}
+
+func New(groupDAO GroupDAO, accountDAO AccountDAO, tx TxManager, logger *zap.Logger) Service {
+	return Service{
+		logger:     logger,
+		groupDAO:   groupDAO,
+		accountDAO: accountDAO,
+		tx:         tx,
+	}
+}
+
+func membershipEntityToDomain(membership entity.AccountGroup) domain.GroupMembership {
+	return domain.GroupMembership{
+		AccountId:     membership.AccountId,
+		GroupName:     membership.GroupName,
+		EffectiveFrom: membership.EffectiveFrom,
+		EffectiveTo:   membership.EffectiveTo,
+		Reason:        membership.Reason,
+	}
+}
+
+// effectiveAt возвращает дату вступления изменений в силу, по умолчанию - текущее время
+func effectiveAt(at *time.Time) time.Time {
+	if at == nil {
+		return time.Now()
+	}
+
+	return *at
+}
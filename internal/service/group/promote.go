@@ -0,0 +1,137 @@
+package group
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// PromoteCohort переводит всю группу под новым названием либо выпускает ее.
+// Выпуск завершает членство студентов в группе и деактивирует их аккаунты,
+// сданные работы при этом остаются привязанными к исходной группе.
+func (s Service) PromoteCohort(ctx context.Context, req dto.PromoteCohortReq) (domain.Cohort, error) {
+	ctx, span := tracing.Start(ctx, "group.PromoteCohort")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.PromoteCohortOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.GroupName == "" {
+		return domain.Cohort{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "group_name"})
+	}
+
+	if !req.Graduate && (req.NewGroupName == "" || req.NewGroupName == req.GroupName) {
+		return domain.Cohort{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "new_group_name"})
+	}
+
+	at := effectiveAt(req.EffectiveAt)
+
+	cohort := domain.Cohort{
+		GroupName:   req.GroupName,
+		IsGraduated: req.Graduate,
+		EffectiveAt: at,
+	}
+
+	if !req.Graduate {
+		cohort.NewGroupName = req.NewGroupName
+	}
+
+	// Состав группы читается и меняется в одной транзакции: при ошибке группа
+	// не остается переведенной или выпущенной частично
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		members, err := s.groupDAO.MembersByGroup(ctx, req.GroupName, at)
+		if err != nil {
+			return ctxutils.Wrap(ctx, err, "Ошибка получения состава группы")
+		}
+
+		if len(members) == 0 {
+			return apperr.NotFound(domain.CodeEmptyGroup)
+		}
+
+		if req.Graduate {
+			cohort.Members, err = s.graduate(ctx, l, members, at)
+		} else {
+			cohort.Members, err = s.promote(ctx, l, members, req.NewGroupName, at)
+		}
+
+		return err
+	})
+	if err != nil {
+		return domain.Cohort{}, err
+	}
+
+	if req.Graduate {
+		l.Info("группа выпущена",
+			zap.String("группа", req.GroupName),
+			zap.Int("кол-во выпускников", len(cohort.Members)),
+		)
+
+		return cohort, nil
+	}
+
+	l.Info("группа переведена",
+		zap.String("группа", req.GroupName),
+		zap.String("новая группа", req.NewGroupName),
+		zap.Int("кол-во студентов", len(cohort.Members)),
+	)
+
+	return cohort, nil
+}
+
+// graduate завершает членство студентов в группе и деактивирует их аккаунты
+func (s Service) graduate(ctx context.Context, l *zap.Logger, members []entity.AccountGroup, at time.Time) ([]domain.GroupMembership, error) {
+	graduates := make([]domain.GroupMembership, 0, len(members))
+
+	for _, member := range members {
+		err := s.groupDAO.Close(ctx, member.Id, at)
+		if err != nil {
+			l.Warn("ошибка завершения членства", zap.Int("id аккаунта", member.AccountId), zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка выпуска группы")
+		}
+
+		err = s.accountDAO.Deactivate(ctx, member.AccountId, at)
+		if err != nil {
+			l.Warn("ошибка деактивации аккаунта", zap.Int("id аккаунта", member.AccountId), zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка выпуска группы")
+		}
+
+		graduated := membershipEntityToDomain(member)
+		graduated.EffectiveTo = &at
+		graduated.Reason = domain.GraduationReason
+
+		graduates = append(graduates, graduated)
+	}
+
+	return graduates, nil
+}
+
+// promote переводит студентов группы в группу newGroupName
+func (s Service) promote(ctx context.Context, l *zap.Logger, members []entity.AccountGroup, newGroupName string, at time.Time) ([]domain.GroupMembership, error) {
+	moved := make([]domain.GroupMembership, 0, len(members))
+
+	for _, member := range members {
+		membership, err := s.move(ctx, member.AccountId, newGroupName, at, domain.PromotionReason)
+		if err != nil {
+			l.Warn("ошибка перевода аккаунта", zap.Int("id аккаунта", member.AccountId), zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка перевода группы")
+		}
+
+		moved = append(moved, membership)
+	}
+
+	return moved, nil
+}
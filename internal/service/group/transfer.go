@@ -0,0 +1,113 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+func (s Service) Transfer(ctx context.Context, req dto.TransferReq) ([]domain.GroupMembership, error) {
+	ctx, span := tracing.Start(ctx, "group.Transfer")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.TransferAccountsOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.GroupName == "" {
+		return nil, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "group_name"})
+	}
+
+	if len(req.AccountIds) == 0 {
+		return nil, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "account_ids"})
+	}
+
+	at := effectiveAt(req.EffectiveAt)
+
+	// Аккаунты переводятся в одной транзакции: при ошибке ни один перевод не сохраняется
+	var memberships []domain.GroupMembership
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		memberships = make([]domain.GroupMembership, 0, len(req.AccountIds))
+
+		for _, accountId := range req.AccountIds {
+			membership, err := s.move(ctx, accountId, req.GroupName, at, domain.TransferReason)
+			if err != nil {
+				l.Warn("ошибка перевода аккаунта",
+					zap.Int("id аккаунта", accountId),
+					zap.String("группа", req.GroupName),
+					zap.Error(err),
+				)
+
+				return ctxutils.Wrap(ctx, err, fmt.Sprintf("Ошибка перевода аккаунта %d", accountId))
+			}
+
+			memberships = append(memberships, membership)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("аккаунты переведены",
+		zap.Ints("id аккаунтов", req.AccountIds),
+		zap.String("группа", req.GroupName),
+		zap.Time("дата перевода", at),
+	)
+
+	return memberships, nil
+}
+
+// move завершает текущее членство аккаунта в группе и открывает новое с момента at
+func (s Service) move(ctx context.Context, accountId int, groupName string, at time.Time, reason string) (domain.GroupMembership, error) {
+	account, err := s.accountDAO.ById(ctx, accountId)
+	if err != nil {
+		return domain.GroupMembership{}, err
+	}
+
+	if !account.IsActive {
+		return domain.GroupMembership{}, apperr.Conflict(domain.CodeAccountInactive)
+	}
+
+	current, err := s.groupDAO.CurrentByAccountId(ctx, accountId, at)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.GroupMembership{}, err
+	}
+
+	if err == nil {
+		if current.GroupName == groupName {
+			return membershipEntityToDomain(current), nil
+		}
+
+		err = s.groupDAO.Close(ctx, current.Id, at)
+		if err != nil {
+			return domain.GroupMembership{}, err
+		}
+	}
+
+	saved, err := s.groupDAO.Save(ctx, dto.NewMembership{
+		AccountId:     accountId,
+		GroupName:     groupName,
+		EffectiveFrom: at,
+		Reason:        reason,
+	})
+	if err != nil {
+		return domain.GroupMembership{}, err
+	}
+
+	return membershipEntityToDomain(saved), nil
+}
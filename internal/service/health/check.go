@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/buildinfo"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// Readiness проверяет, может ли приложение обслуживать запросы: база данных доступна, миграции
+// применены до ожидаемой версии, в каталоги файлов можно писать
+func (s Service) Readiness(ctx context.Context) domain.Readiness {
+	ctx, span := tracing.Start(ctx, "health.Readiness")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.ReadinessOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	readiness := domain.Readiness{
+		Checks: []domain.HealthCheck{
+			check("database", func() error {
+				return s.dao.Ping(ctx)
+			}),
+			check("migrations", func() error {
+				version, err := s.dao.MigrationVersion(ctx)
+				if err != nil {
+					return err
+				}
+
+				if version != s.cfg.MigrationVersion {
+					return fmt.Errorf("версия миграций %d, ожидается %d", version, s.cfg.MigrationVersion)
+				}
+
+				return nil
+			}),
+		},
+	}
+
+	for _, dir := range s.cfg.StorageDirs {
+		readiness.Checks = append(readiness.Checks, check("storage:"+dir, func() error {
+			return s.storage.Writable(dir)
+		}))
+	}
+
+	for _, c := range readiness.Checks {
+		if c.Err != nil {
+			l.Warn("проверка готовности не пройдена", zap.String("проверка", c.Name), zap.Error(c.Err))
+		}
+	}
+
+	return readiness
+}
+
+func check(name string, fn func() error) domain.HealthCheck {
+	now := time.Now()
+	err := fn()
+
+	return domain.HealthCheck{
+		Name:     name,
+		Err:      err,
+		Duration: time.Since(now),
+	}
+}
+
+// Version возвращает сведения о сборке и версию миграций базы данных. Недоступность базы
+// не считается ошибкой: версия сборки нужна и тогда, когда база не отвечает
+func (s Service) Version(ctx context.Context) domain.BuildInfo {
+	ctx, span := tracing.Start(ctx, "health.Version")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.VersionOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	build := buildinfo.Get()
+
+	info := domain.BuildInfo{
+		Commit:                   build.Commit,
+		BuildTime:                build.Time,
+		Modified:                 build.Modified,
+		GoVersion:                build.GoVersion,
+		ExpectedMigrationVersion: s.cfg.MigrationVersion,
+	}
+
+	version, err := s.dao.MigrationVersion(ctx)
+	if err != nil {
+		l.Warn("ошибка получения версии миграций", zap.Error(err))
+
+		return info
+	}
+
+	info.MigrationVersion = &version
+
+	return info
+}
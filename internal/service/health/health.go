@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type DAO interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int64, error)
+}
+
+type StorageChecker interface {
+	Writable(dir string) error
+}
+
+type Config struct {
+	// MigrationVersion версия последней миграции из каталога migrations, до которой должна быть обновлена база
+	MigrationVersion int64
+	// StorageDirs каталоги, в которые приложение пишет файлы
+	StorageDirs []string
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	dao     DAO
+	storage StorageChecker
+
+	cfg Config
+}
+
+func New(dao DAO, storage StorageChecker, cfg Config, logger *zap.Logger) Service {
+	return Service{
+		logger:  logger,
+		dao:     dao,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
@@ -0,0 +1,281 @@
+package issued_practice
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"strconv"
+	"strings"
+)
+
+const manifestName = "manifest.csv"
+
+// Submissions собирает список работ по заданию для выгрузки архивом.
+// Автор задания получает работы всех групп, остальные преподаватели - только групп, где они ведут дисциплину
+func (s Service) Submissions(ctx context.Context, req dto.EntityId) (domain.SubmissionArchive, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.Submissions")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DownloadSubmissionsArchive),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	practiceEntity, err := s.issuedPracticeDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.SubmissionArchive{}, ctxutils.Wrap(ctx, err, "нет практического задания с таким id")
+	}
+
+	groups, err := s.visibleGroups(ctx, l, accountId, practiceEntity)
+	if err != nil {
+		return domain.SubmissionArchive{}, err
+	}
+
+	solved, err := s.solvedPracticeDAO.ByIssuedPracticeId(ctx, practiceEntity.Id)
+	if err != nil {
+		l.Warn("ошибка получения работ по заданию", zap.Error(err))
+
+		return domain.SubmissionArchive{}, ctxutils.Wrap(ctx, err, "Не удалось получить работы по заданию")
+	}
+
+	practice, err := s.entityToDomain(ctx, practiceEntity)
+	if err != nil {
+		return domain.SubmissionArchive{}, ctxutils.Wrap(ctx, err, "ошибка получения автора задания")
+	}
+
+	persons := make(map[int]entity.Person)
+	versions := make(map[int]int)
+	submissions := make([]domain.Submission, 0, len(solved))
+
+	// Работы приходят в порядке сдачи, поэтому номер версии - порядковый номер работы студента
+	for _, work := range solved {
+		if !groups[work.GroupName] {
+			continue
+		}
+
+		person, ok := persons[work.PerformedAccountId]
+		if !ok {
+			person, err = s.personDAO.ByAccountId(ctx, work.PerformedAccountId)
+			if err != nil {
+				l.Warn("ошибка получения студента", zap.Int("id аккаунта", work.PerformedAccountId), zap.Error(err))
+
+				return domain.SubmissionArchive{}, ctxutils.Wrap(ctx, err, "Не удалось получить данные студента")
+			}
+
+			persons[work.PerformedAccountId] = person
+		}
+
+		versions[work.PerformedAccountId]++
+
+		submission := domain.Submission{
+			SolvedPracticeId: work.Id,
+			LastName:         person.LastName,
+			FirstName:        person.FirstName,
+			GroupName:        work.GroupName,
+			Version:          versions[work.PerformedAccountId],
+			Mark:             work.Mark,
+			Path:             work.Path,
+		}
+
+		if work.SolvedTime != nil {
+			submission.SolvedTime = *work.SolvedTime
+			submission.IsLate = practice.Deadline != nil && work.SolvedTime.After(*practice.Deadline)
+		}
+
+		submissions = append(submissions, submission)
+	}
+
+	l.Info("сформирован список работ для архива",
+		zap.Int("id задания", practice.Id),
+		zap.Int("кол-во", len(submissions)),
+	)
+
+	return domain.SubmissionArchive{
+		Practice:    practice,
+		Submissions: submissions,
+	}, nil
+}
+
+// WriteSubmissionsArchive пишет zip архив с работами в w по мере чтения файлов из хранилища,
+// не держа архив целиком в памяти. Работы раскладываются по папкам групп, в корне лежит manifest.csv
+func (s Service) WriteSubmissionsArchive(ctx context.Context, archive domain.SubmissionArchive, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "issued_practice.WriteSubmissionsArchive")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DownloadSubmissionsArchive),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	zw := zip.NewWriter(w)
+
+	names := make([]string, len(archive.Submissions))
+	taken := make(map[string]bool, len(archive.Submissions))
+
+	for i, submission := range archive.Submissions {
+		name := submissionName(submission)
+
+		// Однофамильцы в одной группе получают id работы в имени файла
+		if taken[name] {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(name, ext), submission.SolvedPracticeId, ext)
+		}
+
+		taken[name] = true
+		names[i] = name
+	}
+
+	err := writeManifest(zw, archive.Submissions, names)
+	if err != nil {
+		l.Warn("ошибка записи манифеста", zap.Error(err))
+		return err
+	}
+
+	for i, submission := range archive.Submissions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err = s.writeSubmission(ctx, zw, submission, names[i])
+
+		// Зараженная работа пропускается, чтобы не срывать выгрузку остальных; в манифесте она остается
+		if errors.Is(err, domain.ErrFileInfected) {
+			l.Warn("зараженная работа не добавлена в архив", zap.Int("id работы", submission.SolvedPracticeId))
+			continue
+		}
+
+		if err != nil {
+			l.Warn("ошибка записи работы в архив",
+				zap.Int("id работы", submission.SolvedPracticeId),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (s Service) writeSubmission(ctx context.Context, zw *zip.Writer, submission domain.Submission, name string) error {
+	f, err := s.fileStorage.Open(ctx, submission.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Документы docx уже сжаты, повторное сжатие только тратит процессор
+	dst, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Store,
+		Modified: submission.SolvedTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, f)
+	return err
+}
+
+func writeManifest(zw *zip.Writer, submissions []domain.Submission, names []string) error {
+	dst, err := zw.Create(manifestName)
+	if err != nil {
+		return err
+	}
+
+	// BOM нужен, чтобы Excel правильно открыл кириллицу
+	_, err = io.WriteString(dst, "\ufeff")
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(dst)
+	cw.Comma = ';'
+
+	err = cw.Write([]string{"файл", "группа", "фамилия", "имя", "версия", "время сдачи", "опоздание", "оценка"})
+	if err != nil {
+		return err
+	}
+
+	for i, submission := range submissions {
+		late := "нет"
+		if submission.IsLate {
+			late = "да"
+		}
+
+		var solvedTime string
+		if !submission.SolvedTime.IsZero() {
+			solvedTime = submission.SolvedTime.Format("2006-01-02 15:04:05")
+		}
+
+		// Оценка 0 означает, что работа еще не проверена
+		var mark string
+		if submission.Mark != 0 {
+			mark = strconv.Itoa(submission.Mark)
+		}
+
+		err = cw.Write([]string{
+			names[i],
+			submission.GroupName,
+			submission.LastName,
+			submission.FirstName,
+			strconv.Itoa(submission.Version),
+			solvedTime,
+			late,
+			mark,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// submissionName формирует путь работы в архиве вида Группа/Фамилия_Имя_vN.ext
+func submissionName(submission domain.Submission) string {
+	return fmt.Sprintf("%s/%s_%s_v%d%s",
+		safeName(submission.GroupName),
+		safeName(submission.LastName),
+		safeName(submission.FirstName),
+		submission.Version,
+		filepath.Ext(submission.Path),
+	)
+}
+
+// safeName убирает из части пути символы, которые ломают структуру архива
+func safeName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", " ", "_", "..", "_").Replace(strings.TrimSpace(name))
+}
+
+// visibleGroups возвращает группы задания, работы которых доступны аккаунту, или текст ошибки
+func (s Service) visibleGroups(ctx context.Context, l *zap.Logger, accountId int, practice entity.IssuedPractice) (map[string]bool, error) {
+	groups, err := s.mediator.TeacherGroups(ctx, accountId, practice)
+	if err != nil {
+		l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+		return nil, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+	}
+
+	if len(groups) == 0 {
+		return nil, apperr.Forbidden(domain.CodeNotAssigned)
+	}
+
+	return groups, nil
+}
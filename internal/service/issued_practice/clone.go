@@ -0,0 +1,185 @@
+package issued_practice
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// Clone выдает копию задания в новые группы и семестр. Файл задания не копируется,
+// клон ссылается на тот же путь в хранилище
+func (s Service) Clone(ctx context.Context, req dto.ClonePracticeReq) (domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.Clone")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CloneIssuedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	source, err := s.issuedPracticeDAO.ById(ctx, req.PracticeId)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Нет практического задания с таким id")
+	}
+
+	if source.DeletedAt != nil {
+		return domain.IssuedPractice{}, apperr.Conflict(domain.CodePracticeDeleted)
+	}
+
+	term, err := s.targetTerm(ctx, l, req.TermId)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	cloned, err := s.clone(ctx, l, accountId, source, term, req.TargetGroups, req.Deadline)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	return cloned, nil
+}
+
+// CloneDiscipline клонирует весь набор заданий дисциплины из одного семестра в другой
+func (s Service) CloneDiscipline(ctx context.Context, req dto.CloneDisciplineReq) ([]domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.CloneDiscipline")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CloneDisciplinePractices),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	if req.DisciplineId == 0 || req.FromTermId == 0 {
+		return nil, apperr.Validation(domain.CodeFieldsRequired).WithDetails(map[string]any{"fields": "discipline_id, from_term_id"})
+	}
+
+	term, err := s.targetTerm(ctx, l, req.TermId)
+	if err != nil {
+		return nil, err
+	}
+
+	if term.Id == req.FromTermId {
+		return nil, apperr.Validation(domain.CodeSameTerm)
+	}
+
+	sources, err := s.issuedPracticeDAO.ByDiscipline(ctx, req.DisciplineId, req.FromTermId)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения заданий дисциплины")
+	}
+
+	if len(sources) == 0 {
+		return nil, apperr.NotFound(domain.CodeNothingToClone)
+	}
+
+	// Назначение проверяется один раз для всего набора, чтобы не склонировать его частично
+	assigned, err := s.mediator.TeacherAssigned(ctx, accountId, req.DisciplineId, term.Name, req.TargetGroups)
+	if err != nil {
+		l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+
+		return nil, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+	}
+
+	if !assigned {
+		return nil, apperr.Forbidden(domain.CodeNotAssigned)
+	}
+
+	practices := make([]domain.IssuedPractice, 0, len(sources))
+
+	for _, source := range sources {
+		cloned, err := s.clone(ctx, l, accountId, source, term, req.TargetGroups, req.Deadline)
+		if err != nil {
+			return nil, ctxutils.Wrap(ctx, err, fmt.Sprintf("Ошибка клонирования задания %d: %s", source.Id, err))
+		}
+
+		practices = append(practices, cloned)
+	}
+
+	l.Info("задания дисциплины склонированы",
+		zap.Int("id дисциплины", req.DisciplineId),
+		zap.Int("id семестра", term.Id),
+		zap.Int("кол-во", len(practices)),
+	)
+
+	return practices, nil
+}
+
+// clone сохраняет копию задания от имени аккаунта. Возвращает склонированное задание или текст ошибки
+func (s Service) clone(ctx context.Context, l *zap.Logger, accountId int, source entity.IssuedPractice,
+	term entity.AcademicTerm, targetGroups []string, deadline *time.Time) (domain.IssuedPractice, error) {
+	if len(targetGroups) == 0 {
+		return domain.IssuedPractice{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "target_groups"})
+	}
+
+	// Задания без дисциплины выданы до появления назначений и не могут быть проверены
+	if source.DisciplineId == nil {
+		return domain.IssuedPractice{}, apperr.Validation(domain.CodePracticeNoDiscipline)
+	}
+
+	assigned, err := s.mediator.TeacherAssigned(ctx, accountId, *source.DisciplineId, term.Name, targetGroups)
+	if err != nil {
+		l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+	}
+
+	if !assigned {
+		return domain.IssuedPractice{}, apperr.Forbidden(domain.CodeNotAssigned)
+	}
+
+	var fileName string
+
+	if source.FileName != nil {
+		fileName = *source.FileName
+	}
+
+	saved, err := s.issuedPracticeDAO.Save(ctx, dto.NewIssuedPractice{
+		AccountId:      accountId,
+		TargetGroups:   targetGroups,
+		DisciplineId:   *source.DisciplineId,
+		AcademicTermId: term.Id,
+		Title:          source.Title,
+		Theme:          source.Theme,
+		Major:          source.Major,
+		Path:           source.Path,
+		FileName:       fileName,
+		UploadAt:       time.Now(),
+		Deadline:       deadline,
+		ClonedFrom:     &source.Id,
+	})
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить практическое задание")
+	}
+
+	// Копия ссылается на тот же файл, поэтому текст для поиска переносится без повторного извлечения
+	err = s.issuedPracticeDAO.CopyText(ctx, source.Id, saved.Id)
+	if err != nil {
+		l.Warn("ошибка копирования текста задания", zap.Int("id задания", saved.Id), zap.Error(err))
+	}
+
+	practice, err := s.entityToDomain(ctx, saved)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось получить данные пользователя")
+	}
+
+	l.Info("задание склонировано",
+		zap.Int("id исходного задания", source.Id),
+		zap.Int("id задания", practice.Id),
+		zap.Strings("целевые группы", targetGroups),
+	)
+
+	return practice, nil
+}
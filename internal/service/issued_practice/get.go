@@ -2,86 +2,133 @@ package issued_practice
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"go.uber.org/zap"
+	"path/filepath"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"strings"
+	"unicode"
 )
 
-type GetPracticeResult struct {
-	Practice domain.IssuedPractice
-	Error    error
-}
-
 func (s Service) ById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.ById")
+	defer span.End()
+
 	// необходимо проверить id, кто запрашивает
 	// если это студент и его целевая группа совпадает и id верен - отдаем ее,
 	// в ином случае, если доступ есть - отдаем по id
-	resCh := make(chan GetPracticeResult)
 
-	_ = s.logger.With(
+	_ = logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.GetIssuedPracticeInfoById),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		practiceEntity, err := s.issuedPracticeDAO.ById(ctx, req.Id)
-		if err != nil {
-			sendGetPracticeResult(resCh, domain.IssuedPractice{}, "нет практического задания с таким id")
-			return
-		}
+	practiceEntity, err := s.issuedPracticeDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "нет практического задания с таким id")
+	}
 
-		person, err := s.personDAO.ByAccountId(ctx, practiceEntity.AccountId)
-		if err != nil {
-			sendGetPracticeResult(resCh, domain.IssuedPractice{}, "ошибка получения автора задания")
-			return
-		}
+	practice, err := s.entityToDomain(ctx, practiceEntity)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "ошибка получения автора задания")
+	}
 
-		var isDeleted bool
+	return practice, nil
+}
 
-		if practiceEntity.DeletedAt != nil {
-			isDeleted = true
-		}
+// File открывает файл задания для выдачи. Файл, не прошедший антивирусную проверку, не выдается
+func (s Service) File(ctx context.Context, req dto.EntityId) (domain.File, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.File")
+	defer span.End()
 
-		practice := domain.IssuedPractice{
-			Id:           practiceEntity.Id,
-			AuthorName:   fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName),
-			AuthorId:     practiceEntity.AccountId,
-			TargetGroups: practiceEntity.TargetGroups,
-			Title:        practiceEntity.Title,
-			Theme:        practiceEntity.Theme,
-			Major:        practiceEntity.Major,
-			Path:         practiceEntity.Path,
-			UploadAt:     practiceEntity.UploadAt,
-			IsDeleted:    isDeleted,
-			DeletedAt:    practiceEntity.DeletedAt,
-		}
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DownloadIssuedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	practice, err := s.ById(ctx, req)
+	if err != nil {
+		return domain.File{}, err
+	}
 
-		sendGetPracticeResult(resCh, practice, "")
-		return
-	}()
+	file, err := s.fileStorage.File(ctx, practice.Path)
+	if err != nil {
+		l.Warn("ошибка открытия файла задания", zap.Int("id задания", practice.Id), zap.Error(err))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.IssuedPractice{}, ctx.Err()
-		case result := <-resCh:
-			return result.Practice, result.Error
+		if errors.Is(err, domain.ErrFileInfected) || errors.Is(err, domain.ErrFileNotScanned) {
+			return domain.File{}, err
 		}
+
+		return domain.File{}, ctxutils.Wrap(ctx, err, "не удалось найти файл")
 	}
+
+	// Файл выдается под именем, с которым его загрузили, а не под внутренним именем в хранилище
+	file.Name = downloadName(practice.FileName, practice.Title, file.Name)
+
+	return file, nil
 }
 
-func sendGetPracticeResult(resCh chan GetPracticeResult, practice domain.IssuedPractice, errMsg string) {
-	var err error
+// Preview открывает предпросмотр файла задания, если у аккаунта есть доступ к заданию
+func (s Service) Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.Preview")
+	defer span.End()
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.PreviewIssuedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	practice, err := s.ById(ctx, dto.EntityId{Id: req.Id})
+	if err != nil {
+		return domain.File{}, err
 	}
 
-	resCh <- GetPracticeResult{
-		Practice: practice,
-		Error:    err,
+	file, err := s.fileStorage.Preview(ctx, practice.Path, req.Kind)
+	if err != nil {
+		l.Warn("ошибка получения предпросмотра задания", zap.Int("id задания", practice.Id), zap.Error(err))
+
+		if errors.Is(err, domain.ErrFileInfected) || errors.Is(err, domain.ErrFileNotScanned) ||
+			errors.Is(err, domain.ErrPreviewUnsupported) || errors.Is(err, domain.ErrPreviewFailed) {
+			return domain.File{}, err
+		}
+
+		return domain.File{}, ctxutils.Wrap(ctx, err, "не удалось найти файл")
 	}
+
+	file.Name = downloadName(practice.FileName, practice.Title, file.Name)
+
+	return file, nil
+}
+
+// downloadName возвращает имя загруженного файла, а для заданий без него - название задания.
+// Расширение берется у отдаваемого файла, так как предпросмотр отдается в другом формате
+func downloadName(fileName, title, storedName string) string {
+	name := sanitizeName(strings.TrimSuffix(fileName, filepath.Ext(fileName)))
+	if name == "" {
+		name = sanitizeName(title)
+	}
+
+	if name == "" {
+		return storedName
+	}
+
+	return name + filepath.Ext(storedName)
+}
+
+// sanitizeName заменяет разделители пути и управляющие символы, чтобы имя нельзя было принять за путь
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '/', r == '\\', unicode.IsControl(r):
+			return '_'
+		default:
+			return r
+		}
+	}, strings.TrimSpace(name))
 }
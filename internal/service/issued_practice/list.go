@@ -0,0 +1,81 @@
+package issued_practice
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// ByParams возвращает практические задания семестра, по умолчанию - текущего.
+// Студент получает только задания своей группы
+func (s Service) ByParams(ctx context.Context, p params.IssuedPractice) ([]domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.ByParams")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetIssuedPracticeInfoByParams),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	if p.TermId == 0 {
+		term, err := s.termDAO.Current(ctx)
+		if err != nil {
+			l.Warn("ошибка получения текущего семестра", zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Не задан текущий семестр")
+		}
+
+		p.TermId = term.Id
+	}
+
+	// Ошибку проверки доступа не пробрасываем: у студента нет ни одного доступа,
+	// поэтому он просто получает ограниченный список
+	hasAccess, err := s.accountMediator.HasAccess(ctx, accountId, domain.IssuedPracticeObject, domain.GetAction)
+	if err != nil {
+		l.Debug("нет доступа к практическим заданиям", zap.Error(err))
+	}
+
+	if !hasAccess {
+		membership, err := s.groupDAO.CurrentByAccountId(ctx, accountId, time.Now())
+		if err != nil {
+			l.Warn("ошибка получения группы студента", zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка получения группы студента")
+		}
+
+		p.GroupName = membership.GroupName
+		p.AccountId = accountId
+	}
+
+	practicesEntity, err := s.issuedPracticeDAO.ByParams(ctx, p)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения практических заданий")
+	}
+
+	practices := make([]domain.IssuedPractice, 0, len(practicesEntity))
+
+	for _, practiceEntity := range practicesEntity {
+		practice, err := s.entityToDomain(ctx, practiceEntity)
+		if err != nil {
+			l.Warn("ошибка формирования практического задания",
+				zap.Int("id задания", practiceEntity.Id),
+				zap.Error(err),
+			)
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка формирования практических заданий")
+		}
+
+		practices = append(practices, practice)
+	}
+
+	return practices, nil
+}
@@ -2,25 +2,85 @@ package issued_practice
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go.uber.org/zap"
-	"mime/multipart"
+	"io"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"time"
 )
 
 type IssuedPracticeDAO interface {
 	Save(ctx context.Context, data dto.NewIssuedPractice) (entity.IssuedPractice, error)
 	ById(ctx context.Context, id int) (entity.IssuedPractice, error)
+	ByParams(ctx context.Context, p params.IssuedPractice) ([]entity.IssuedPractice, error)
+	ByDiscipline(ctx context.Context, disciplineId, termId int) ([]entity.IssuedPractice, error)
+
+	Update(ctx context.Context, practice entity.IssuedPracticeUpdate) (entity.IssuedPractice, error)
+	SoftDeleteById(ctx context.Context, id int, info dto.DeleteInfo) error
+	RestoreById(ctx context.Context, id int) error
+
+	SaveFileVersion(ctx context.Context, version entity.IssuedPracticeFileVersion) error
+	FileVersions(ctx context.Context, practiceId int) ([]entity.IssuedPracticeFileVersion, error)
+
+	SaveText(ctx context.Context, practiceId int, text string) error
+	CopyText(ctx context.Context, fromId, toId int) error
+	Search(ctx context.Context, p params.PracticeSearch) ([]entity.IssuedPracticeSearchHit, error)
+}
+
+type SolvedPracticeDAO interface {
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error)
+}
+
+type Notifier interface {
+	// Notify отправляет уведомление каждому из аккаунтов
+	Notify(ctx context.Context, accountIds []int, message string) error
+}
+
+type TermDAO interface {
+	ById(ctx context.Context, id int) (entity.AcademicTerm, error)
+	Current(ctx context.Context) (entity.AcademicTerm, error)
+}
+
+type GroupDAO interface {
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
 }
 
 type PracticeMediator interface {
 	// IssuedGroupMatch Проверяет, совпадает ли группа студента с одной из целевых груп практического задания
 	IssuedGroupMatch(ctx context.Context, accountId, practiceId int) (bool, error)
+	// TeacherAssigned Проверяет, что преподаватель ведет дисциплину во всех переданных группах
+	TeacherAssigned(ctx context.Context, accountId, disciplineId int, term string, groups []string) (bool, error)
+	// TermOpen Проверяет, что семестр практического задания не закрыт
+	TermOpen(ctx context.Context, practiceId int) (bool, error)
+	// TeacherGroups Возвращает целевые группы задания, с работами которых может работать аккаунт
+	TeacherGroups(ctx context.Context, accountId int, practice entity.IssuedPractice) (map[string]bool, error)
 }
 
 type PracticeFileStorage interface {
-	// SaveFile возвращает путь, по которому был сохранен файл
-	SaveFile(ctx context.Context, file *multipart.File, root, ext, name string) (string, error)
+	// SaveFile читает файл из потока и возвращает путь, размер и хэш сохраненного файла
+	SaveFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error)
+	// SaveUploadedFile сохраняет файл возобновляемой загрузки, для которого допустим больший размер
+	SaveUploadedFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error)
+	// Discard удаляет сохраненный файл и освобождает его место, если запись о нем не сохранилась
+	Discard(ctx context.Context, path string)
+	// Open открывает сохраненный файл на чтение
+	Open(ctx context.Context, path string) (io.ReadCloser, error)
+	// File открывает сохраненный файл для выдачи, если он прошел антивирусную проверку
+	File(ctx context.Context, path string) (domain.File, error)
+	// Preview открывает предпросмотр сохраненного файла в PDF или изображение его первой страницы
+	Preview(ctx context.Context, path, kind string) (domain.File, error)
+}
+
+// UsageTracker учитывает место, занятое файлами удаленных и восстановленных заданий
+type UsageTracker interface {
+	Release(ctx context.Context, paths []string) error
+	Restore(ctx context.Context, paths []string) error
 }
 
 type AccountMediator interface {
@@ -35,23 +95,159 @@ type Service struct {
 	logger *zap.Logger
 
 	issuedPracticeDAO IssuedPracticeDAO
+	solvedPracticeDAO SolvedPracticeDAO
 
 	personDAO PersonDAO
+	termDAO   TermDAO
+	groupDAO  GroupDAO
 
 	fileStorage PracticeFileStorage
+	notifier    Notifier
+	usage       UsageTracker
 
 	accountMediator AccountMediator
 	mediator        PracticeMediator
 }
 
-func New(issuedPracticeDAO IssuedPracticeDAO, personDAO PersonDAO, fileStorage PracticeFileStorage,
+func New(issuedPracticeDAO IssuedPracticeDAO, solvedPracticeDAO SolvedPracticeDAO, personDAO PersonDAO,
+	termDAO TermDAO, groupDAO GroupDAO, fileStorage PracticeFileStorage, notifier Notifier, usage UsageTracker,
 	accountMediator AccountMediator, practiceMediator PracticeMediator, logger *zap.Logger) Service {
 	return Service{
 		logger:            logger,
 		issuedPracticeDAO: issuedPracticeDAO,
+		solvedPracticeDAO: solvedPracticeDAO,
 		fileStorage:       fileStorage,
+		notifier:          notifier,
+		usage:             usage,
 		personDAO:         personDAO,
+		termDAO:           termDAO,
+		groupDAO:          groupDAO,
 		accountMediator:   accountMediator,
 		mediator:          practiceMediator,
 	}
 }
+
+// entityToDomain дополняет практическое задание ФИО автора
+func (s Service) entityToDomain(ctx context.Context, practice entity.IssuedPractice) (domain.IssuedPractice, error) {
+	person, err := s.personDAO.ByAccountId(ctx, practice.AccountId)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	var isDeleted bool
+
+	if practice.DeletedAt != nil {
+		isDeleted = true
+	}
+
+	var fileName string
+
+	if practice.FileName != nil {
+		fileName = *practice.FileName
+	}
+
+	return domain.IssuedPractice{
+		Id:             practice.Id,
+		AuthorName:     fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName),
+		AuthorId:       practice.AccountId,
+		TargetGroups:   practice.TargetGroups,
+		DisciplineId:   practice.DisciplineId,
+		AcademicTermId: practice.AcademicTermId,
+		Deadline:       practice.Deadline,
+		ClonedFrom:     practice.ClonedFrom,
+		Title:          practice.Title,
+		Theme:          practice.Theme,
+		Major:          practice.Major,
+		Path:           practice.Path,
+		FileName:       fileName,
+		UploadAt:       practice.UploadAt,
+		IsDeleted:      isDeleted,
+		DeletedAt:      practice.DeletedAt,
+	}, nil
+}
+
+// notifySolvers уведомляет студентов, которые уже сдали работу по заданию.
+// Ошибка уведомления не отменяет изменение задания, поэтому только логируется
+func (s Service) notifySolvers(ctx context.Context, l *zap.Logger, practiceId int, message string) {
+	solved, err := s.solvedPracticeDAO.ByIssuedPracticeId(ctx, practiceId)
+	if err != nil {
+		l.Warn("ошибка получения работ по заданию", zap.Int("id задания", practiceId), zap.Error(err))
+		return
+	}
+
+	accountIds := make([]int, 0, len(solved))
+
+	for _, practice := range solved {
+		accountIds = append(accountIds, practice.PerformedAccountId)
+	}
+
+	if len(accountIds) == 0 {
+		return
+	}
+
+	err = s.notifier.Notify(ctx, accountIds, message)
+	if err != nil {
+		l.Warn("ошибка уведомления студентов", zap.Int("id задания", practiceId), zap.Error(err))
+	}
+}
+
+// practicePaths возвращает пути текущего файла задания и всех его предыдущих версий
+func (s Service) practicePaths(ctx context.Context, practice entity.IssuedPractice) ([]string, error) {
+	versions, err := s.issuedPracticeDAO.FileVersions(ctx, practice.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(versions)+1)
+	paths = append(paths, practice.Path)
+
+	for _, version := range versions {
+		paths = append(paths, version.Path)
+	}
+
+	return paths, nil
+}
+
+// targetTerm возвращает семестр, в котором можно выдавать задания: указанный или текущий, если id не задан.
+// Закрытый семестр доступен только для чтения
+func (s Service) targetTerm(ctx context.Context, l *zap.Logger, termId int) (entity.AcademicTerm, error) {
+	var term entity.AcademicTerm
+	var err error
+
+	if termId == 0 {
+		term, err = s.termDAO.Current(ctx)
+	} else {
+		term, err = s.termDAO.ById(ctx, termId)
+	}
+
+	if err != nil {
+		l.Warn("ошибка получения семестра", zap.Int("id семестра", termId), zap.Error(err))
+
+		if termId == 0 {
+			return entity.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Не задан текущий семестр")
+		}
+
+		return entity.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Нет семестра с таким id")
+	}
+
+	if term.ClosedAt != nil {
+		return entity.AcademicTerm{}, apperr.Conflict(domain.CodeTermClosed)
+	}
+
+	return term, nil
+}
+
+// saveFileErrMsg возвращает сообщение об ошибке сохранения файла. Отказ антивируса, превышение квоты и размера
+// сообщаются отдельно, чтобы пользователь не пытался загрузить тот же файл повторно
+func saveFileErrMsg(err error, fallback string) string {
+	switch {
+	case errors.Is(err, domain.ErrFileInfected):
+		return "Файл заражен и помещен в карантин"
+	case errors.Is(err, domain.ErrFileNotScanned):
+		return "Не удалось проверить файл антивирусом"
+	case errors.Is(err, domain.ErrQuotaExceeded), errors.Is(err, domain.ErrFileTooLarge):
+		return err.Error()
+	default:
+		return fallback
+	}
+}
@@ -8,105 +8,99 @@ import (
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/storage"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/rndutils"
+	"practice_vgpek/pkg/tracing"
 	"strings"
 	"time"
 )
 
-type SavePracticeResult struct {
-	SavedPractice domain.IssuedPractice
-	Error         error
-}
-
 func (s Service) Save(ctx context.Context, req dto.NewIssuedPracticeReq) (domain.IssuedPractice, error) {
-	resCh := make(chan SavePracticeResult)
+	ctx, span := tracing.Start(ctx, "issued_practice.Save")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.UploadIssuedPracticeOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		accountId := ctx.Value("AccountId").(int)
-
-		// Формируем название, добавляем в конце набор случайных символов для уникальности
-		name := fmt.Sprintf("%s_%s", req.Title, rndutils.RandString(5))
-		name = strings.Replace(name, " ", "_", -1)
-
-		// Сохраняем файл практического задания
-		savedPath, err := s.fileStorage.SaveFile(ctx, req.File, "issued", ".docx", name)
-		if err != nil {
-			l.Warn("возникла ошибка при сохранении файла", zap.Error(err))
-
-			sendUploadPracticeResult(resCh, domain.IssuedPractice{}, "Не удалось сохранить файл")
-			return
-		}
-
-		data := dto.NewIssuedPractice{
-			AccountId:    accountId,
-			TargetGroups: req.TargetGroups,
-			Title:        req.Title,
-			Theme:        req.Theme,
-			Major:        req.Major,
-			Path:         savedPath,
-			UploadAt:     time.Now(),
-		}
-
-		savedPracticeData, err := s.issuedPracticeDAO.Save(ctx, data)
-		if err != nil {
-			sendUploadPracticeResult(resCh, domain.IssuedPractice{}, "Не удалось сохранить практическое задание")
-			return
-		}
-
-		person, err := s.personDAO.ByAccountId(ctx, savedPracticeData.AccountId)
-		if err != nil {
-			sendUploadPracticeResult(resCh, domain.IssuedPractice{}, "Не удалось получить данные пользователя")
-			return
-		}
-
-		var isDeleted bool
-
-		if savedPracticeData.DeletedAt != nil {
-			isDeleted = true
-		}
-
-		practice := domain.IssuedPractice{
-			Id:           savedPracticeData.Id,
-			AuthorName:   fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName),
-			AuthorId:     savedPracticeData.AccountId,
-			TargetGroups: savedPracticeData.TargetGroups,
-			Title:        savedPracticeData.Title,
-			Theme:        savedPracticeData.Theme,
-			Major:        savedPracticeData.Major,
-			Path:         savedPracticeData.Path,
-			UploadAt:     savedPracticeData.UploadAt,
-			IsDeleted:    isDeleted,
-			DeletedAt:    savedPracticeData.DeletedAt,
-		}
-
-		sendUploadPracticeResult(resCh, practice, "")
-		return
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.IssuedPractice{}, ctx.Err()
-		case result := <-resCh:
-			return result.SavedPractice, result.Error
-		}
+	accountId := ctx.Value("AccountId").(int)
+
+	if req.DisciplineId == 0 {
+		return domain.IssuedPractice{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "discipline_id"})
+	}
+
+	// Задания выдаются только в текущем, не закрытом семестре
+	term, err := s.targetTerm(ctx, l, 0)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	// Выдавать задания можно только в группы, в которых преподаватель ведет дисциплину в этом семестре
+	assigned, err := s.mediator.TeacherAssigned(ctx, accountId, req.DisciplineId, term.Name, req.TargetGroups)
+	if err != nil {
+		l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+	}
+
+	if !assigned {
+		l.Info("преподаватель не назначен на дисциплину в целевых группах",
+			zap.Int("id аккаунта", accountId),
+			zap.Int("id дисциплины", req.DisciplineId),
+			zap.Strings("целевые группы", req.TargetGroups),
+		)
+
+		return domain.IssuedPractice{}, apperr.Forbidden(domain.CodeNotAssigned)
+	}
+
+	// Формируем название, добавляем в конце набор случайных символов для уникальности
+	name := fmt.Sprintf("%s_%s", req.Title, rndutils.RandString(5))
+	name = strings.Replace(name, " ", "_", -1)
+
+	saveFile := s.fileStorage.SaveFile
+	if req.Resumable {
+		saveFile = s.fileStorage.SaveUploadedFile
 	}
-}
 
-func sendUploadPracticeResult(resCh chan SavePracticeResult, resp domain.IssuedPractice, errMsg string) {
-	var err error
+	// Сохраняем файл практического задания
+	saved, err := saveFile(ctx, req.File, storage.IssuedRoot, req.Ext, name)
+	if err != nil {
+		l.Warn("возникла ошибка при сохранении файла", zap.Error(err))
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, saveFileErrMsg(err, "Не удалось сохранить файл"))
 	}
 
-	resCh <- SavePracticeResult{
-		SavedPractice: resp,
-		Error:         err,
+	data := dto.NewIssuedPractice{
+		AccountId:      accountId,
+		DisciplineId:   req.DisciplineId,
+		AcademicTermId: term.Id,
+		TargetGroups:   req.TargetGroups,
+		Title:          req.Title,
+		Theme:          req.Theme,
+		Major:          req.Major,
+		Path:           saved.Path,
+		FileName:       req.FileName,
+		UploadAt:       time.Now(),
+		Deadline:       req.Deadline,
 	}
+
+	savedPracticeData, err := s.issuedPracticeDAO.Save(ctx, data)
+	if err != nil {
+		s.fileStorage.Discard(ctx, saved.Path)
+
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить практическое задание")
+	}
+
+	s.indexText(ctx, l, savedPracticeData.Id, savedPracticeData.Path)
+
+	practice, err := s.entityToDomain(ctx, savedPracticeData)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось получить данные пользователя")
+	}
+
+	return practice, nil
 }
@@ -0,0 +1,114 @@
+package issued_practice
+
+import (
+	"bytes"
+	"context"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/docutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"strings"
+	"time"
+)
+
+// Search ищет задания по названию, теме, специальности и тексту файла.
+// Студент находит только задания своей группы
+func (s Service) Search(ctx context.Context, p params.PracticeSearch) ([]domain.PracticeSearchHit, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.Search")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.SearchIssuedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	p.Query = strings.TrimSpace(p.Query)
+	if p.Query == "" {
+		return nil, apperr.Validation(domain.CodeEmptyQuery)
+	}
+
+	// Как и в списке заданий, ошибка проверки доступа означает студента
+	hasAccess, err := s.accountMediator.HasAccess(ctx, accountId, domain.IssuedPracticeObject, domain.GetAction)
+	if err != nil {
+		l.Debug("нет доступа к практическим заданиям", zap.Error(err))
+	}
+
+	if !hasAccess {
+		membership, err := s.groupDAO.CurrentByAccountId(ctx, accountId, time.Now())
+		if err != nil {
+			l.Warn("ошибка получения группы студента", zap.Error(err))
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка получения группы студента")
+		}
+
+		p.GroupName = membership.GroupName
+	}
+
+	hitsEntity, err := s.issuedPracticeDAO.Search(ctx, p)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка поиска практических заданий")
+	}
+
+	hits := make([]domain.PracticeSearchHit, 0, len(hitsEntity))
+
+	for _, hitEntity := range hitsEntity {
+		practice, err := s.entityToDomain(ctx, hitEntity.IssuedPractice)
+		if err != nil {
+			l.Warn("ошибка формирования практического задания",
+				zap.Int("id задания", hitEntity.Id),
+				zap.Error(err),
+			)
+
+			return nil, ctxutils.Wrap(ctx, err, "Ошибка формирования практических заданий")
+		}
+
+		hits = append(hits, domain.PracticeSearchHit{
+			Practice:      practice,
+			Rank:          hitEntity.Rank,
+			TitleHeadline: hitEntity.TitleHeadline,
+			Snippet:       hitEntity.Snippet,
+		})
+	}
+
+	return hits, nil
+}
+
+// indexText извлекает текст файла задания для полнотекстового поиска.
+// Задание без текста находится по названию, поэтому ошибка только логируется
+func (s Service) indexText(ctx context.Context, l *zap.Logger, practiceId int, path string) {
+	f, err := s.fileStorage.Open(ctx, path)
+	if err != nil {
+		l.Warn("ошибка открытия файла задания", zap.Int("id задания", practiceId), zap.Error(err))
+		return
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+
+	_, err = io.Copy(&buf, f)
+	if err != nil {
+		l.Warn("ошибка чтения файла задания", zap.Int("id задания", practiceId), zap.Error(err))
+		return
+	}
+
+	text, err := docutils.ExtractText(buf.Bytes(), filepath.Ext(path))
+	if err != nil {
+		l.Warn("ошибка извлечения текста задания", zap.Int("id задания", practiceId), zap.Error(err))
+		return
+	}
+
+	err = s.issuedPracticeDAO.SaveText(ctx, practiceId, text)
+	if err != nil {
+		l.Warn("ошибка сохранения текста задания", zap.Int("id задания", practiceId), zap.Error(err))
+	}
+}
@@ -0,0 +1,283 @@
+package issued_practice
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/storage"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/rndutils"
+	"practice_vgpek/pkg/tracing"
+	"strings"
+	"time"
+)
+
+func (s Service) Update(ctx context.Context, req dto.UpdateIssuedPracticeReq) (domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.Update")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.UpdateIssuedPracticeOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	practice, err := s.editable(ctx, l, accountId, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	if practice.DeletedAt != nil {
+		return domain.IssuedPractice{}, apperr.Conflict(domain.CodePracticeDeleted)
+	}
+
+	update := entity.IssuedPracticeUpdate{
+		Id:       req.Id,
+		Deadline: req.Deadline,
+		Title:    req.Title,
+		Theme:    req.Theme,
+		Major:    req.Major,
+	}
+
+	if req.TargetGroups != nil {
+		if len(req.TargetGroups) == 0 {
+			return domain.IssuedPractice{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "target_groups"})
+		}
+
+		// Новые группы должны входить в назначение преподавателя в семестре задания
+		if practice.DisciplineId != nil {
+			var term string
+
+			if practice.AcademicTermId != nil {
+				termEntity, err := s.termDAO.ById(ctx, *practice.AcademicTermId)
+				if err != nil {
+					return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка получения семестра задания")
+				}
+
+				term = termEntity.Name
+			}
+
+			assigned, err := s.mediator.TeacherAssigned(ctx, accountId, *practice.DisciplineId, term, req.TargetGroups)
+			if err != nil {
+				l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+
+				return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+			}
+
+			if !assigned {
+				return domain.IssuedPractice{}, apperr.Forbidden(domain.CodeNotAssigned)
+			}
+		}
+
+		update.TargetGroups = req.TargetGroups
+	}
+
+	if req.File != nil {
+		title := practice.Title
+		if req.Title != nil {
+			title = *req.Title
+		}
+
+		name := fmt.Sprintf("%s_%s", title, rndutils.RandString(5))
+		name = strings.Replace(name, " ", "_", -1)
+
+		saveFile := s.fileStorage.SaveFile
+		if req.Resumable {
+			saveFile = s.fileStorage.SaveUploadedFile
+		}
+
+		saved, err := saveFile(ctx, req.File, storage.IssuedRoot, req.Ext, name)
+		if err != nil {
+			l.Warn("возникла ошибка при сохранении файла", zap.Error(err))
+
+			return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, saveFileErrMsg(err, "Не удалось сохранить файл"))
+		}
+
+		// Старый файл не удаляется, а остается предыдущей версией задания
+		err = s.issuedPracticeDAO.SaveFileVersion(ctx, entity.IssuedPracticeFileVersion{
+			IssuedPracticeId: practice.Id,
+			Path:             practice.Path,
+			ReplacedBy:       accountId,
+			ReplacedAt:       time.Now(),
+		})
+		if err != nil {
+			s.fileStorage.Discard(ctx, saved.Path)
+
+			return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить предыдущую версию файла")
+		}
+
+		update.Path = &saved.Path
+		// Имя прежнего файла к новому не относится, поэтому перезаписывается даже пустым
+		update.FileName = &req.FileName
+	}
+
+	updated, err := s.issuedPracticeDAO.Update(ctx, update)
+	if err != nil {
+		if update.Path != nil {
+			s.fileStorage.Discard(ctx, *update.Path)
+		}
+
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось изменить практическое задание")
+	}
+
+	if update.Path != nil {
+		s.indexText(ctx, l, updated.Id, updated.Path)
+	}
+
+	s.notifySolvers(ctx, l, updated.Id,
+		fmt.Sprintf("Практическое задание «%s» было изменено преподавателем", updated.Title))
+
+	resp, err := s.entityToDomain(ctx, updated)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось получить данные пользователя")
+	}
+
+	l.Info("практическое задание изменено", zap.Int("id задания", resp.Id))
+
+	return resp, nil
+}
+
+func (s Service) DeleteById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.DeleteById")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DeleteIssuedPracticeOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	practice, err := s.editable(ctx, l, accountId, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	if practice.DeletedAt != nil {
+		return domain.IssuedPractice{}, apperr.Conflict(domain.CodePracticeDeleted)
+	}
+
+	err = s.issuedPracticeDAO.SoftDeleteById(ctx, req.Id, dto.DeleteInfo{DeleteTime: time.Now()})
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось удалить практическое задание")
+	}
+
+	// Ошибка учета места не отменяет удаление, поэтому только логируется
+	paths, err := s.practicePaths(ctx, practice)
+	if err == nil {
+		err = s.usage.Release(ctx, paths)
+	}
+
+	if err != nil {
+		l.Warn("ошибка освобождения места файлов задания", zap.Int("id задания", practice.Id), zap.Error(err))
+	}
+
+	s.notifySolvers(ctx, l, practice.Id,
+		fmt.Sprintf("Практическое задание «%s» было удалено преподавателем", practice.Title))
+
+	resp, err := s.reloaded(ctx, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	return resp, nil
+}
+
+func (s Service) RestoreById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error) {
+	ctx, span := tracing.Start(ctx, "issued_practice.RestoreById")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.RestoreIssuedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	practice, err := s.editable(ctx, l, accountId, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	if practice.DeletedAt == nil {
+		return domain.IssuedPractice{}, apperr.Conflict(domain.CodePracticeNotDeleted)
+	}
+
+	err = s.issuedPracticeDAO.RestoreById(ctx, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось восстановить практическое задание")
+	}
+
+	paths, err := s.practicePaths(ctx, practice)
+	if err == nil {
+		err = s.usage.Restore(ctx, paths)
+	}
+
+	if err != nil {
+		l.Warn("ошибка возврата в учет файлов задания", zap.Int("id задания", practice.Id), zap.Error(err))
+	}
+
+	s.notifySolvers(ctx, l, practice.Id,
+		fmt.Sprintf("Практическое задание «%s» было восстановлено преподавателем", practice.Title))
+
+	resp, err := s.reloaded(ctx, req.Id)
+	if err != nil {
+		return domain.IssuedPractice{}, err
+	}
+
+	return resp, nil
+}
+
+// editable проверяет, что задание может изменить аккаунт: он должен быть автором,
+// а семестр задания не должен быть закрыт. Возвращает задание или текст ошибки
+func (s Service) editable(ctx context.Context, l *zap.Logger, accountId, practiceId int) (entity.IssuedPractice, error) {
+	practice, err := s.issuedPracticeDAO.ById(ctx, practiceId)
+	if err != nil {
+		return entity.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Нет практического задания с таким id")
+	}
+
+	if practice.AccountId != accountId {
+		l.Info("попытка изменить чужое задание",
+			zap.Int("id аккаунта", accountId),
+			zap.Int("id задания", practiceId),
+		)
+
+		return entity.IssuedPractice{}, apperr.Forbidden(domain.CodeNotAuthor)
+	}
+
+	termOpen, err := s.mediator.TermOpen(ctx, practiceId)
+	if err != nil {
+		l.Warn("ошибка проверки семестра задания", zap.Error(err))
+
+		return entity.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка проверки семестра задания")
+	}
+
+	if !termOpen {
+		return entity.IssuedPractice{}, apperr.Conflict(domain.CodeTermClosed)
+	}
+
+	return practice, nil
+}
+
+// reloaded возвращает актуальное состояние задания после изменения
+func (s Service) reloaded(ctx context.Context, practiceId int) (domain.IssuedPractice, error) {
+	practiceEntity, err := s.issuedPracticeDAO.ById(ctx, practiceId)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка получения практического задания")
+	}
+
+	practice, err := s.entityToDomain(ctx, practiceEntity)
+	if err != nil {
+		return domain.IssuedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка получения автора задания")
+	}
+
+	return practice, nil
+}
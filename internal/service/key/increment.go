@@ -2,68 +2,62 @@ package key
 
 import (
 	"context"
-	"fmt"
 	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 )
 
-type IncrementResult struct {
-	Key   entity.Key
-	Error error
-}
-
 func (s Service) Increment(ctx context.Context, key entity.Key) (entity.Key, error) {
-	resCh := make(chan IncrementResult)
+	ctx, span := tracing.Start(ctx, "key.Increment")
+	defer span.End()
 
-	_ = s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.IncrementKey),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	current := key.CurrentCountUsages + 1
+	var incremented entity.Key
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Счетчик перечитывается в транзакции, чтобы параллельные регистрации не теряли использования ключа
+		current, err := s.keyDAO.ById(ctx, key.Id)
+		if err != nil {
+			return ctxutils.Wrap(ctx, err, "Ошибка получения ключа регистрации")
+		}
+
+		if !current.IsValid || current.CurrentCountUsages >= current.MaxCountUsages {
+			l.Warn("ключ исчерпан до завершения регистрации", zap.Int("id ключа", key.Id))
+			return apperr.Forbidden(domain.CodeRegKeyExhausted)
+		}
+
+		count := current.CurrentCountUsages + 1
 
-	go func() {
-		incremented, err := s.keyDAO.Update(ctx, entity.KeyUpdate{
+		incremented, err = s.keyDAO.Update(ctx, entity.KeyUpdate{
 			Id:                 key.Id,
 			RoleId:             nil,
 			Body:               nil,
 			MaxCountUsages:     nil,
-			CurrentCountUsages: &current,
+			CurrentCountUsages: &count,
 			CreatedAt:          nil,
 			IsValid:            nil,
 			InvalidationTime:   nil,
 			GroupName:          nil,
 		})
 		if err != nil {
-			sendIncrementResult(resCh, entity.Key{}, "Ошибка инкрементирования ключа")
-			return
+			return ctxutils.Wrap(ctx, err, "Ошибка инкрементирования ключа")
 		}
 
-		sendIncrementResult(resCh, incremented, "")
-		return
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return entity.Key{}, ctx.Err()
-		case result := <-resCh:
-			return result.Key, result.Error
-		}
+		return nil
+	})
+	if err != nil {
+		return entity.Key{}, err
 	}
-}
-
-func sendIncrementResult(resCh chan IncrementResult, resp entity.Key, errMsg string) {
-	var err error
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
-	}
-
-	resCh <- IncrementResult{
-		Key:   resp,
-		Error: err,
-	}
+	return incremented, nil
 }
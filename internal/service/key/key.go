@@ -19,16 +19,22 @@ type RoleDAO interface {
 	ById(ctx context.Context, id int) (entity.Role, error)
 }
 
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Service struct {
 	l       *zap.Logger
 	keyDAO  DAO
 	roleDAO RoleDAO
+	tx      TxManager
 }
 
-func New(kd DAO, rd RoleDAO, logger *zap.Logger) Service {
+func New(kd DAO, rd RoleDAO, tx TxManager, logger *zap.Logger) Service {
 	return Service{
 		l:       logger,
 		keyDAO:  kd,
 		roleDAO: rd,
+		tx:      tx,
 	}
 }
@@ -0,0 +1,140 @@
+package key
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/goleak"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/params"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowDAO отвечает с задержкой delay, но, как pgx, прерывает запрос при отмене контекста
+type slowDAO struct {
+	delay time.Duration
+}
+
+func (d slowDAO) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d.delay):
+		return nil
+	}
+}
+
+func (d slowDAO) Update(ctx context.Context, key entity.KeyUpdate) (entity.Key, error) {
+	return entity.Key{Id: key.Id, InvalidationTime: key.InvalidationTime}, d.wait(ctx)
+}
+
+func (d slowDAO) ById(ctx context.Context, id int) (entity.Key, error) {
+	return entity.Key{Id: id, RoleId: 1, MaxCountUsages: 10}, d.wait(ctx)
+}
+
+func (d slowDAO) ByParams(ctx context.Context, p params.Default) ([]entity.Key, error) {
+	return []entity.Key{{Id: 1, RoleId: 1}}, d.wait(ctx)
+}
+
+func (d slowDAO) Save(ctx context.Context, info dto.NewKeyInfo) (entity.Key, error) {
+	return entity.Key{Id: 1, RoleId: info.RoleId}, d.wait(ctx)
+}
+
+type slowRoleDAO struct {
+	slowDAO
+}
+
+func (d slowRoleDAO) ById(ctx context.Context, id int) (entity.Role, error) {
+	return entity.Role{Id: id, Name: "STUDENT"}, d.wait(ctx)
+}
+
+// txManager выполняет функцию без транзакции, как TxManager при отмене контекста не повторяя ее
+type txManager struct{}
+
+func (txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// TestNoGoroutineLeakOnTimeout вызывает методы сервиса под нагрузкой с короткими и уже истекшими
+// сроками. После возврата всех вызовов не должно остаться ни одной горутины, ждущей отправки результата
+func TestNoGoroutineLeakOnTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	dao := slowDAO{delay: 5 * time.Millisecond}
+	s := New(dao, slowRoleDAO{dao}, txManager{}, zap.NewNop())
+
+	calls := []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			_, err := s.NewKey(ctx, dto.NewKeyReq{RoleId: 1, MaxCountUsages: 5})
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := s.KeyById(ctx, dto.EntityId{Id: 1})
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := s.KeysByParams(ctx, params.State{})
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := s.InvalidateKey(ctx, dto.EntityId{Id: 1})
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := s.Increment(ctx, entity.Key{Id: 1})
+			return err
+		},
+	}
+
+	timeouts := []time.Duration{0, time.Millisecond, 3 * time.Millisecond, 50 * time.Millisecond}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 200; i++ {
+		for _, call := range calls {
+			timeout := timeouts[i%len(timeouts)]
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+
+				err := call(ctx)
+
+				// Истекший заранее срок должен вернуться ошибкой контекста, а не результатом или другой ошибкой
+				if timeout == 0 && !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("вызов с истекшим сроком вернул %v, ожидается context.DeadlineExceeded", err)
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+}
+
+func TestCanceledCallReturnsImmediately(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// DAO, который отвечал бы дольше срока теста, если бы не прерывался отменой
+	dao := slowDAO{delay: time.Minute}
+	s := New(dao, slowRoleDAO{dao}, txManager{}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	_, err := s.KeyById(ctx, dto.EntityId{Id: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ошибка %v, ожидается context.Canceled", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("вызов вернулся через %s после отмены", elapsed)
+	}
+}
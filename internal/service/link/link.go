@@ -0,0 +1,86 @@
+package link
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DownloadLinkDAO interface {
+	Save(ctx context.Context, data dto.NewDownloadLink) (entity.DownloadLink, error)
+	ById(ctx context.Context, id string) (entity.DownloadLink, error)
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+	Revoke(ctx context.Context, id string, by int, at time.Time) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AuditDAO interface {
+	Save(ctx context.Context, data dto.NewAuditLog) error
+}
+
+// Config настройки подписанных ссылок
+type Config struct {
+	// Secret ключ HMAC для подписи ссылок
+	Secret []byte
+	// TTL срок действия ссылки
+	TTL time.Duration
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	linkDAO  DownloadLinkDAO
+	auditDAO AuditDAO
+
+	cfg Config
+}
+
+func New(linkDAO DownloadLinkDAO, auditDAO AuditDAO, cfg Config, logger *zap.Logger) Service {
+	return Service{
+		logger:   logger,
+		linkDAO:  linkDAO,
+		auditDAO: auditDAO,
+		cfg:      cfg,
+	}
+}
+
+// sign подписывает все параметры ссылки, чтобы ее нельзя было перенести на другой файл или аккаунт
+func (s Service) sign(object string, resourceId, accountId int, expiresAt int64, linkId string, singleUse bool) string {
+	payload := strings.Join([]string{
+		object,
+		strconv.Itoa(resourceId),
+		strconv.Itoa(accountId),
+		strconv.FormatInt(expiresAt, 10),
+		linkId,
+		strconv.FormatBool(singleUse),
+	}, "|")
+
+	mac := hmac.New(sha256.New, s.cfg.Secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s Service) audit(ctx context.Context, accountId int, event, linkId, details string) {
+	err := s.auditDAO.Save(ctx, dto.NewAuditLog{
+		AccountId: accountId,
+		Event:     event,
+		Object:    linkId,
+		Details:   details,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Error("ошибка записи в журнал аудита",
+			zap.String("событие", event),
+			zap.String("id ссылки", linkId),
+			zap.Error(err),
+		)
+	}
+}
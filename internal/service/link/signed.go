@@ -0,0 +1,224 @@
+package link
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"net/url"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/rndutils"
+	"practice_vgpek/pkg/tracing"
+	"strconv"
+	"time"
+)
+
+// Параметры подписанной ссылки
+const (
+	idParam      = "id"
+	accountParam = "acc"
+	expiresParam = "exp"
+	linkParam    = "link"
+	onceParam    = "once"
+	sigParam     = "sig"
+)
+
+// Mint выпускает подписанную ссылку на файл для текущего аккаунта. Доступ к файлу
+// должен быть проверен до выпуска ссылки
+func (s Service) Mint(ctx context.Context, req dto.NewDownloadLinkReq) (domain.DownloadLink, error) {
+	ctx, span := tracing.Start(ctx, "link.Mint")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CreateDownloadLinkOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	// Время хранится с точностью до секунды, как и в подписи
+	expiresAt := time.Now().Add(s.cfg.TTL).Truncate(time.Second)
+
+	link, err := s.linkDAO.Save(ctx, dto.NewDownloadLink{
+		Id:         rndutils.RandString(32),
+		AccountId:  accountId,
+		Object:     req.Object,
+		ResourceId: req.ResourceId,
+		SingleUse:  req.SingleUse,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return domain.DownloadLink{}, err
+	}
+
+	query := url.Values{}
+	query.Set(idParam, strconv.Itoa(link.ResourceId))
+	query.Set(accountParam, strconv.Itoa(link.AccountId))
+	query.Set(expiresParam, strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set(linkParam, link.Id)
+	if link.SingleUse {
+		query.Set(onceParam, "1")
+	}
+	query.Set(sigParam, s.sign(link.Object, link.ResourceId, link.AccountId, expiresAt.Unix(), link.Id, link.SingleUse))
+
+	l.Info("выпущена ссылка на скачивание",
+		zap.String("id ссылки", link.Id),
+		zap.String("объект", link.Object),
+		zap.Int("id записи", link.ResourceId),
+		zap.Int("id аккаунта", link.AccountId),
+	)
+
+	return domain.DownloadLink{
+		Id:         link.Id,
+		AccountId:  link.AccountId,
+		Object:     link.Object,
+		ResourceId: link.ResourceId,
+		SingleUse:  link.SingleUse,
+		ExpiresAt:  expiresAt,
+		Query:      query.Encode(),
+	}, nil
+}
+
+// Redeem проверяет подписанную ссылку на файл объекта object. Ссылка действует только для того файла
+// и аккаунта, для которых выпущена, а одноразовая ссылка после проверки становится использованной
+func (s Service) Redeem(ctx context.Context, object string, query url.Values) (domain.DownloadLink, error) {
+	ctx, span := tracing.Start(ctx, "link.Redeem")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.RedeemDownloadLinkOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	linkId := query.Get(linkParam)
+	singleUse := query.Get(onceParam) == "1"
+
+	resourceId, idErr := strconv.Atoi(query.Get(idParam))
+	accountId, accErr := strconv.Atoi(query.Get(accountParam))
+	expiresAt, expErr := strconv.ParseInt(query.Get(expiresParam), 10, 64)
+
+	if linkId == "" || errors.Join(idErr, accErr, expErr) != nil ||
+		!hmac.Equal([]byte(query.Get(sigParam)), []byte(s.sign(object, resourceId, accountId, expiresAt, linkId, singleUse))) {
+		l.Warn("недействительная подпись ссылки", zap.String("id ссылки", linkId))
+		s.audit(ctx, 0, domain.AuditLinkInvalid, linkId, "неверная подпись")
+		return domain.DownloadLink{}, domain.ErrLinkInvalid
+	}
+
+	now := time.Now()
+
+	if now.Unix() > expiresAt {
+		s.audit(ctx, accountId, domain.AuditLinkExpired, linkId, fmt.Sprintf("%s %d", object, resourceId))
+		return domain.DownloadLink{}, domain.ErrLinkExpired
+	}
+
+	link, err := s.linkDAO.ById(ctx, linkId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.audit(ctx, accountId, domain.AuditLinkInvalid, linkId, "ссылка не найдена")
+		return domain.DownloadLink{}, domain.ErrLinkInvalid
+	}
+	if err != nil {
+		return domain.DownloadLink{}, err
+	}
+
+	if link.RevokedAt != nil {
+		s.audit(ctx, accountId, domain.AuditLinkRevokedUse, linkId, fmt.Sprintf("%s %d", object, resourceId))
+		return domain.DownloadLink{}, domain.ErrLinkRevoked
+	}
+
+	if link.SingleUse {
+		err = s.linkDAO.MarkUsed(ctx, link.Id, now)
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.audit(ctx, accountId, domain.AuditLinkReused, linkId, fmt.Sprintf("%s %d", object, resourceId))
+			return domain.DownloadLink{}, domain.ErrLinkUsed
+		}
+		if err != nil {
+			return domain.DownloadLink{}, err
+		}
+	}
+
+	return domain.DownloadLink{
+		Id:         link.Id,
+		AccountId:  link.AccountId,
+		Object:     link.Object,
+		ResourceId: link.ResourceId,
+		SingleUse:  link.SingleUse,
+		ExpiresAt:  link.ExpiresAt,
+	}, nil
+}
+
+// Revoke отзывает ссылку текущего аккаунта до истечения срока ее действия.
+// Чужая ссылка не отличается от несуществующей
+func (s Service) Revoke(ctx context.Context, req dto.LinkId) error {
+	ctx, span := tracing.Start(ctx, "link.Revoke")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.RevokeDownloadLinkOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+		zap.String("id ссылки", req.Id),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	link, err := s.linkDAO.ById(ctx, req.Id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && link.AccountId != accountId) {
+		return apperr.NotFound(domain.CodeLinkInvalid)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.linkDAO.Revoke(ctx, link.Id, accountId, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperr.Conflict(domain.CodeLinkRevoked)
+	}
+	if err != nil {
+		return err
+	}
+
+	l.Info("ссылка на скачивание отозвана", zap.Int("id аккаунта", accountId))
+	s.audit(ctx, accountId, domain.AuditLinkRevoked, link.Id, fmt.Sprintf("%s %d", link.Object, link.ResourceId))
+
+	return nil
+}
+
+// Run удаляет истекшие ссылки до отмены контекста
+func (s Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		s.clean(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s Service) clean(ctx context.Context) {
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CleanDownloadLinksOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	// Истечение проверяется по подписанному времени, поэтому удаленная запись не мешает отличить истекшую ссылку
+	count, err := s.linkDAO.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		l.Warn("ошибка удаления истекших ссылок", zap.Error(err))
+		return
+	}
+
+	if count != 0 {
+		l.Info("удалены истекшие ссылки", zap.Int64("кол-во", count))
+	}
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+type NotificationDAO interface {
+	Save(ctx context.Context, data dto.NewNotification) error
+	ByAccountId(ctx context.Context, accountId int, p params.Default) ([]entity.Notification, error)
+	MarkRead(ctx context.Context, id, accountId int, at time.Time) error
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	notificationDAO NotificationDAO
+}
+
+func New(notificationDAO NotificationDAO, logger *zap.Logger) Service {
+	return Service{
+		logger:          logger,
+		notificationDAO: notificationDAO,
+	}
+}
+
+// Notify отправляет одно и то же уведомление каждому из аккаунтов,
+// повторяющиеся аккаунты получают уведомление один раз
+func (s Service) Notify(ctx context.Context, accountIds []int, message string) error {
+	ctx, span := tracing.Start(ctx, "notification.Notify")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.NotifyStudentsOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	notified := make(map[int]bool, len(accountIds))
+
+	for _, accountId := range accountIds {
+		if notified[accountId] {
+			continue
+		}
+
+		err := s.notificationDAO.Save(ctx, dto.NewNotification{AccountId: accountId, Message: message})
+		if err != nil {
+			l.Warn("ошибка сохранения уведомления", zap.Int("id аккаунта", accountId), zap.Error(err))
+			return err
+		}
+
+		notified[accountId] = true
+	}
+
+	l.Info("уведомления отправлены", zap.Int("кол-во", len(notified)))
+
+	return nil
+}
+
+func (s Service) NotificationsByAccountId(ctx context.Context, req dto.EntityId, p params.Default) ([]domain.Notification, error) {
+	ctx, span := tracing.Start(ctx, "notification.NotificationsByAccountId")
+	defer span.End()
+
+	notificationsEntity, err := s.notificationDAO.ByAccountId(ctx, req.Id, p)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения уведомлений")
+	}
+
+	notifications := make([]domain.Notification, 0, len(notificationsEntity))
+
+	for _, notification := range notificationsEntity {
+		notifications = append(notifications, domain.Notification{
+			Id:        notification.Id,
+			AccountId: notification.AccountId,
+			Message:   notification.Message,
+			CreatedAt: notification.CreatedAt,
+			IsRead:    notification.ReadAt != nil,
+			ReadAt:    notification.ReadAt,
+		})
+	}
+
+	return notifications, nil
+}
+
+// MarkRead отмечает уведомление прочитанным, чужие уведомления не изменяются
+func (s Service) MarkRead(ctx context.Context, req dto.EntityId) error {
+	ctx, span := tracing.Start(ctx, "notification.MarkRead")
+	defer span.End()
+
+	accountId := ctx.Value("AccountId").(int)
+
+	err := s.notificationDAO.MarkRead(ctx, req.Id, accountId, time.Now())
+	if err != nil {
+		return ctxutils.Wrap(ctx, err, "Ошибка отметки уведомления")
+	}
+
+	return nil
+}
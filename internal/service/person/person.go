@@ -40,16 +40,25 @@ type AccountDAO interface {
 	Save(ctx context.Context, data dto.AccountRegistrationData) (entity.Account, error)
 	ById(ctx context.Context, id int) (entity.Account, error)
 	ByParams(ctx context.Context, p params.Default) ([]entity.Account, error)
-	HardDeleteById(ctx context.Context, id int) error
+}
+
+type GroupDAO interface {
+	Save(ctx context.Context, data dto.NewMembership) (entity.AccountGroup, error)
+}
+
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Service struct {
 	logger *zap.Logger
+	tx     TxManager
 
 	keyDAO      KeyDAO
 	personDAO   PersonDAO
 	accountDAO  AccountDAO
 	roleDAO     RoleDAO
+	groupDAO    GroupDAO
 	roleService RoleService
 
 	permDAO PermDAO
@@ -57,14 +66,16 @@ type Service struct {
 	keyService KeyService
 }
 
-func New(roleService RoleService, permDAO PermDAO, kd KeyDAO, pd PersonDAO, ad AccountDAO, rd RoleDAO, keyService KeyService, logger *zap.Logger) Service {
+func New(roleService RoleService, permDAO PermDAO, kd KeyDAO, pd PersonDAO, ad AccountDAO, rd RoleDAO, gd GroupDAO, keyService KeyService, tx TxManager, logger *zap.Logger) Service {
 	return Service{
 		logger: logger,
+		tx:     tx,
 
 		keyDAO:     kd,
 		personDAO:  pd,
 		accountDAO: ad,
 		roleDAO:    rd,
+		groupDAO:   gd,
 		permDAO:    permDAO,
 
 		roleService: roleService,
@@ -2,66 +2,69 @@ package person
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/password"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
-type NewUserResult struct {
-	User  domain.Person
-	Error error
-}
+var errKeyExhausted = errors.New("превышено кол-во регистраций по ключу")
 
 func (s Service) NewUser(ctx context.Context, registration dto.RegistrationReq) (domain.Person, error) {
-	resCh := make(chan NewUserResult)
+	ctx, span := tracing.Start(ctx, "person.NewUser")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.RegistrationOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
+	pHash, err := password.Hash(registration.Password)
+	if err != nil {
+		l.Warn("ошибка хеширования пароля", zap.Error(err))
+		return domain.Person{}, ctxutils.Wrap(ctx, err, "Ошибка хеширования пароля")
+	}
+
+	var (
+		key           entity.Key
+		accountEntity entity.Account
+		personEntity  entity.Person
+		roleEntity    entity.Role
+	)
+
+	// Аккаунт, пользователь, группа и использование ключа сохраняются в одной транзакции,
+	// чтобы сбой посреди регистрации не оставлял аккаунтов без пользователя и неучтенных регистраций
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
 		// Получаем ключ по указанному телу
-		key, err := s.keyDAO.ByBody(ctx, registration.BodyKey)
+		key, err = s.keyDAO.ByBody(ctx, registration.BodyKey)
 		if err != nil {
-			sendRegistrationResult(resCh, domain.Person{}, "Ошибка получения ключа регистрации")
-			return
+			return ctxutils.Wrap(ctx, err, "Ошибка получения ключа регистрации")
 		}
 
 		// Проверяем, валиден ли он
 		if !key.IsValid {
 			l.Warn("попытка зарегистрироваться по невалидному ключу", zap.Int("id ключа", key.Id))
-			sendRegistrationResult(resCh, domain.Person{}, "Невалидный ключ регистрации")
-			return
+			return apperr.Forbidden(domain.CodeRegKeyInvalid)
 		}
 
-		// Если текущее кол-во регистраций больше или равно допустимому - инвалидируем
+		// Если текущее кол-во регистраций больше или равно допустимому - ключ инвалидируется после отката транзакции
 		if key.CurrentCountUsages >= key.MaxCountUsages {
 			l.Warn("превышено кол-во попыток регистрации по ключу", zap.Int("id ключа", key.Id))
-			_, err = s.keyService.InvalidateKey(ctx, dto.EntityId{Id: key.Id})
-			if err != nil {
-				sendRegistrationResult(resCh, domain.Person{}, "Ошибка инвалидирования ключа регистрации")
-				return
-			}
-			sendRegistrationResult(resCh, domain.Person{}, "Превышено кол-во регистраций по ключу")
-			return
-		}
-
-		pHash, err := password.Hash(registration.Password)
-		if err != nil {
-			l.Warn("ошибка хеширования пароля", zap.Error(err))
-			sendRegistrationResult(resCh, domain.Person{}, "Ошибка хеширования пароля")
-			return
+			return errKeyExhausted
 		}
 
 		// Сохраняем сущность Аккаунт
-		accountEntity, err := s.accountDAO.Save(ctx, dto.AccountRegistrationData{
+		accountEntity, err = s.accountDAO.Save(ctx, dto.AccountRegistrationData{
 			Login:        registration.Login,
 			PasswordHash: pHash,
 			CreatedAt:    time.Now(),
@@ -69,12 +72,11 @@ func (s Service) NewUser(ctx context.Context, registration dto.RegistrationReq)
 			KeyId:        key.Id,
 		})
 		if err != nil {
-			sendRegistrationResult(resCh, domain.Person{}, "Ошибка создания пользователя")
-			return
+			return ctxutils.Wrap(ctx, err, "Ошибка создания пользователя")
 		}
 
 		// Сохраняем сущность Пользователь
-		personEntity, err := s.personDAO.Save(ctx, dto.PersonRegistrationData{
+		personEntity, err = s.personDAO.Save(ctx, dto.PersonRegistrationData{
 			UUID:       uuid.New(),
 			FirstName:  registration.FirstName,
 			SecondName: registration.SecondName,
@@ -82,67 +84,60 @@ func (s Service) NewUser(ctx context.Context, registration dto.RegistrationReq)
 			AccountId:  accountEntity.Id,
 		})
 		if err != nil {
-			accErr := s.accountDAO.HardDeleteById(ctx, accountEntity.Id)
-			if accErr != nil {
-				l.Warn("ошибка удаления аккаунта", zap.Error(accErr))
-			}
-			sendRegistrationResult(resCh, domain.Person{}, "Ошибка создания пользователя")
-			return
+			return ctxutils.Wrap(ctx, err, "Ошибка создания пользователя")
 		}
 
-		// Если ключ хороший и регистрация успешная, увеличиваем кол-во регистраций по нему
-		_, err = s.keyService.Increment(ctx, key)
+		// Первая группа аккаунта - группа, зашитая в ключ регистрации
+		_, err = s.groupDAO.Save(ctx, dto.NewMembership{
+			AccountId:     accountEntity.Id,
+			GroupName:     key.GroupName,
+			EffectiveFrom: accountEntity.CreatedAt,
+			Reason:        domain.RegistrationReason,
+		})
 		if err != nil {
-			sendRegistrationResult(resCh, domain.Person{}, err.Error())
-			return
+			l.Warn("ошибка сохранения группы аккаунта", zap.Int("id аккаунта", accountEntity.Id), zap.Error(err))
+			return ctxutils.Wrap(ctx, err, "Ошибка создания пользователя")
 		}
 
-		roleEntity, err := s.roleDAO.ById(ctx, accountEntity.RoleId)
+		// Если ключ хороший и регистрация успешная, увеличиваем кол-во регистраций по нему
+		_, err = s.keyService.Increment(ctx, key)
 		if err != nil {
-			sendRegistrationResult(resCh, domain.Person{}, "Ошибка получения роли пользователя")
-			return
+			return err
 		}
 
-		person := domain.Person{
-			UUID:       personEntity.UUID,
-			FirstName:  personEntity.FirstName,
-			MiddleName: personEntity.MiddleName,
-			LastName:   personEntity.LastName,
-			Account: domain.Account{
-				Login:          accountEntity.Login,
-				IsActive:       accountEntity.IsActive,
-				DeactivateTime: accountEntity.DeactivateTime,
-				RoleName:       roleEntity.Name,
-				RoleId:         roleEntity.Id,
-				KeyId:          key.Id,
-				CreatedAt:      accountEntity.CreatedAt,
-			},
+		roleEntity, err = s.roleDAO.ById(ctx, accountEntity.RoleId)
+		if err != nil {
+			return ctxutils.Wrap(ctx, err, "Ошибка получения роли пользователя")
 		}
 
-		sendRegistrationResult(resCh, person, "")
-		return
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Person{}, ctx.Err()
-		case result := <-resCh:
-			return result.User, result.Error
+		return nil
+	})
+	if errors.Is(err, errKeyExhausted) {
+		_, err = s.keyService.InvalidateKey(ctx, dto.EntityId{Id: key.Id})
+		if err != nil {
+			return domain.Person{}, ctxutils.Wrap(ctx, err, "Ошибка инвалидирования ключа регистрации")
 		}
+		return domain.Person{}, apperr.Forbidden(domain.CodeRegKeyExhausted)
 	}
-
-}
-
-func sendRegistrationResult(resCh chan NewUserResult, resp domain.Person, errMsg string) {
-	var err error
-
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+	if err != nil {
+		return domain.Person{}, err
 	}
 
-	resCh <- NewUserResult{
-		User:  resp,
-		Error: err,
+	person := domain.Person{
+		UUID:       personEntity.UUID,
+		FirstName:  personEntity.FirstName,
+		MiddleName: personEntity.MiddleName,
+		LastName:   personEntity.LastName,
+		Account: domain.Account{
+			Login:          accountEntity.Login,
+			IsActive:       accountEntity.IsActive,
+			DeactivateTime: accountEntity.DeactivateTime,
+			RoleName:       roleEntity.Name,
+			RoleId:         roleEntity.Id,
+			KeyId:          key.Id,
+			CreatedAt:      accountEntity.CreatedAt,
+		},
 	}
+
+	return person, nil
 }
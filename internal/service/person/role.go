@@ -2,110 +2,81 @@ package person
 
 import (
 	"context"
-	"fmt"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 )
 
-type PermResult struct {
-	Perm  domain.RolePermission
-	Error error
-}
-
 func (s Service) PermByAccountId(ctx context.Context, req dto.EntityId) (domain.RolePermission, error) {
-	resCh := make(chan PermResult)
+	ctx, span := tracing.Start(ctx, "person.PermByAccountId")
+	defer span.End()
 
-	_ = s.logger.With(
+	_ = logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.GetPermByAccountIdOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		role, err := s.roleService.RoleById(ctx, req)
-		if err != nil {
-			sendGetPermResult(resCh, domain.RolePermission{}, "ошибка получения роли")
-			return
-		}
-
-		perms, err := s.permDAO.ByRoleId(ctx, role.ID)
-		if err != nil {
-			sendGetPermResult(resCh, domain.RolePermission{}, "ошибка получения доступов")
-			return
-		}
-
-		var rolePerm domain.RolePermission
-
-		domainRole := domain.Role{
-			ID:          role.ID,
-			Name:        role.Name,
-			Description: role.Description,
-			CreatedAt:   role.CreatedAt,
-			IsDeleted:   role.IsDeleted,
-			DeletedAt:   role.DeletedAt,
-		}
+	role, err := s.roleService.RoleById(ctx, req)
+	if err != nil {
+		return domain.RolePermission{}, ctxutils.Wrap(ctx, err, "ошибка получения роли")
+	}
 
-		rolePerm.Role = domainRole
+	perms, err := s.permDAO.ByRoleId(ctx, role.ID)
+	if err != nil {
+		return domain.RolePermission{}, ctxutils.Wrap(ctx, err, "ошибка получения доступов")
+	}
 
-		for _, perm := range perms {
-			var actionIsDeleted, objectIsDeleted bool
+	var rolePerm domain.RolePermission
 
-			if perm.Action.IsDeleted != nil {
-				actionIsDeleted = true
-			}
-			if perm.Action.IsDeleted != nil {
-				objectIsDeleted = true
-			}
+	domainRole := domain.Role{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		IsDeleted:   role.IsDeleted,
+		DeletedAt:   role.DeletedAt,
+	}
 
-			domainAction := domain.Action{
-				ID:          perm.Action.Id,
-				Name:        perm.Action.Name,
-				Description: perm.Action.Description,
-				CreatedAt:   perm.Action.CreatedAt,
-				IsDeleted:   actionIsDeleted,
-				DeletedAt:   perm.Action.IsDeleted,
-			}
+	rolePerm.Role = domainRole
 
-			domainObject := domain.Object{
-				ID:          perm.Object.Id,
-				Name:        perm.Object.Name,
-				Description: perm.Object.Description,
-				CreatedAt:   perm.Object.CreatedAt,
-				IsDeleted:   objectIsDeleted,
-				DeletedAt:   perm.Object.IsDeleted,
-			}
+	for _, perm := range perms {
+		var actionIsDeleted, objectIsDeleted bool
 
-			rolePerm.Object = domain.ObjectWithActions{
-				Object:  domainObject,
-				Actions: append(rolePerm.Object.Actions, domainAction),
-			}
+		if perm.Action.IsDeleted != nil {
+			actionIsDeleted = true
+		}
+		if perm.Action.IsDeleted != nil {
+			objectIsDeleted = true
 		}
 
-		sendGetPermResult(resCh, rolePerm, "")
-		return
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.RolePermission{}, ctx.Err()
-		case result := <-resCh:
-			return result.Perm, result.Error
+		domainAction := domain.Action{
+			ID:          perm.Action.Id,
+			Name:        perm.Action.Name,
+			Description: perm.Action.Description,
+			CreatedAt:   perm.Action.CreatedAt,
+			IsDeleted:   actionIsDeleted,
+			DeletedAt:   perm.Action.IsDeleted,
 		}
-	}
-}
 
-func sendGetPermResult(ch chan PermResult, perm domain.RolePermission, errMsg string) {
-	var err error
+		domainObject := domain.Object{
+			ID:          perm.Object.Id,
+			Name:        perm.Object.Name,
+			Description: perm.Object.Description,
+			CreatedAt:   perm.Object.CreatedAt,
+			IsDeleted:   objectIsDeleted,
+			DeletedAt:   perm.Object.IsDeleted,
+		}
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+		rolePerm.Object = domain.ObjectWithActions{
+			Object:  domainObject,
+			Actions: append(rolePerm.Object.Actions, domainAction),
+		}
 	}
 
-	ch <- PermResult{
-		Perm:  perm,
-		Error: err,
-	}
+	return rolePerm, nil
 }
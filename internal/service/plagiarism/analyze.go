@@ -0,0 +1,191 @@
+package plagiarism
+
+import (
+	"bytes"
+	"context"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/docutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/textutils"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// document текст работы, подготовленный для сравнения
+type document struct {
+	solved entity.SolvedPractice
+
+	words     []textutils.Word
+	shingles  []uint64
+	signature textutils.Signature
+}
+
+// Enqueue ставит задание в очередь на сравнение работ. При переполненной очереди задание пропускается,
+// работы будут сравнены при следующей сдаче или повторном запросе
+func (s Service) Enqueue(issuedPracticeId int) bool {
+	select {
+	case s.queue <- issuedPracticeId:
+		return true
+	default:
+		s.logger.Warn("очередь сравнения работ переполнена",
+			zap.String(operation.Operation, operation.AnalyzeSimilarityOperation),
+			zap.String(layer.Layer, layer.ServiceLayer),
+			zap.Int("id задания", issuedPracticeId),
+		)
+		return false
+	}
+}
+
+// Run обрабатывает очередь сравнения работ до отмены контекста
+func (s Service) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case practiceId := <-s.queue:
+			analyzeCtx, cancel := context.WithTimeout(ctx, analyzeTimeout)
+
+			// Ошибка уже залогирована, задание будет сравнено при следующей сдаче
+			_ = s.Analyze(analyzeCtx, practiceId)
+
+			cancel()
+		}
+	}
+}
+
+// Analyze сравнивает последние версии работ всех студентов по заданию попарно и сохраняет результат
+func (s Service) Analyze(ctx context.Context, issuedPracticeId int) error {
+	ctx, span := tracing.Start(ctx, "plagiarism.Analyze")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AnalyzeSimilarityOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+		zap.Int("id задания", issuedPracticeId),
+	)
+
+	solved, err := s.solvedPracticeDAO.ByIssuedPracticeId(ctx, issuedPracticeId)
+	if err != nil {
+		l.Warn("ошибка получения работ по заданию", zap.Error(err))
+		return err
+	}
+
+	// Работы идут в порядке сдачи, сравнивается последняя версия работы каждого студента
+	latest := make(map[int]int, len(solved))
+	order := make([]int, 0, len(solved))
+
+	for i, work := range solved {
+		if _, ok := latest[work.PerformedAccountId]; !ok {
+			order = append(order, work.PerformedAccountId)
+		}
+
+		latest[work.PerformedAccountId] = i
+	}
+
+	documents := make([]document, 0, len(order))
+
+	for _, accountId := range order {
+		work := solved[latest[accountId]]
+
+		doc, err := s.document(ctx, work)
+		if err != nil {
+			// Работа, которую не удалось прочитать, сравнивается только по хэшу
+			l.Warn("ошибка извлечения текста работы", zap.Int("id работы", work.Id), zap.Error(err))
+		}
+
+		documents = append(documents, doc)
+	}
+
+	now := time.Now()
+	pairs := make([]dto.NewSimilarity, 0)
+
+	for i := 0; i < len(documents); i++ {
+		for j := i + 1; j < len(documents); j++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			first, second := documents[i], documents[j]
+
+			pair := dto.NewSimilarity{
+				IssuedPracticeId: issuedPracticeId,
+				FirstSolvedId:    first.solved.Id,
+				SecondSolvedId:   second.solved.Id,
+				ComputedAt:       now,
+			}
+
+			if first.solved.ContentHash != nil && second.solved.ContentHash != nil &&
+				*first.solved.ContentHash == *second.solved.ContentHash {
+				pair.Score = 1
+				pair.IsIdentical = true
+				pair.Fragments = []string{}
+
+				pairs = append(pairs, pair)
+				continue
+			}
+
+			// MinHash отсекает явно непохожие пары, для остальных считается точная оценка
+			if first.signature.Similarity(second.signature) < storeScore {
+				continue
+			}
+
+			pair.Score = textutils.Jaccard(first.shingles, second.shingles)
+			if pair.Score < storeScore {
+				continue
+			}
+
+			pair.Fragments = textutils.CommonFragments(first.words, first.shingles, second.shingles,
+				shingleSize, fragmentsLimit, fragmentMaxLen)
+
+			pairs = append(pairs, pair)
+		}
+	}
+
+	err = s.similarityDAO.ReplaceForPractice(ctx, issuedPracticeId, pairs)
+	if err != nil {
+		l.Warn("ошибка сохранения результатов сравнения", zap.Error(err))
+		return err
+	}
+
+	l.Info("работы по заданию сравнены",
+		zap.Int("кол-во работ", len(documents)),
+		zap.Int("похожих пар", len(pairs)),
+	)
+
+	return nil
+}
+
+// document читает файл работы и строит MinHash подпись его текста
+func (s Service) document(ctx context.Context, solved entity.SolvedPractice) (document, error) {
+	doc := document{solved: solved}
+
+	f, err := s.fileStorage.Open(ctx, solved.Path)
+	if err != nil {
+		return doc, err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+
+	_, err = io.Copy(&buf, f)
+	if err != nil {
+		return doc, err
+	}
+
+	text, err := docutils.ExtractText(buf.Bytes(), filepath.Ext(solved.Path))
+	if err != nil {
+		return doc, err
+	}
+
+	doc.words = textutils.Words(text)
+	doc.shingles = textutils.Shingles(doc.words, shingleSize)
+	doc.signature = textutils.MinHash(doc.shingles, signatureSize)
+
+	return doc, nil
+}
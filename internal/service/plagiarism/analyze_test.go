@@ -0,0 +1,58 @@
+package plagiarism
+
+import (
+	"context"
+	"go.uber.org/goleak"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/entity"
+	"testing"
+	"time"
+)
+
+// blockingDAO сообщает о начале запроса и, как pgx, держит его до отмены контекста
+type blockingDAO struct {
+	started chan int
+}
+
+func (d blockingDAO) ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error) {
+	d.started <- issuedPracticeId
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// TestRunStopsOnCancel отменяет контекст, пока идет сравнение работ. Run должен прервать сравнение
+// и вернуться, не оставив горутин
+func TestRunStopsOnCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	dao := blockingDAO{started: make(chan int, 1)}
+	s := New(dao, nil, nil, nil, nil, nil, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	if !s.Enqueue(1) {
+		t.Fatal("задание не поставлено в очередь")
+	}
+
+	select {
+	case <-dao.started:
+	case <-time.After(time.Second):
+		t.Fatal("сравнение работ не началось")
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run не вернулся после отмены контекста")
+	}
+}
@@ -0,0 +1,81 @@
+package plagiarism
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"io"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"time"
+)
+
+const (
+	// shingleSize длина шингла в словах
+	shingleSize = 5
+	// signatureSize количество хэш-функций MinHash подписи
+	signatureSize = 128
+	// storeScore пары с меньшей оценкой схожести не сохраняются
+	storeScore = 0.3
+
+	fragmentsLimit  = 5
+	fragmentMaxLen  = 300
+	queueSize       = 100
+	analyzeTimeout  = 5 * time.Minute
+	defaultMinScore = 0.5
+)
+
+type SolvedPracticeDAO interface {
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error)
+}
+
+type IssuedPracticeDAO interface {
+	ById(ctx context.Context, id int) (entity.IssuedPractice, error)
+}
+
+type SimilarityDAO interface {
+	ReplaceForPractice(ctx context.Context, issuedPracticeId int, pairs []dto.NewSimilarity) error
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int, minScore float64) ([]entity.Similarity, error)
+}
+
+type PersonDAO interface {
+	ByAccountId(ctx context.Context, accountId int) (entity.Person, error)
+}
+
+type PracticeMediator interface {
+	// TeacherGroups Возвращает целевые группы задания, с работами которых может работать аккаунт
+	TeacherGroups(ctx context.Context, accountId int, practice entity.IssuedPractice) (map[string]bool, error)
+}
+
+type FileStorage interface {
+	// Open открывает сохраненный файл на чтение
+	Open(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	solvedPracticeDAO SolvedPracticeDAO
+	issuedPracticeDAO IssuedPracticeDAO
+	similarityDAO     SimilarityDAO
+	personDAO         PersonDAO
+
+	mediator    PracticeMediator
+	fileStorage FileStorage
+
+	// queue id заданий, ожидающих сравнения работ
+	queue chan int
+}
+
+func New(solvedPracticeDAO SolvedPracticeDAO, issuedPracticeDAO IssuedPracticeDAO, similarityDAO SimilarityDAO,
+	personDAO PersonDAO, mediator PracticeMediator, fileStorage FileStorage, logger *zap.Logger) Service {
+	return Service{
+		logger:            logger,
+		solvedPracticeDAO: solvedPracticeDAO,
+		issuedPracticeDAO: issuedPracticeDAO,
+		similarityDAO:     similarityDAO,
+		personDAO:         personDAO,
+		mediator:          mediator,
+		fileStorage:       fileStorage,
+		queue:             make(chan int, queueSize),
+	}
+}
@@ -0,0 +1,160 @@
+package plagiarism
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+// Report возвращает подозрительные пары работ по заданию. Автор задания видит все пары,
+// остальные преподаватели - пары, в которых хотя бы одна работа из группы, где они ведут дисциплину
+func (s Service) Report(ctx context.Context, req dto.SimilarityReportReq) ([]domain.SimilarityPair, error) {
+	ctx, span := tracing.Start(ctx, "plagiarism.Report")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetSimilarityReport),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	minScore := req.MinScore
+	if minScore <= 0 {
+		minScore = defaultMinScore
+	}
+
+	practice, err := s.issuedPracticeDAO.ById(ctx, req.IssuedPracticeId)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "нет практического задания с таким id")
+	}
+
+	visible, err := s.visibleGroups(ctx, l, accountId, practice)
+	if err != nil {
+		return nil, err
+	}
+
+	similarities, err := s.similarityDAO.ByIssuedPracticeId(ctx, practice.Id, minScore)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "ошибка получения результатов сравнения")
+	}
+
+	solved, err := s.solvedPracticeDAO.ByIssuedPracticeId(ctx, practice.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "ошибка получения работ по заданию")
+	}
+
+	works := make(map[int]entity.SolvedPractice, len(solved))
+	for _, work := range solved {
+		works[work.Id] = work
+	}
+
+	names := make(map[int]string)
+	pairs := make([]domain.SimilarityPair, 0, len(similarities))
+
+	for _, similarity := range similarities {
+		first, okFirst := works[similarity.FirstSolvedId]
+		second, okSecond := works[similarity.SecondSolvedId]
+
+		// Удаленные после сравнения работы в отчет не попадают
+		if !okFirst || !okSecond {
+			continue
+		}
+
+		if !visible[first.GroupName] && !visible[second.GroupName] {
+			continue
+		}
+
+		firstName, err := s.studentName(ctx, names, first.PerformedAccountId)
+		if err != nil {
+			return nil, ctxutils.Wrap(ctx, err, "ошибка получения студента")
+		}
+
+		secondName, err := s.studentName(ctx, names, second.PerformedAccountId)
+		if err != nil {
+			return nil, ctxutils.Wrap(ctx, err, "ошибка получения студента")
+		}
+
+		pairs = append(pairs, domain.SimilarityPair{
+			FirstSolvedId:   first.Id,
+			FirstStudent:    firstName,
+			FirstGroupName:  first.GroupName,
+			SecondSolvedId:  second.Id,
+			SecondStudent:   secondName,
+			SecondGroupName: second.GroupName,
+			Score:           similarity.Score,
+			IsIdentical:     similarity.IsIdentical,
+			Fragments:       similarity.Fragments,
+			ComputedAt:      similarity.ComputedAt,
+		})
+	}
+
+	return pairs, nil
+}
+
+// Reanalyze ставит задание в очередь на повторное сравнение работ после проверки доступа к нему
+func (s Service) Reanalyze(ctx context.Context, req dto.EntityId) error {
+	ctx, span := tracing.Start(ctx, "plagiarism.Reanalyze")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AnalyzeSimilarityOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	practice, err := s.issuedPracticeDAO.ById(ctx, req.Id)
+	if err != nil {
+		return ctxutils.Wrap(ctx, err, "нет практического задания с таким id")
+	}
+
+	_, err = s.visibleGroups(ctx, l, ctx.Value("AccountId").(int), practice)
+	if err != nil {
+		return err
+	}
+
+	if !s.Enqueue(practice.Id) {
+		return apperr.Unavailable(domain.CodeQueueFull)
+	}
+
+	return nil
+}
+
+func (s Service) studentName(ctx context.Context, names map[int]string, accountId int) (string, error) {
+	if name, ok := names[accountId]; ok {
+		return name, nil
+	}
+
+	person, err := s.personDAO.ByAccountId(ctx, accountId)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName)
+	names[accountId] = name
+
+	return name, nil
+}
+
+// visibleGroups возвращает группы задания, работы которых доступны аккаунту, или текст ошибки
+func (s Service) visibleGroups(ctx context.Context, l *zap.Logger, accountId int, practice entity.IssuedPractice) (map[string]bool, error) {
+	groups, err := s.mediator.TeacherGroups(ctx, accountId, practice)
+	if err != nil {
+		l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+		return nil, ctxutils.Wrap(ctx, err, "Не удалось проверить назначение преподавателя")
+	}
+
+	if len(groups) == 0 {
+		return nil, apperr.Forbidden(domain.CodeNotAssigned)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,163 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+// maxTopConsumers ограничивает размер отчета о крупнейших потребителях
+const maxTopConsumers = 100
+
+func (s Service) SetQuota(ctx context.Context, req dto.SetQuotaReq) (domain.StorageQuota, error) {
+	ctx, span := tracing.Start(ctx, "quota.SetQuota")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.SetQuotaOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if (req.RoleId == 0) == (req.GroupName == "") {
+		return domain.StorageQuota{}, apperr.Validation(domain.CodeQuotaTarget)
+	}
+
+	if req.LimitBytes <= 0 {
+		return domain.StorageQuota{}, apperr.Validation(domain.CodeValueNotPositive).WithDetails(map[string]any{"field": "limit_bytes"})
+	}
+
+	saved, err := s.quotaDAO.SaveQuota(ctx, req)
+	if err != nil {
+		return domain.StorageQuota{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить квоту, возможно, такой роли нет")
+	}
+
+	l.Info("квота установлена",
+		zap.Int("id роли", req.RoleId),
+		zap.String("группа", req.GroupName),
+		zap.Int64("лимит", req.LimitBytes),
+	)
+
+	return quotaEntityToDomain(saved), nil
+}
+
+func (s Service) Quotas(ctx context.Context) ([]domain.StorageQuota, error) {
+	ctx, span := tracing.Start(ctx, "quota.Quotas")
+	defer span.End()
+
+	quotasEntity, err := s.quotaDAO.Quotas(ctx)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения квот")
+	}
+
+	quotas := make([]domain.StorageQuota, 0, len(quotasEntity))
+
+	for _, quota := range quotasEntity {
+		quotas = append(quotas, quotaEntityToDomain(quota))
+	}
+
+	return quotas, nil
+}
+
+func (s Service) DeleteQuota(ctx context.Context, req dto.EntityId) error {
+	ctx, span := tracing.Start(ctx, "quota.DeleteQuota")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DeleteQuotaOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	err := s.quotaDAO.DeleteQuota(ctx, req.Id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperr.NotFound(apperr.CodeNotFound)
+	}
+
+	if err != nil {
+		return ctxutils.Wrap(ctx, err, "Не удалось удалить квоту")
+	}
+
+	l.Info("квота снята", zap.Int("id квоты", req.Id))
+
+	return nil
+}
+
+// TopConsumers возвращает аккаунты или группы, занимающие больше всего места
+func (s Service) TopConsumers(ctx context.Context, req dto.TopConsumersReq) ([]domain.StorageUsage, error) {
+	ctx, span := tracing.Start(ctx, "quota.TopConsumers")
+	defer span.End()
+
+	if req.By != domain.UsageByAccount && req.By != domain.UsageByGroup {
+		return nil, apperr.Validation(domain.CodeValueNotAllowed).WithDetails(map[string]any{"field": "by", "allowed": "account, group"})
+	}
+
+	if req.Limit <= 0 || req.Limit > maxTopConsumers {
+		req.Limit = maxTopConsumers
+	}
+
+	usageEntity, err := s.quotaDAO.Top(ctx, req.By, req.Limit)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения занятого места")
+	}
+
+	usage := make([]domain.StorageUsage, 0, len(usageEntity))
+
+	for _, u := range usageEntity {
+		usage = append(usage, domain.StorageUsage{
+			AccountId: u.AccountId,
+			Login:     u.Login,
+			GroupName: u.GroupName,
+			UsedBytes: u.UsedBytes,
+			FileCount: u.FileCount,
+		})
+	}
+
+	return usage, nil
+}
+
+// Usage возвращает занятое аккаунтом и его группой место вместе с действующими квотами
+func (s Service) Usage(ctx context.Context, req dto.EntityId) (domain.AccountStorage, error) {
+	ctx, span := tracing.Start(ctx, "quota.Usage")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetUsageOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	lim, err := s.limits(ctx, req.Id)
+	if err != nil {
+		l.Warn("ошибка получения квот аккаунта", zap.Int("id аккаунта", req.Id), zap.Error(err))
+
+		return domain.AccountStorage{}, ctxutils.Wrap(ctx, err, "Ошибка получения квот")
+	}
+
+	used, err := s.quotaDAO.AccountUsage(ctx, req.Id)
+	if err != nil {
+		return domain.AccountStorage{}, ctxutils.Wrap(ctx, err, "Ошибка получения занятого места")
+	}
+
+	storage := domain.AccountStorage{
+		UsedBytes:       used,
+		LimitBytes:      lim.account,
+		GroupName:       lim.groupName,
+		GroupLimitBytes: lim.group,
+	}
+
+	if lim.groupName != "" {
+		storage.GroupUsedBytes, err = s.quotaDAO.GroupUsage(ctx, lim.groupName)
+		if err != nil {
+			return domain.AccountStorage{}, ctxutils.Wrap(ctx, err, "Ошибка получения занятого места")
+		}
+	}
+
+	return storage, nil
+}
@@ -0,0 +1,159 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/sizeutils"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+// limits действующие ограничения аккаунта. nil - ограничения нет
+type limits struct {
+	account *int64
+
+	groupName string
+	group     *int64
+}
+
+// Check проверяет, что файл размера size помещается и в квоту роли аккаунта, и в квоту его группы.
+// Превышение возвращается как domain.ErrQuotaExceeded с описанием для пользователя
+func (s Service) Check(ctx context.Context, accountId int, size int64) error {
+	ctx, span := tracing.Start(ctx, "quota.Check")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CheckQuotaOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	lim, err := s.limits(ctx, accountId)
+	if err != nil {
+		l.Warn("ошибка получения квот аккаунта", zap.Int("id аккаунта", accountId), zap.Error(err))
+		return err
+	}
+
+	if lim.account != nil {
+		used, err := s.quotaDAO.AccountUsage(ctx, accountId)
+		if err != nil {
+			return err
+		}
+
+		if used+size > *lim.account {
+			l.Info("превышена квота аккаунта",
+				zap.Int("id аккаунта", accountId),
+				zap.Int64("занято", used),
+				zap.Int64("размер файла", size),
+			)
+
+			return fmt.Errorf("%w: занято %s из %s, файл %s", domain.ErrQuotaExceeded,
+				sizeutils.Format(used), sizeutils.Format(*lim.account), sizeutils.Format(size))
+		}
+	}
+
+	if lim.group != nil {
+		used, err := s.quotaDAO.GroupUsage(ctx, lim.groupName)
+		if err != nil {
+			return err
+		}
+
+		if used+size > *lim.group {
+			l.Info("превышена квота группы",
+				zap.String("группа", lim.groupName),
+				zap.Int64("занято", used),
+				zap.Int64("размер файла", size),
+			)
+
+			return fmt.Errorf("%w группы %s: занято %s из %s, файл %s", domain.ErrQuotaExceeded,
+				lim.groupName, sizeutils.Format(used), sizeutils.Format(*lim.group), sizeutils.Format(size))
+		}
+	}
+
+	return nil
+}
+
+// Track учитывает сохраненный файл в занятом месте аккаунта и его текущей группы
+func (s Service) Track(ctx context.Context, accountId int, path string, size int64) error {
+	ctx, span := tracing.Start(ctx, "quota.Track")
+	defer span.End()
+
+	groupName, err := s.currentGroup(ctx, accountId)
+	if err != nil {
+		return err
+	}
+
+	return s.quotaDAO.SaveFile(ctx, dto.NewStoredFile{
+		Path:      path,
+		AccountId: accountId,
+		GroupName: groupName,
+		Size:      size,
+	})
+}
+
+// Release освобождает место файлов, например при удалении задания
+func (s Service) Release(ctx context.Context, paths []string) error {
+	ctx, span := tracing.Start(ctx, "quota.Release")
+	defer span.End()
+
+	return s.quotaDAO.Release(ctx, paths, time.Now())
+}
+
+// Restore возвращает файлы в учет, например при восстановлении задания
+func (s Service) Restore(ctx context.Context, paths []string) error {
+	ctx, span := tracing.Start(ctx, "quota.Restore")
+	defer span.End()
+
+	return s.quotaDAO.Restore(ctx, paths)
+}
+
+func (s Service) limits(ctx context.Context, accountId int) (limits, error) {
+	var lim limits
+
+	account, err := s.accountDAO.ById(ctx, accountId)
+	if err != nil {
+		return lim, err
+	}
+
+	roleQuota, err := s.quotaDAO.QuotaByRole(ctx, account.RoleId)
+	if err == nil {
+		lim.account = &roleQuota.LimitBytes
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return lim, err
+	}
+
+	lim.groupName, err = s.currentGroup(ctx, accountId)
+	if err != nil || lim.groupName == "" {
+		return lim, err
+	}
+
+	groupQuota, err := s.quotaDAO.QuotaByGroup(ctx, lim.groupName)
+	if err == nil {
+		lim.group = &groupQuota.LimitBytes
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return lim, err
+	}
+
+	return lim, nil
+}
+
+// currentGroup возвращает текущую группу аккаунта, пустую строку - если аккаунт не состоит в группе
+func (s Service) currentGroup(ctx context.Context, accountId int) (string, error) {
+	membership, err := s.groupDAO.CurrentByAccountId(ctx, accountId, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return membership.GroupName, nil
+}
@@ -0,0 +1,61 @@
+package quota
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"time"
+)
+
+type QuotaDAO interface {
+	SaveFile(ctx context.Context, data dto.NewStoredFile) error
+	Release(ctx context.Context, paths []string, at time.Time) error
+	Restore(ctx context.Context, paths []string) error
+
+	AccountUsage(ctx context.Context, accountId int) (int64, error)
+	GroupUsage(ctx context.Context, groupName string) (int64, error)
+	Top(ctx context.Context, by string, limit int) ([]entity.StorageUsage, error)
+
+	SaveQuota(ctx context.Context, data dto.SetQuotaReq) (entity.StorageQuota, error)
+	QuotaByRole(ctx context.Context, roleId int) (entity.StorageQuota, error)
+	QuotaByGroup(ctx context.Context, groupName string) (entity.StorageQuota, error)
+	Quotas(ctx context.Context) ([]entity.StorageQuota, error)
+	DeleteQuota(ctx context.Context, id int) error
+}
+
+type AccountDAO interface {
+	ById(ctx context.Context, id int) (entity.Account, error)
+}
+
+type GroupDAO interface {
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	quotaDAO   QuotaDAO
+	accountDAO AccountDAO
+	groupDAO   GroupDAO
+}
+
+func New(quotaDAO QuotaDAO, accountDAO AccountDAO, groupDAO GroupDAO, logger *zap.Logger) Service {
+	return Service{
+		logger:     logger,
+		quotaDAO:   quotaDAO,
+		accountDAO: accountDAO,
+		groupDAO:   groupDAO,
+	}
+}
+
+func quotaEntityToDomain(quota entity.StorageQuota) domain.StorageQuota {
+	return domain.StorageQuota{
+		Id:         quota.Id,
+		RoleId:     quota.RoleId,
+		GroupName:  quota.GroupName,
+		LimitBytes: quota.LimitBytes,
+		UpdatedAt:  quota.UpdatedAt,
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
@@ -20,222 +24,165 @@ type ObjectDAO interface {
 }
 
 func (s RBACService) NewObject(ctx context.Context, req dto.NewRBACReq) (domain.Object, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.NewObject")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.AddObjectOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		// Проверяем что объект вообще введен
-		if req.Name == "" {
-			l.Warn("Пустой добавляемый объект")
+	// Проверяем что объект вообще введен
+	if req.Name == "" {
+		l.Warn("Пустой добавляемый объект")
 
-			sendPartResult(resCh, domain.Object{}, "Пустой добавляемый объект")
-			return
-		}
-
-		part := dto.NewRBACPart{
-			Name:        req.Name,
-			Description: req.Description,
-		}
-
-		added, err := s.objectDAO.Save(ctx, part)
-		if err != nil {
-			sendPartResult(resCh, domain.Object{}, "Неизвестная ошибка сохранения объекта действия")
-			return
-		}
+		return domain.Object{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "name"})
+	}
 
-		object := domain.Object{
-			ID:          added.Id,
-			Name:        added.Name,
-			Description: added.Description,
-			CreatedAt:   added.CreatedAt,
-			IsDeleted:   false,
-			DeletedAt:   nil,
-		}
+	part := dto.NewRBACPart{
+		Name:        req.Name,
+		Description: req.Description,
+	}
 
-		sendPartResult(resCh, object, "")
-		return
-	}()
+	added, err := s.objectDAO.Save(ctx, part)
+	if err != nil {
+		return domain.Object{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка сохранения объекта действия")
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Object{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Object(result.part.Part()), result.error
-		}
+	object := domain.Object{
+		ID:          added.Id,
+		Name:        added.Name,
+		Description: added.Description,
+		CreatedAt:   added.CreatedAt,
+		IsDeleted:   false,
+		DeletedAt:   nil,
 	}
+
+	return object, nil
 }
 
 func (s RBACService) ObjectById(ctx context.Context, req dto.EntityId) (domain.Object, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.ObjectById")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.GetObjectOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		objectEntity, err := s.objectDAO.ById(ctx, req.Id)
-		if err != nil {
-			sendPartResult(resCh, domain.Object{}, "Неизвестная ошибка получения объекта")
-			return
-		}
-
-		object := domain.Object{
-			ID:          objectEntity.Id,
-			Name:        objectEntity.Name,
-			Description: objectEntity.Description,
-			CreatedAt:   objectEntity.CreatedAt,
-			IsDeleted:   false,
-			DeletedAt:   nil,
-		}
+	objectEntity, err := s.objectDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.Object{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка получения объекта")
+	}
 
-		l.Info("получение объекта по id",
-			zap.Int("id объекта", object.ID),
-			zap.Time("время создания", object.CreatedAt),
-			zap.Bool("удалено", object.IsDeleted),
-		)
+	object := domain.Object{
+		ID:          objectEntity.Id,
+		Name:        objectEntity.Name,
+		Description: objectEntity.Description,
+		CreatedAt:   objectEntity.CreatedAt,
+		IsDeleted:   false,
+		DeletedAt:   nil,
+	}
 
-		sendPartResult(resCh, object, "")
-		return
-	}()
+	l.Info("получение объекта по id",
+		zap.Int("id объекта", object.ID),
+		zap.Time("время создания", object.CreatedAt),
+		zap.Bool("удалено", object.IsDeleted),
+	)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Object{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Object(result.part.Part()), result.error
-		}
-	}
+	return object, nil
 }
 
 func (s RBACService) DeleteObjectById(ctx context.Context, req dto.EntityId) (domain.Object, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.DeleteObjectById")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.SoftDeleteObjectById),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		info := dto.DeleteInfo{DeleteTime: time.Now()}
-
-		err := s.objectDAO.SoftDeleteById(ctx, req.Id, info)
-		if err != nil {
-			l.Warn("ошибка мягкого удаления объекта",
-				zap.Int("id роли", req.Id),
-				zap.Time("время удаления", info.DeleteTime),
-			)
+	info := dto.DeleteInfo{DeleteTime: time.Now()}
 
-			sendPartResult(resCh, domain.Role{}, "Неизвестная ошибка удаления роли")
-			return
-		}
-
-		deletedObjectEntity, err := s.objectDAO.ById(ctx, req.Id)
-		if err != nil {
-			l.Warn("ошибка получения удаленной роли", zap.Int("id роли", req.Id))
+	err := s.objectDAO.SoftDeleteById(ctx, req.Id, info)
+	if err != nil {
+		l.Warn("ошибка мягкого удаления объекта",
+			zap.Int("id роли", req.Id),
+			zap.Time("время удаления", info.DeleteTime),
+		)
 
-			sendPartResult(resCh, domain.Role{}, "Ошибка удаления роли")
-			return
-		}
+		return domain.Object{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка удаления объекта")
+	}
 
-		var isDeleted bool
+	deletedObjectEntity, err := s.objectDAO.ById(ctx, req.Id)
+	if err != nil {
+		l.Warn("ошибка получения удаленной роли", zap.Int("id роли", req.Id))
 
-		if deletedObjectEntity.IsDeleted != nil {
-			isDeleted = true
-		}
+		return domain.Object{}, ctxutils.Wrap(ctx, err, "Ошибка удаления объекта")
+	}
 
-		object := domain.Object{
-			ID:          deletedObjectEntity.Id,
-			Name:        deletedObjectEntity.Name,
-			Description: deletedObjectEntity.Description,
-			CreatedAt:   deletedObjectEntity.CreatedAt,
-			IsDeleted:   isDeleted,
-			DeletedAt:   deletedObjectEntity.IsDeleted,
-		}
+	var isDeleted bool
 
-		sendPartResult(resCh, object, "")
-		return
-	}()
+	if deletedObjectEntity.IsDeleted != nil {
+		isDeleted = true
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Object{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Object(result.part.Part()), result.error
-		}
+	object := domain.Object{
+		ID:          deletedObjectEntity.Id,
+		Name:        deletedObjectEntity.Name,
+		Description: deletedObjectEntity.Description,
+		CreatedAt:   deletedObjectEntity.CreatedAt,
+		IsDeleted:   isDeleted,
+		DeletedAt:   deletedObjectEntity.IsDeleted,
 	}
+
+	return object, nil
 }
 
 func (s RBACService) ObjectsByParams(ctx context.Context, p params.State) ([]domain.Object, error) {
-	resCh := make(chan partsResult)
+	ctx, span := tracing.Start(ctx, "rbac.ObjectsByParams")
+	defer span.End()
 
-	_ = s.l.With(
+	_ = logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.GetObjectsOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		objectsEntity, err := s.objectDAO.ByParams(ctx, p.Default)
-		if err != nil {
-			sendPartsResult(resCh, []domain.Object{}, "ошибка получения объектов действий")
-			return
-		}
-
-		objects := make([]domain.Object, 0, len(objectsEntity))
-		for _, objectEntity := range objectsEntity {
-			var isDeleted bool
-
-			if objectEntity.IsDeleted != nil {
-				isDeleted = true
-			}
+	objectsEntity, err := s.objectDAO.ByParams(ctx, p.Default)
+	if err != nil {
+		return []domain.Object{}, ctxutils.Wrap(ctx, err, "ошибка получения объектов действий")
+	}
 
-			object := domain.Object{
-				ID:          objectEntity.Id,
-				Name:        objectEntity.Name,
-				Description: objectEntity.Description,
-				CreatedAt:   objectEntity.CreatedAt,
-				IsDeleted:   isDeleted,
-				DeletedAt:   objectEntity.IsDeleted,
-			}
+	objects := make([]domain.Object, 0, len(objectsEntity))
+	for _, objectEntity := range objectsEntity {
+		var isDeleted bool
 
-			objects = append(objects, object)
+		if objectEntity.IsDeleted != nil {
+			isDeleted = true
 		}
 
-		resp := make([]domain.Object, 0, len(objects))
-
-		switch p.State {
-		case params.All:
-			resp = append(resp, objects...)
-		case params.Deleted:
-			resp = append(resp, filterDeleted(objects)...)
-		case params.NotDeleted:
-			resp = append(resp, filterNotDeleted(objects)...)
+		object := domain.Object{
+			ID:          objectEntity.Id,
+			Name:        objectEntity.Name,
+			Description: objectEntity.Description,
+			CreatedAt:   objectEntity.CreatedAt,
+			IsDeleted:   isDeleted,
+			DeletedAt:   objectEntity.IsDeleted,
 		}
 
-		sendPartsResult(resCh, resp, "")
-		return
-
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-resCh:
-			resp := make([]domain.Object, 0, len(result.parts))
+		objects = append(objects, object)
+	}
 
-			for _, object := range result.parts {
-				resp = append(resp, domain.Object(object.Part()))
-			}
+	resp := make([]domain.Object, 0, len(objects))
 
-			return resp, result.error
-		}
+	switch p.State {
+	case params.All:
+		resp = append(resp, objects...)
+	case params.Deleted:
+		resp = append(resp, filterDeleted(objects)...)
+	case params.NotDeleted:
+		resp = append(resp, filterNotDeleted(objects)...)
 	}
+
+	return resp, nil
 }
@@ -2,158 +2,139 @@ package rbac
 
 import (
 	"context"
-	"fmt"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 )
 
 type PermissionDAO interface {
 	Save(ctx context.Context, roleId, objectId int, actionsId []int) error
 	ByRoleId(ctx context.Context, roleId int) ([]entity.Permissions, error)
-}
-
-type AddedPermissionResult struct {
-	Error error
-}
-
-type GetPermByRoleIdResult struct {
-	Permissions []domain.Permissions
-	Error       error
+	DeleteByRoleObject(ctx context.Context, roleId, objectId int) error
 }
 
 func (s RBACService) NewPermission(ctx context.Context, req dto.SetPermissionReq) error {
-	resCh := make(chan AddedPermissionResult)
+	ctx, span := tracing.Start(ctx, "rbac.NewPermission")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.AddPermissionOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		if len(req.ActionsId) == 0 {
-			l.Warn("нет действий для добавления")
-
-			sendAddPermissionResult(resCh, "нет действий для добавления")
-			return
-		}
+	if len(req.ActionsId) == 0 {
+		l.Warn("нет действий для добавления")
 
-		err := s.permDAO.Save(ctx, req.RoleId, req.ObjectId, req.ActionsId)
-		if err != nil {
-			sendAddPermissionResult(resCh, "Неизвестная ошибка добавления доступов")
-			return
-		}
-
-		sendAddPermissionResult(resCh, "")
-	}()
+		return apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "actions_id"})
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			ctx.Err().Error()
-		case result := <-resCh:
-			result.Error.Error()
-		}
+	// Доступы сохраняются все вместе или не сохраняются вовсе
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.permDAO.Save(ctx, req.RoleId, req.ObjectId, req.ActionsId)
+	})
+	if err != nil {
+		return ctxutils.Wrap(ctx, err, "Неизвестная ошибка добавления доступов")
 	}
+
+	return nil
 }
 
-func (s RBACService) ByRoleId(ctx context.Context, req dto.EntityId) ([]domain.Permissions, error) {
-	resCh := make(chan GetPermByRoleIdResult)
+// ReplacePermission заменяет доступы роли к объекту на переданный набор действий.
+// Пустой набор действий снимает все доступы роли к объекту
+func (s RBACService) ReplacePermission(ctx context.Context, req dto.SetPermissionReq) error {
+	ctx, span := tracing.Start(ctx, "rbac.ReplacePermission")
+	defer span.End()
 
-	_ = s.l.With(
-		zap.String(operation.Operation, operation.GetPermissionOperation),
+	l := logger.FromContext(ctx, s.l).With(
+		zap.String(operation.Operation, operation.ReplacePermissionOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		permEntity, err := s.permDAO.ByRoleId(ctx, req.Id)
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.permDAO.DeleteByRoleObject(ctx, req.RoleId, req.ObjectId)
 		if err != nil {
-			sendGetPermByRoleIdResult(resCh, nil, "ошибка получения доступов")
-			return
+			return err
 		}
 
-		perm := make([]domain.Permissions, 0, len(permEntity))
-
-		for _, p := range permEntity {
-			var roleIsDeleted, objectIsDeleted, actionIsDeleted bool
-
-			if p.Role.IsDeleted != nil {
-				roleIsDeleted = true
-			}
-			if p.Object.IsDeleted != nil {
-				objectIsDeleted = true
-			}
-			if p.Action.IsDeleted != nil {
-				actionIsDeleted = true
-			}
-
-			perm = append(perm, domain.Permissions{
-				PermissionId: p.PermissionId,
-				Role: domain.Role{
-					ID:          p.Role.Id,
-					Name:        p.Role.Name,
-					Description: p.Role.Description,
-					CreatedAt:   p.Role.CreatedAt,
-					IsDeleted:   roleIsDeleted,
-					DeletedAt:   p.Role.IsDeleted,
-				},
-				Action: domain.Action{
-					ID:          p.Action.Id,
-					Name:        p.Action.Name,
-					Description: p.Action.Description,
-					CreatedAt:   p.Action.CreatedAt,
-					IsDeleted:   actionIsDeleted,
-					DeletedAt:   p.Action.IsDeleted,
-				},
-				Object: domain.Object{
-					ID:          p.Object.Id,
-					Name:        p.Object.Name,
-					Description: p.Object.Description,
-					CreatedAt:   p.Object.CreatedAt,
-					IsDeleted:   objectIsDeleted,
-					DeletedAt:   p.Object.IsDeleted,
-				},
-			})
+		if len(req.ActionsId) == 0 {
+			return nil
 		}
 
-		sendGetPermByRoleIdResult(resCh, perm, "")
-		return
-	}()
+		return s.permDAO.Save(ctx, req.RoleId, req.ObjectId, req.ActionsId)
+	})
+	if err != nil {
+		l.Warn("ошибка замены доступов", zap.Error(err))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-resCh:
-			return result.Permissions, result.Error
-		}
+		return ctxutils.Wrap(ctx, err, "Неизвестная ошибка замены доступов")
 	}
+
+	return nil
 }
 
-func sendAddPermissionResult(resCh chan AddedPermissionResult, errMsg string) {
-	var err error
+func (s RBACService) ByRoleId(ctx context.Context, req dto.EntityId) ([]domain.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "rbac.ByRoleId")
+	defer span.End()
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
-	}
+	_ = logger.FromContext(ctx, s.l).With(
+		zap.String(operation.Operation, operation.GetPermissionOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
 
-	resCh <- AddedPermissionResult{
-		Error: err,
+	permEntity, err := s.permDAO.ByRoleId(ctx, req.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "ошибка получения доступов")
 	}
-}
 
-func sendGetPermByRoleIdResult(resCh chan GetPermByRoleIdResult, resp []domain.Permissions, errMsg string) {
-	var err error
+	perm := make([]domain.Permissions, 0, len(permEntity))
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
-	}
+	for _, p := range permEntity {
+		var roleIsDeleted, objectIsDeleted, actionIsDeleted bool
 
-	resCh <- GetPermByRoleIdResult{
-		Permissions: resp,
-		Error:       err,
+		if p.Role.IsDeleted != nil {
+			roleIsDeleted = true
+		}
+		if p.Object.IsDeleted != nil {
+			objectIsDeleted = true
+		}
+		if p.Action.IsDeleted != nil {
+			actionIsDeleted = true
+		}
+
+		perm = append(perm, domain.Permissions{
+			PermissionId: p.PermissionId,
+			Role: domain.Role{
+				ID:          p.Role.Id,
+				Name:        p.Role.Name,
+				Description: p.Role.Description,
+				CreatedAt:   p.Role.CreatedAt,
+				IsDeleted:   roleIsDeleted,
+				DeletedAt:   p.Role.IsDeleted,
+			},
+			Action: domain.Action{
+				ID:          p.Action.Id,
+				Name:        p.Action.Name,
+				Description: p.Action.Description,
+				CreatedAt:   p.Action.CreatedAt,
+				IsDeleted:   actionIsDeleted,
+				DeletedAt:   p.Action.IsDeleted,
+			},
+			Object: domain.Object{
+				ID:          p.Object.Id,
+				Name:        p.Object.Name,
+				Description: p.Object.Description,
+				CreatedAt:   p.Object.CreatedAt,
+				IsDeleted:   objectIsDeleted,
+				DeletedAt:   p.Object.IsDeleted,
+			},
+		})
 	}
+
+	return perm, nil
 }
@@ -1,15 +1,21 @@
 package rbac
 
 import (
+	"context"
 	"go.uber.org/zap"
 )
 
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type RBACService struct {
 	l         *zap.Logger
 	actionDAO ActionDAO
 	objectDAO ObjectDAO
 	roleDAO   RoleDAO
 	permDAO   PermissionDAO
+	tx        TxManager
 }
 
 func New(
@@ -17,12 +23,14 @@ func New(
 	objectDAO ObjectDAO,
 	roleDAO RoleDAO,
 	permDAO PermissionDAO,
+	tx TxManager,
 	logger *zap.Logger) RBACService {
 	return RBACService{
 		actionDAO: actionDAO,
 		objectDAO: objectDAO,
 		roleDAO:   roleDAO,
 		permDAO:   permDAO,
+		tx:        tx,
 		l:         logger,
 	}
 }
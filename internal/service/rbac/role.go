@@ -9,6 +9,10 @@ import (
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
@@ -20,234 +24,177 @@ type RoleDAO interface {
 }
 
 func (s RBACService) NewRole(ctx context.Context, req dto.NewRBACReq) (domain.Role, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.NewRole")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.AddRoleOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		if req.Name == "" {
-			l.Warn("Пустая добавляемая роль")
+	if req.Name == "" {
+		l.Warn("Пустая добавляемая роль")
 
-			sendPartResult(resCh, domain.Role{}, "Пустая добавляемая роль")
-			return
-		}
-
-		part := dto.NewRBACPart{
-			Name:        req.Name,
-			Description: req.Description,
-		}
-
-		added, err := s.roleDAO.Save(ctx, part)
-		if err != nil {
-			sendPartResult(resCh, domain.Role{}, "Неизвестная ошибка сохранения роли")
-			return
-		}
+		return domain.Role{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "name"})
+	}
 
-		var isDeleted bool
+	part := dto.NewRBACPart{
+		Name:        req.Name,
+		Description: req.Description,
+	}
 
-		if added.IsDeleted != nil {
-			isDeleted = true
-		}
+	added, err := s.roleDAO.Save(ctx, part)
+	if err != nil {
+		return domain.Role{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка сохранения роли")
+	}
 
-		// Формируем ответ
-		role := domain.Role{
-			ID:          added.Id,
-			Name:        added.Name,
-			Description: added.Description,
-			CreatedAt:   added.CreatedAt,
-			IsDeleted:   isDeleted,
-			DeletedAt:   added.IsDeleted,
-		}
+	var isDeleted bool
 
-		sendPartResult(resCh, role, "")
-		return
-	}()
+	if added.IsDeleted != nil {
+		isDeleted = true
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Role{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Role(result.part.Part()), result.error
-		}
+	// Формируем ответ
+	role := domain.Role{
+		ID:          added.Id,
+		Name:        added.Name,
+		Description: added.Description,
+		CreatedAt:   added.CreatedAt,
+		IsDeleted:   isDeleted,
+		DeletedAt:   added.IsDeleted,
 	}
+
+	return role, nil
 }
 
 func (s RBACService) DeleteRoleById(ctx context.Context, req dto.EntityId) (domain.Role, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.DeleteRoleById")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.SoftDeleteRoleOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		info := dto.DeleteInfo{DeleteTime: time.Now()}
+	info := dto.DeleteInfo{DeleteTime: time.Now()}
 
-		err := s.roleDAO.SoftDeleteById(ctx, req.Id, info)
-		if err != nil {
-			l.Warn("ошибка мягкого удаления роли",
-				zap.Int("id роли", req.Id),
-				zap.Time("время удаления", info.DeleteTime),
-			)
-
-			sendPartResult(resCh, domain.Role{}, "Неизвестная ошибка удаления роли")
-			return
-		}
-
-		deletedRoleEntity, err := s.roleDAO.ById(ctx, req.Id)
-		if err != nil {
-			l.Warn("ошибка получения удаленной роли", zap.Int("id роли", req.Id))
+	err := s.roleDAO.SoftDeleteById(ctx, req.Id, info)
+	if err != nil {
+		l.Warn("ошибка мягкого удаления роли",
+			zap.Int("id роли", req.Id),
+			zap.Time("время удаления", info.DeleteTime),
+		)
 
-			sendPartResult(resCh, domain.Role{}, "Ошибка удаления роли")
-			return
-		}
+		return domain.Role{}, ctxutils.Wrap(ctx, err, "Неизвестная ошибка удаления роли")
+	}
 
-		var isDeleted bool
+	deletedRoleEntity, err := s.roleDAO.ById(ctx, req.Id)
+	if err != nil {
+		l.Warn("ошибка получения удаленной роли", zap.Int("id роли", req.Id))
 
-		if deletedRoleEntity.IsDeleted != nil {
-			isDeleted = true
-		}
+		return domain.Role{}, ctxutils.Wrap(ctx, err, "Ошибка удаления роли")
+	}
 
-		role := domain.Role{
-			ID:          deletedRoleEntity.Id,
-			Name:        deletedRoleEntity.Name,
-			Description: deletedRoleEntity.Description,
-			CreatedAt:   deletedRoleEntity.CreatedAt,
-			IsDeleted:   isDeleted,
-			DeletedAt:   deletedRoleEntity.IsDeleted,
-		}
+	var isDeleted bool
 
-		sendPartResult(resCh, role, "")
-		return
-	}()
+	if deletedRoleEntity.IsDeleted != nil {
+		isDeleted = true
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Role{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Role(result.part.Part()), result.error
-		}
+	role := domain.Role{
+		ID:          deletedRoleEntity.Id,
+		Name:        deletedRoleEntity.Name,
+		Description: deletedRoleEntity.Description,
+		CreatedAt:   deletedRoleEntity.CreatedAt,
+		IsDeleted:   isDeleted,
+		DeletedAt:   deletedRoleEntity.IsDeleted,
 	}
+
+	return role, nil
 }
 
 func (s RBACService) RoleById(ctx context.Context, req dto.EntityId) (domain.Role, error) {
-	resCh := make(chan partResult)
+	ctx, span := tracing.Start(ctx, "rbac.RoleById")
+	defer span.End()
 
-	l := s.l.With(
+	l := logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.GetRoleOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		roleEntity, err := s.roleDAO.ById(ctx, req.Id)
-		if err != nil {
-			sendPartResult(resCh, domain.Role{}, "Ошибка получения роли")
-			return
-		}
-
-		var isDeleted bool
+	roleEntity, err := s.roleDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.Role{}, ctxutils.Wrap(ctx, err, "Ошибка получения роли")
+	}
 
-		if roleEntity.IsDeleted != nil {
-			isDeleted = true
-		}
+	var isDeleted bool
 
-		role := domain.Role{
-			ID:          roleEntity.Id,
-			Name:        roleEntity.Name,
-			Description: roleEntity.Description,
-			CreatedAt:   roleEntity.CreatedAt,
-			IsDeleted:   isDeleted,
-			DeletedAt:   roleEntity.IsDeleted,
-		}
+	if roleEntity.IsDeleted != nil {
+		isDeleted = true
+	}
 
-		l.Info("получение роли по id",
-			zap.Int("id роли", role.ID),
-			zap.Time("время создания", role.CreatedAt),
-			zap.Bool("удалено", isDeleted),
-		)
+	role := domain.Role{
+		ID:          roleEntity.Id,
+		Name:        roleEntity.Name,
+		Description: roleEntity.Description,
+		CreatedAt:   roleEntity.CreatedAt,
+		IsDeleted:   isDeleted,
+		DeletedAt:   roleEntity.IsDeleted,
+	}
 
-		sendPartResult(resCh, role, "")
-		return
-	}()
+	l.Info("получение роли по id",
+		zap.Int("id роли", role.ID),
+		zap.Time("время создания", role.CreatedAt),
+		zap.Bool("удалено", isDeleted),
+	)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.Role{}, ctx.Err()
-		case result := <-resCh:
-			return domain.Role(result.part.Part()), result.error
-		}
-	}
+	return role, nil
 }
 
 func (s RBACService) RolesByParams(ctx context.Context, p params.State) ([]domain.Role, error) {
-	resCh := make(chan partsResult)
+	ctx, span := tracing.Start(ctx, "rbac.RolesByParams")
+	defer span.End()
 
-	_ = s.l.With(
+	_ = logger.FromContext(ctx, s.l).With(
 		zap.String(operation.Operation, operation.GetRoleOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		rolesEntity, err := s.roleDAO.ByParams(ctx, p.Default)
-		if err != nil {
-			sendPartsResult(resCh, []domain.Role{}, "Ошибка получения ролей")
-			return
-		}
-
-		roles := make([]domain.Role, 0, len(rolesEntity))
-		for _, roleEntity := range rolesEntity {
-			var isDeleted bool
-
-			if roleEntity.IsDeleted != nil {
-				isDeleted = true
-			}
+	rolesEntity, err := s.roleDAO.ByParams(ctx, p.Default)
+	if err != nil {
+		return []domain.Role{}, ctxutils.Wrap(ctx, err, "Ошибка получения ролей")
+	}
 
-			role := domain.Role{
-				ID:          roleEntity.Id,
-				Name:        roleEntity.Name,
-				Description: roleEntity.Description,
-				CreatedAt:   roleEntity.CreatedAt,
-				IsDeleted:   isDeleted,
-				DeletedAt:   roleEntity.IsDeleted,
-			}
+	roles := make([]domain.Role, 0, len(rolesEntity))
+	for _, roleEntity := range rolesEntity {
+		var isDeleted bool
 
-			roles = append(roles, role)
+		if roleEntity.IsDeleted != nil {
+			isDeleted = true
 		}
 
-		resp := make([]domain.Role, 0, len(rolesEntity))
-
-		switch p.State {
-		case params.All:
-			resp = append(resp, roles...)
-		case params.Deleted:
-			resp = append(resp, filterDeleted(roles)...)
-		case params.NotDeleted:
-			resp = append(resp, filterNotDeleted(roles)...)
+		role := domain.Role{
+			ID:          roleEntity.Id,
+			Name:        roleEntity.Name,
+			Description: roleEntity.Description,
+			CreatedAt:   roleEntity.CreatedAt,
+			IsDeleted:   isDeleted,
+			DeletedAt:   roleEntity.IsDeleted,
 		}
 
-		sendPartsResult(resCh, resp, "")
-		return
-
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-resCh:
-			resp := make([]domain.Role, 0, len(result.parts))
+		roles = append(roles, role)
+	}
 
-			for _, role := range result.parts {
-				resp = append(resp, domain.Role(role.Part()))
-			}
+	resp := make([]domain.Role, 0, len(rolesEntity))
 
-			return resp, result.error
-		}
+	switch p.State {
+	case params.All:
+		resp = append(resp, roles...)
+	case params.Deleted:
+		resp = append(resp, filterDeleted(roles)...)
+	case params.NotDeleted:
+		resp = append(resp, filterNotDeleted(roles)...)
 	}
+
+	return resp, nil
 }
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"go.uber.org/zap"
+	"io"
+	"net/url"
 	"practice_vgpek/internal/dao"
 	"practice_vgpek/internal/mediator/account"
 	"practice_vgpek/internal/mediator/practice"
@@ -10,13 +12,23 @@ import (
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/params"
+	"practice_vgpek/internal/service/discipline"
+	"practice_vgpek/internal/service/group"
+	"practice_vgpek/internal/service/health"
 	"practice_vgpek/internal/service/issued_practice"
 	"practice_vgpek/internal/service/key"
+	"practice_vgpek/internal/service/link"
+	"practice_vgpek/internal/service/notification"
 	"practice_vgpek/internal/service/person"
+	"practice_vgpek/internal/service/plagiarism"
+	"practice_vgpek/internal/service/quota"
 	"practice_vgpek/internal/service/rbac"
 	"practice_vgpek/internal/service/solved_practice"
+	"practice_vgpek/internal/service/term"
 	"practice_vgpek/internal/service/token"
+	"practice_vgpek/internal/service/upload"
 	"practice_vgpek/internal/storage"
+	"practice_vgpek/pkg/antivirus"
 )
 
 type AuthnService interface {
@@ -55,6 +67,7 @@ type RBACService interface {
 	RolesByParams(ctx context.Context, params params.State) ([]domain.Role, error)
 
 	NewPermission(ctx context.Context, req dto.SetPermissionReq) error
+	ReplacePermission(ctx context.Context, req dto.SetPermissionReq) error
 	ByRoleId(ctx context.Context, req dto.EntityId) ([]domain.Permissions, error)
 }
 
@@ -68,13 +81,111 @@ type KeyService interface {
 type IssuedPracticeService interface {
 	Save(ctx context.Context, req dto.NewIssuedPracticeReq) (domain.IssuedPractice, error)
 	ById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+	File(ctx context.Context, req dto.EntityId) (domain.File, error)
+	Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error)
+	ByParams(ctx context.Context, p params.IssuedPractice) ([]domain.IssuedPractice, error)
+	Search(ctx context.Context, p params.PracticeSearch) ([]domain.PracticeSearchHit, error)
+
+	Update(ctx context.Context, req dto.UpdateIssuedPracticeReq) (domain.IssuedPractice, error)
+	DeleteById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+	RestoreById(ctx context.Context, req dto.EntityId) (domain.IssuedPractice, error)
+
+	Clone(ctx context.Context, req dto.ClonePracticeReq) (domain.IssuedPractice, error)
+	CloneDiscipline(ctx context.Context, req dto.CloneDisciplineReq) ([]domain.IssuedPractice, error)
+
+	Submissions(ctx context.Context, req dto.EntityId) (domain.SubmissionArchive, error)
+	WriteSubmissionsArchive(ctx context.Context, archive domain.SubmissionArchive, w io.Writer) error
+}
+
+type NotificationService interface {
+	Notify(ctx context.Context, accountIds []int, message string) error
+	NotificationsByAccountId(ctx context.Context, req dto.EntityId, p params.Default) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, req dto.EntityId) error
 }
 
 type SolvedPracticeService interface {
 	Save(ctx context.Context, req dto.NewSolvedPracticeReq) (domain.SolvedPractice, error)
 	ById(ctx context.Context, req dto.EntityId) (domain.SolvedPractice, error)
+	File(ctx context.Context, req dto.EntityId) (domain.File, error)
+	Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error)
 
 	SetMark(ctx context.Context, req dto.MarkPracticeReq) (domain.SolvedPractice, error)
+
+	ImportMarks(ctx context.Context, req dto.MarkImportReq) (domain.MarkImport, error)
+	MarkHistory(ctx context.Context, req dto.EntityId) ([]domain.MarkHistory, error)
+}
+
+type GroupService interface {
+	Transfer(ctx context.Context, req dto.TransferReq) ([]domain.GroupMembership, error)
+	PromoteCohort(ctx context.Context, req dto.PromoteCohortReq) (domain.Cohort, error)
+
+	MembersByGroup(ctx context.Context, req dto.GroupMembersReq) ([]domain.GroupMembership, error)
+}
+
+type DisciplineService interface {
+	NewDiscipline(ctx context.Context, req dto.NewDisciplineReq) (domain.Discipline, error)
+	DisciplineById(ctx context.Context, req dto.EntityId) (domain.Discipline, error)
+	DisciplinesByParams(ctx context.Context, p params.State) ([]domain.Discipline, error)
+	DisciplinesByAccountId(ctx context.Context, req dto.EntityId) ([]domain.Discipline, error)
+	DeleteDisciplineById(ctx context.Context, req dto.EntityId) (domain.Discipline, error)
+
+	NewAssignment(ctx context.Context, req dto.NewAssignmentReq) (domain.TeachingAssignment, error)
+	AssignmentsByAccountId(ctx context.Context, req dto.EntityId) ([]domain.TeachingAssignment, error)
+	DeleteAssignmentById(ctx context.Context, req dto.EntityId) (domain.TeachingAssignment, error)
+}
+
+type TermService interface {
+	NewTerm(ctx context.Context, req dto.NewTermReq) (domain.AcademicTerm, error)
+	CurrentTerm(ctx context.Context) (domain.AcademicTerm, error)
+	TermsByParams(ctx context.Context, p params.Default) ([]domain.AcademicTerm, error)
+
+	SetCurrentTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error)
+	CloseTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error)
+}
+
+type PlagiarismService interface {
+	// Run обрабатывает очередь сравнения работ до отмены контекста
+	Run(ctx context.Context)
+
+	Report(ctx context.Context, req dto.SimilarityReportReq) ([]domain.SimilarityPair, error)
+	Reanalyze(ctx context.Context, req dto.EntityId) error
+}
+
+type QuotaService interface {
+	SetQuota(ctx context.Context, req dto.SetQuotaReq) (domain.StorageQuota, error)
+	Quotas(ctx context.Context) ([]domain.StorageQuota, error)
+	DeleteQuota(ctx context.Context, req dto.EntityId) error
+
+	Usage(ctx context.Context, req dto.EntityId) (domain.AccountStorage, error)
+	TopConsumers(ctx context.Context, req dto.TopConsumersReq) ([]domain.StorageUsage, error)
+}
+
+type UploadService interface {
+	// Run удаляет истекшие загрузки до отмены контекста
+	Run(ctx context.Context)
+
+	MaxSize() int64
+	ResumableMaxSize() int64
+
+	Create(ctx context.Context, req dto.NewUploadReq) (domain.UploadSession, error)
+	Session(ctx context.Context, req dto.UploadId) (domain.UploadSession, error)
+	Append(ctx context.Context, req dto.UploadChunkReq) (domain.UploadSession, error)
+	Open(ctx context.Context, req dto.UploadId) (domain.File, error)
+	Delete(ctx context.Context, req dto.UploadId) error
+}
+
+type LinkService interface {
+	// Run удаляет истекшие ссылки до отмены контекста
+	Run(ctx context.Context)
+
+	Mint(ctx context.Context, req dto.NewDownloadLinkReq) (domain.DownloadLink, error)
+	Redeem(ctx context.Context, object string, query url.Values) (domain.DownloadLink, error)
+	Revoke(ctx context.Context, req dto.LinkId) error
+}
+
+type HealthService interface {
+	Readiness(ctx context.Context) domain.Readiness
+	Version(ctx context.Context) domain.BuildInfo
 }
 
 type Service struct {
@@ -84,22 +195,41 @@ type Service struct {
 	RBACService
 	IssuedPracticeService
 	SolvedPracticeService
+	GroupService
+	DisciplineService
+	TermService
+	NotificationService
+	PlagiarismService
+	QuotaService
+	UploadService
+	LinkService
+	HealthService
 }
 
-func New(daoAggregator dao.Aggregator, logger *zap.Logger) Service {
-	issuedMediator := practice.NewIssuedPracticeMediator(daoAggregator.AccountDAO, daoAggregator.IssuedDAO, daoAggregator.KeyDAO)
-	fileStorage := storage.NewFileStorage()
-	rbacService := rbac.New(daoAggregator.ActionDAO, daoAggregator.ObjectDAO, daoAggregator.RoleDAO, daoAggregator.PermissionDAO, logger)
+func New(daoAggregator dao.Aggregator, scanner antivirus.Scanner, storageCfg storage.Config, uploadCfg upload.Config, linkCfg link.Config, tokenCfg token.Config, healthCfg health.Config, logger *zap.Logger) Service {
+	issuedMediator := practice.NewIssuedPracticeMediator(daoAggregator.AccountDAO, daoAggregator.IssuedDAO, daoAggregator.GroupDAO, daoAggregator.AssignmentDAO, daoAggregator.TermDAO)
+	quotaService := quota.New(daoAggregator.QuotaDAO, daoAggregator.AccountDAO, daoAggregator.GroupDAO, logger)
+	uploadService := upload.New(daoAggregator.UploadDAO, quotaService, uploadCfg, logger)
+	fileStorage := storage.NewFileStorage(scanner, storageCfg.Preview.Converter, quotaService, daoAggregator.FileScanDAO, daoAggregator.FileDigestDAO, daoAggregator.AuditDAO, storageCfg.Root, uploadCfg.MaxSize, uploadCfg.ResumableMaxSize, storageCfg.Preview.ThumbnailWidth, logger)
+	linkService := link.New(daoAggregator.DownloadLinkDAO, daoAggregator.AuditDAO, linkCfg, logger)
+	rbacService := rbac.New(daoAggregator.ActionDAO, daoAggregator.ObjectDAO, daoAggregator.RoleDAO, daoAggregator.PermissionDAO, daoAggregator.TxManager, logger)
 
-	keyService := key.New(daoAggregator.KeyDAO, daoAggregator.RoleDAO, logger)
+	keyService := key.New(daoAggregator.KeyDAO, daoAggregator.RoleDAO, daoAggregator.TxManager, logger)
 
-	personService := person.New(rbacService, daoAggregator.PermissionDAO, daoAggregator.KeyDAO, daoAggregator.PersonDAO, daoAggregator.AccountDAO, daoAggregator.RoleDAO, keyService, logger)
+	personService := person.New(rbacService, daoAggregator.PermissionDAO, daoAggregator.KeyDAO, daoAggregator.PersonDAO, daoAggregator.AccountDAO, daoAggregator.RoleDAO, daoAggregator.GroupDAO, keyService, daoAggregator.TxManager, logger)
 
 	accountMediator := account.NewAccountMediator(personService, keyService, rbacService, rbacService)
 
-	tokenService := token.New(daoAggregator.AccountDAO, "ioj9t3r89ug489h", logger)
-	issuedService := issued_practice.New(daoAggregator.IssuedDAO, daoAggregator.PersonDAO, fileStorage, accountMediator, issuedMediator, logger)
-	solvedService := solved_practice.New(accountMediator, issuedMediator, fileStorage, daoAggregator.SolvedDAO, daoAggregator.IssuedDAO, daoAggregator.PersonDAO, daoAggregator.AccountDAO, logger)
+	notificationService := notification.New(daoAggregator.NotificationDAO, logger)
+
+	tokenService := token.New(daoAggregator.AccountDAO, tokenCfg, logger)
+	issuedService := issued_practice.New(daoAggregator.IssuedDAO, daoAggregator.SolvedDAO, daoAggregator.PersonDAO, daoAggregator.TermDAO, daoAggregator.GroupDAO, fileStorage, notificationService, quotaService, accountMediator, issuedMediator, logger)
+	plagiarismService := plagiarism.New(daoAggregator.SolvedDAO, daoAggregator.IssuedDAO, daoAggregator.SimilarityDAO, daoAggregator.PersonDAO, issuedMediator, fileStorage, logger)
+	solvedService := solved_practice.New(accountMediator, issuedMediator, fileStorage, plagiarismService, daoAggregator.SolvedDAO, daoAggregator.IssuedDAO, daoAggregator.PersonDAO, daoAggregator.AccountDAO, daoAggregator.GroupDAO, daoAggregator.TermDAO, daoAggregator.TxManager, logger)
+	groupService := group.New(daoAggregator.GroupDAO, daoAggregator.AccountDAO, daoAggregator.TxManager, logger)
+	termService := term.New(daoAggregator.TermDAO, logger)
+	healthService := health.New(daoAggregator.HealthDAO, fileStorage, healthCfg, logger)
+	disciplineService := discipline.New(daoAggregator.DisciplineDAO, daoAggregator.AssignmentDAO, daoAggregator.AccountDAO, daoAggregator.PersonDAO, daoAggregator.TermDAO, logger)
 
 	return Service{
 		PersonService:         personService,
@@ -108,5 +238,14 @@ func New(daoAggregator dao.Aggregator, logger *zap.Logger) Service {
 		RBACService:           rbacService,
 		IssuedPracticeService: issuedService,
 		SolvedPracticeService: solvedService,
+		GroupService:          groupService,
+		DisciplineService:     disciplineService,
+		TermService:           termService,
+		NotificationService:   notificationService,
+		PlagiarismService:     plagiarismService,
+		QuotaService:          quotaService,
+		UploadService:         uploadService,
+		LinkService:           linkService,
+		HealthService:         healthService,
 	}
 }
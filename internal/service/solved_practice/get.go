@@ -2,82 +2,132 @@ package solved_practice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"path/filepath"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"strings"
 )
 
-type GetPracticeResult struct {
-	Practice domain.SolvedPractice
-	Error    error
-}
-
 func (s Service) ById(ctx context.Context, req dto.EntityId) (domain.SolvedPractice, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.ById")
+	defer span.End()
+
 	// необходимо проверить id, кто запрашивает
 	// если это студент и его целевая группа совпадает и id верен - отдаем ее,
 	// в ином случае, если доступ есть - отдаем по id
-	resCh := make(chan GetPracticeResult)
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.GetSolvedPracticeInfoById),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		accountId := ctx.Value("AccountId").(int)
+	accountId := ctx.Value("AccountId").(int)
 
-		groupMatch, err := s.issuedPracticeMediator.IssuedGroupMatch(ctx, accountId, req.Id)
-		if err != nil {
-			l.Warn("возникла ошибка при проверке совпадений")
-		}
+	groupMatch, err := s.issuedPracticeMediator.IssuedGroupMatch(ctx, accountId, req.Id)
+	if err != nil {
+		l.Warn("возникла ошибка при проверке совпадений")
+	}
 
-		if !groupMatch {
-			l.Warn("попытка получить практическую студентом с неправильной группой")
+	if !groupMatch {
+		l.Warn("попытка получить практическую студентом с неправильной группой")
 
-			sendGetPracticeResult(resCh, domain.SolvedPractice{}, "нет доступа к практическим группы")
-			return
-		}
+		return domain.SolvedPractice{}, apperr.Forbidden(domain.CodeGroupMismatch)
+	}
 
-		solvedPracticeEntity, err := s.solvedPracticeDAO.ById(ctx, req.Id)
-		if err != nil {
-			sendGetPracticeResult(resCh, domain.SolvedPractice{}, "нет практической работы с таким id")
-			return
-		}
+	solvedPracticeEntity, err := s.solvedPracticeDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "нет практической работы с таким id")
+	}
 
-		practice, err := s.EntityToDomain(ctx, accountId, solvedPracticeEntity)
-		if err != nil {
-			l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
+	practice, err := s.EntityToDomain(ctx, accountId, solvedPracticeEntity)
+	if err != nil {
+		l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
 
-			sendGetPracticeResult(resCh, domain.SolvedPractice{}, "ошибка формирования практической работы")
-			return
-		}
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка формирования практической работы")
+	}
+
+	return practice, nil
+}
+
+// File открывает файл работы, если у аккаунта есть доступ к самой работе
+func (s Service) File(ctx context.Context, req dto.EntityId) (domain.File, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.File")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DownloadSolvedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
 
-		sendGetPracticeResult(resCh, practice, "")
-		return
-	}()
+	practice, err := s.ById(ctx, req)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	file, err := s.fileStorage.File(ctx, practice.Path)
+	if err != nil {
+		l.Warn("ошибка открытия файла работы", zap.Int("id работы", practice.Id), zap.Error(err))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.SolvedPractice{}, ctx.Err()
-		case result := <-resCh:
-			return result.Practice, result.Error
+		if errors.Is(err, domain.ErrFileInfected) || errors.Is(err, domain.ErrFileNotScanned) {
+			return domain.File{}, err
 		}
+
+		return domain.File{}, ctxutils.Wrap(ctx, err, "не удалось найти файл")
 	}
+
+	file.Name = downloadName(practice, file.Name)
+
+	return file, nil
 }
 
-func sendGetPracticeResult(resCh chan GetPracticeResult, practice domain.SolvedPractice, errMsg string) {
-	var err error
+// Preview открывает предпросмотр файла работы, если у аккаунта есть доступ к самой работе
+func (s Service) Preview(ctx context.Context, req dto.PreviewReq) (domain.File, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.Preview")
+	defer span.End()
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.PreviewSolvedPractice),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	practice, err := s.ById(ctx, dto.EntityId{Id: req.Id})
+	if err != nil {
+		return domain.File{}, err
 	}
 
-	resCh <- GetPracticeResult{
-		Practice: practice,
-		Error:    err,
+	file, err := s.fileStorage.Preview(ctx, practice.Path, req.Kind)
+	if err != nil {
+		l.Warn("ошибка получения предпросмотра работы", zap.Int("id работы", practice.Id), zap.Error(err))
+
+		if errors.Is(err, domain.ErrFileInfected) || errors.Is(err, domain.ErrFileNotScanned) ||
+			errors.Is(err, domain.ErrPreviewUnsupported) || errors.Is(err, domain.ErrPreviewFailed) {
+			return domain.File{}, err
+		}
+
+		return domain.File{}, ctxutils.Wrap(ctx, err, "не удалось найти файл")
+	}
+
+	file.Name = downloadName(practice, file.Name)
+
+	return file, nil
+}
+
+// downloadName возвращает имя, с которым работа была загружена, а для работ без него - номер работы.
+// Расширение берется у отдаваемого файла, так как предпросмотр отдается в другом формате
+func downloadName(practice domain.SolvedPractice, storedName string) string {
+	name := strings.TrimSpace(strings.TrimSuffix(practice.FileName, filepath.Ext(practice.FileName)))
+	if name == "" {
+		name = fmt.Sprintf("Работа %d", practice.Id)
 	}
+
+	return name + filepath.Ext(storedName)
 }
@@ -0,0 +1,82 @@
+package solved_practice
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+// MarkHistory возвращает историю оценок работы. Студент видит историю только своих работ
+func (s Service) MarkHistory(ctx context.Context, req dto.EntityId) ([]domain.MarkHistory, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.MarkHistory")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.GetMarkHistoryOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	solved, err := s.solvedPracticeDAO.ById(ctx, req.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "нет практической работы с таким id")
+	}
+
+	if solved.PerformedAccountId != accountId {
+		// Студенты не имеют доступов, ошибка проверки для них означает отказ
+		hasAccess, err := s.accountMediator.HasAccess(ctx, accountId, domain.MarkObject, domain.GetAction)
+		if err != nil || !hasAccess {
+			l.Info("попытка получить историю оценок чужой работы",
+				zap.Int("id аккаунта", accountId),
+				zap.Int("id работы", req.Id),
+			)
+
+			return nil, apperr.Forbidden(apperr.CodeForbidden)
+		}
+	}
+
+	history, err := s.solvedPracticeDAO.MarkHistory(ctx, req.Id)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "ошибка получения истории оценок")
+	}
+
+	names := make(map[int]string)
+	result := make([]domain.MarkHistory, 0, len(history))
+
+	for _, entry := range history {
+		name, ok := names[entry.ChangedBy]
+		if !ok {
+			person, err := s.personDAO.ByAccountId(ctx, entry.ChangedBy)
+			if err != nil {
+				l.Warn("ошибка получения преподавателя", zap.Int("id аккаунта", entry.ChangedBy), zap.Error(err))
+
+				return nil, ctxutils.Wrap(ctx, err, "ошибка получения преподавателя")
+			}
+
+			name = fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName)
+			names[entry.ChangedBy] = name
+		}
+
+		result = append(result, domain.MarkHistory{
+			Id:               entry.Id,
+			SolvedPracticeId: entry.SolvedPracticeId,
+			OldMark:          entry.OldMark,
+			NewMark:          entry.NewMark,
+			ChangedBy:        name,
+			ChangedById:      entry.ChangedBy,
+			ChangedAt:        entry.ChangedAt,
+			Source:           entry.Source,
+		})
+	}
+
+	return result, nil
+}
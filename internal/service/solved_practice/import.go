@@ -0,0 +1,447 @@
+package solved_practice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"math"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/sheetutils"
+	"practice_vgpek/pkg/tracing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Названия столбцов таблицы оценок, регистр не учитывается
+var (
+	practiceColumns = []string{"issued_practice_id", "id задания", "задание"}
+	loginColumns    = []string{"login", "логин"}
+	nameColumns     = []string{"full_name", "фио", "студент"}
+	markColumns     = []string{"mark", "оценка"}
+)
+
+// importPractice задание из таблицы оценок вместе с последними работами студентов по нему
+type importPractice struct {
+	// errMsg ошибка, из-за которой нельзя выставить оценки ни одной работе задания
+	errMsg string
+
+	practice entity.IssuedPractice
+	term     string
+
+	byLogin map[string]entity.SolvedPractice
+	byName  map[string][]entity.SolvedPractice
+
+	// canMark назначен ли преподаватель на дисциплину задания в группе
+	canMark map[string]bool
+}
+
+// ImportMarks проверяет таблицу оценок и возвращает список изменений.
+// С подтверждением изменения применяются в одной транзакции, только если в таблице нет ошибок
+func (s Service) ImportMarks(ctx context.Context, req dto.MarkImportReq) (domain.MarkImport, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.ImportMarks")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.ImportMarksOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(req.Ext) {
+	case ".csv":
+		rows, err = sheetutils.ReadCSV(*req.File)
+	case ".xlsx":
+		rows, err = sheetutils.ReadXLSX(*req.File, req.Size)
+	default:
+		return domain.MarkImport{}, apperr.Validation(domain.CodeUnsupportedFile).WithDetails(map[string]any{"formats": ".csv, .xlsx"})
+	}
+
+	if err != nil {
+		l.Warn("ошибка чтения таблицы оценок", zap.Error(err))
+
+		return domain.MarkImport{}, ctxutils.Wrap(ctx, err, "Не удалось прочитать таблицу оценок")
+	}
+
+	if len(rows) < 2 {
+		return domain.MarkImport{}, apperr.Validation(domain.CodeEmptyTable)
+	}
+
+	header := columnsByName(rows[0])
+
+	practiceCol, hasPractice := findColumn(header, practiceColumns)
+	markCol, hasMark := findColumn(header, markColumns)
+	loginCol, hasLogin := findColumn(header, loginColumns)
+	nameCol, hasName := findColumn(header, nameColumns)
+
+	if !hasPractice || !hasMark || (!hasLogin && !hasName) {
+		return domain.MarkImport{}, apperr.Validation(domain.CodeMissingColumns).WithDetails(map[string]any{"columns": "issued_practice_id, mark, login или full_name"})
+	}
+
+	practices := make(map[int]*importPractice)
+	seen := make(map[int]int)
+
+	result := domain.MarkImport{
+		Rows: make([]domain.MarkImportRow, 0, len(rows)-1),
+	}
+
+	changes := make([]dto.MarkChange, 0, len(rows)-1)
+	changedAt := time.Now()
+
+	for i, values := range rows[1:] {
+		if isEmptyRow(values) {
+			continue
+		}
+
+		row := domain.MarkImportRow{
+			Row:    i + 2,
+			Status: domain.MarkImportError,
+		}
+
+		login := cell(values, loginCol, hasLogin)
+		name := cell(values, nameCol, hasName)
+
+		row.Student = login
+		if row.Student == "" {
+			row.Student = name
+		}
+
+		practiceId, err := parseNumber(cell(values, practiceCol, true))
+		if err != nil {
+			row.Error = "Некорректный id задания"
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		row.IssuedPracticeId = practiceId
+
+		mark, err := parseNumber(cell(values, markCol, true))
+		if err != nil || mark < domain.MinMark || mark > domain.MaxMark {
+			row.Error = fmt.Sprintf("Оценка должна быть числом от %d до %d", domain.MinMark, domain.MaxMark)
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		row.NewMark = mark
+
+		practice, ok := practices[practiceId]
+		if !ok {
+			practice, err = s.importPractice(ctx, l, accountId, practiceId)
+			if err != nil {
+				return domain.MarkImport{}, ctxutils.Wrap(ctx, err, "Не удалось получить работы по заданию")
+			}
+
+			practices[practiceId] = practice
+		}
+
+		if practice.errMsg != "" {
+			row.Error = practice.errMsg
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		solved, errMsg := practice.find(login, name)
+		if errMsg != "" {
+			row.Error = errMsg
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		row.SolvedPracticeId = solved.Id
+		row.GroupName = solved.GroupName
+		row.OldMark = solved.Mark
+
+		canMark, err := s.canImportMark(ctx, accountId, practice, solved.GroupName)
+		if err != nil {
+			l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
+
+			return domain.MarkImport{}, ctxutils.Wrap(ctx, err, "Ошибка проверки назначения преподавателя")
+		}
+
+		if !canMark {
+			row.Error = "Преподаватель не ведет дисциплину в группе студента"
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		if prev, ok := seen[solved.Id]; ok {
+			row.Error = fmt.Sprintf("Работа студента уже указана в строке %d", prev)
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		seen[solved.Id] = row.Row
+
+		if solved.Mark == mark {
+			row.Status = domain.MarkImportUnchanged
+			result.Rows = append(result.Rows, row)
+			continue
+		}
+
+		row.Status = domain.MarkImportChange
+		result.Rows = append(result.Rows, row)
+
+		changes = append(changes, dto.MarkChange{
+			SolvedPracticeId: solved.Id,
+			OldMark:          solved.Mark,
+			NewMark:          mark,
+			ChangedBy:        accountId,
+			ChangedAt:        changedAt,
+			Source:           domain.MarkSourceImport,
+		})
+	}
+
+	for _, row := range result.Rows {
+		if row.Status == domain.MarkImportError {
+			result.Errors++
+		}
+	}
+
+	result.Changes = len(changes)
+
+	l.Info("таблица оценок проверена",
+		zap.Int("id аккаунта", accountId),
+		zap.Int("строк", len(result.Rows)),
+		zap.Int("изменений", result.Changes),
+		zap.Int("ошибок", result.Errors),
+		zap.Bool("подтверждение", req.Confirm),
+	)
+
+	// Таблица с ошибками не применяется даже частично
+	if !req.Confirm || result.Errors > 0 || len(changes) == 0 {
+		return result, nil
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.solvedPracticeDAO.SetMarks(ctx, changes)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrMarkChanged) {
+			return domain.MarkImport{}, ctxutils.Wrap(ctx, err, "Оценки изменились после проверки таблицы, повторите импорт")
+		}
+
+		return domain.MarkImport{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить оценки")
+	}
+
+	result.Applied = true
+
+	l.Info("оценки из таблицы сохранены", zap.Int("изменений", result.Changes))
+
+	return result, nil
+}
+
+// importPractice загружает задание и последние работы студентов по нему.
+// Ошибка возвращается только при сбое базы данных, проблемы задания попадают в errMsg
+func (s Service) importPractice(ctx context.Context, l *zap.Logger, accountId, practiceId int) (*importPractice, error) {
+	practice := &importPractice{
+		byLogin: make(map[string]entity.SolvedPractice),
+		byName:  make(map[string][]entity.SolvedPractice),
+		canMark: make(map[string]bool),
+	}
+
+	issued, err := s.issuedPracticeDAO.ById(ctx, practiceId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		practice.errMsg = "Нет практического задания с таким id"
+		return practice, nil
+	}
+	if err != nil {
+		l.Warn("ошибка получения практического задания", zap.Int("id задания", practiceId), zap.Error(err))
+		return nil, err
+	}
+
+	practice.practice = issued
+
+	if issued.DeletedAt != nil {
+		practice.errMsg = "Практическое задание удалено"
+		return practice, nil
+	}
+
+	if issued.DisciplineId == nil && issued.AccountId != accountId {
+		practice.errMsg = "Оценивать работы по заданию может только его автор"
+		return practice, nil
+	}
+
+	termOpen, err := s.issuedPracticeMediator.TermOpen(ctx, practiceId)
+	if err != nil {
+		l.Warn("ошибка проверки семестра задания", zap.Error(err))
+		return nil, err
+	}
+
+	if !termOpen {
+		practice.errMsg = "Семестр задания закрыт, оценки доступны только для чтения"
+		return practice, nil
+	}
+
+	if issued.AcademicTermId != nil {
+		term, err := s.termDAO.ById(ctx, *issued.AcademicTermId)
+		if err != nil {
+			l.Warn("ошибка получения семестра задания", zap.Error(err))
+			return nil, err
+		}
+
+		practice.term = term.Name
+	}
+
+	solved, err := s.solvedPracticeDAO.ByIssuedPracticeId(ctx, practiceId)
+	if err != nil {
+		l.Warn("ошибка получения работ по заданию", zap.Error(err))
+		return nil, err
+	}
+
+	// Работы идут в порядке сдачи, оценка ставится последней версии работы студента
+	latest := make(map[int]entity.SolvedPractice, len(solved))
+
+	for _, work := range solved {
+		latest[work.PerformedAccountId] = work
+	}
+
+	for studentId, work := range latest {
+		account, err := s.accountDAO.ById(ctx, studentId)
+		if err != nil {
+			l.Warn("ошибка получения аккаунта студента", zap.Int("id аккаунта", studentId), zap.Error(err))
+			return nil, err
+		}
+
+		person, err := s.personDAO.ByAccountId(ctx, studentId)
+		if err != nil {
+			l.Warn("ошибка получения студента", zap.Int("id аккаунта", studentId), zap.Error(err))
+			return nil, err
+		}
+
+		practice.byLogin[normalizeName(account.Login)] = work
+
+		// Студента можно указать как с отчеством, так и без него
+		fullName := normalizeName(fmt.Sprintf("%s %s %s", person.LastName, person.FirstName, person.MiddleName))
+		shortName := normalizeName(fmt.Sprintf("%s %s", person.LastName, person.FirstName))
+
+		practice.byName[fullName] = append(practice.byName[fullName], work)
+		if shortName != fullName {
+			practice.byName[shortName] = append(practice.byName[shortName], work)
+		}
+	}
+
+	return practice, nil
+}
+
+// find ищет работу студента по логину, а если он не указан - по ФИО
+func (p *importPractice) find(login, name string) (entity.SolvedPractice, string) {
+	if login != "" {
+		solved, ok := p.byLogin[normalizeName(login)]
+		if !ok {
+			return entity.SolvedPractice{}, "Студент с таким логином не сдавал работу по заданию"
+		}
+
+		return solved, ""
+	}
+
+	if name == "" {
+		return entity.SolvedPractice{}, "Не указан студент"
+	}
+
+	solved := p.byName[normalizeName(name)]
+
+	switch len(solved) {
+	case 0:
+		return entity.SolvedPractice{}, "Студент с таким ФИО не сдавал работу по заданию"
+	case 1:
+		return solved[0], ""
+	default:
+		return entity.SolvedPractice{}, "Найдено несколько студентов с таким ФИО, укажите логин"
+	}
+}
+
+// canImportMark проверяет, может ли преподаватель оценить работу студента группы
+func (s Service) canImportMark(ctx context.Context, accountId int, practice *importPractice, group string) (bool, error) {
+	if practice.practice.DisciplineId == nil {
+		return practice.practice.AccountId == accountId, nil
+	}
+
+	if canMark, ok := practice.canMark[group]; ok {
+		return canMark, nil
+	}
+
+	canMark, err := s.issuedPracticeMediator.TeacherAssigned(ctx, accountId,
+		*practice.practice.DisciplineId, practice.term, []string{group})
+	if err != nil {
+		return false, err
+	}
+
+	practice.canMark[group] = canMark
+
+	return canMark, nil
+}
+
+func columnsByName(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		columns[normalizeName(name)] = i
+	}
+
+	return columns
+}
+
+func findColumn(columns map[string]int, names []string) (int, bool) {
+	for _, name := range names {
+		if i, ok := columns[name]; ok {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func cell(values []string, col int, present bool) string {
+	if !present || col >= len(values) {
+		return ""
+	}
+
+	return strings.TrimSpace(values[col])
+}
+
+func isEmptyRow(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseNumber читает целое число из ячейки. XLSX может хранить целые числа в виде 5.0
+func parseNumber(value string) (int, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("не целое число: %s", value)
+	}
+
+	return int(f), nil
+}
+
+// normalizeName приводит имя к виду для сравнения: нижний регистр, одиночные пробелы, е вместо ё
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	return strings.ReplaceAll(name, "ё", "е")
+}
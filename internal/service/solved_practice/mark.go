@@ -2,80 +2,145 @@ package solved_practice
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
-	"practice_vgpek/internal/model/entity"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
-type SetMarkResult struct {
-	MarkedPractice domain.SolvedPractice
-	Error          error
-}
-
 func (s Service) SetMark(ctx context.Context, req dto.MarkPracticeReq) (domain.SolvedPractice, error) {
-	resCh := make(chan SetMarkResult)
+	ctx, span := tracing.Start(ctx, "solved_practice.SetMark")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.SetMarkSolvedPractice),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		accountId := ctx.Value("AccountId").(int)
+	accountId := ctx.Value("AccountId").(int)
 
-		markTime := time.Now()
-
-		markedPracticeEntity, err := s.solvedPracticeDAO.Update(ctx, entity.SolvedPracticeUpdate{
-			Id:                 req.SolvedPracticeId,
-			PerformedAccountId: nil,
-			IssuedPracticeId:   nil,
-			Mark:               &req.Mark,
-			MarkTime:           &markTime,
-			SolvedTime:         nil,
-			Path:               nil,
-			IsDeleted:          nil,
+	if req.Mark < domain.MinMark || req.Mark > domain.MaxMark {
+		return domain.SolvedPractice{}, apperr.Validation(domain.CodeValueOutOfRange).WithDetails(map[string]any{
+			"field": "mark",
+			"min":   domain.MinMark,
+			"max":   domain.MaxMark,
 		})
+	}
+
+	// Проверка прав и изменение оценки выполняются в одной транзакции,
+	// при конкурентном изменении оценки транзакция повторяется
+	var practice domain.SolvedPractice
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		practice, err = s.setMark(ctx, l, accountId, req)
+		return err
+	})
+	if err != nil {
+		return domain.SolvedPractice{}, err
+	}
+
+	return practice, nil
+}
+
+func (s Service) setMark(ctx context.Context, l *zap.Logger, accountId int, req dto.MarkPracticeReq) (domain.SolvedPractice, error) {
+	solvedPracticeEntity, err := s.solvedPracticeDAO.ById(ctx, req.SolvedPracticeId)
+	if err != nil {
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "нет практической работы с таким id")
+	}
+
+	issuedPracticeEntity, err := s.issuedPracticeDAO.ById(ctx, solvedPracticeEntity.IssuedPracticeId)
+	if err != nil {
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка получения практического задания")
+	}
+
+	// Оценки закрытого семестра доступны только для чтения
+	termOpen, err := s.issuedPracticeMediator.TermOpen(ctx, issuedPracticeEntity.Id)
+	if err != nil {
+		l.Warn("ошибка проверки семестра задания", zap.Error(err))
+
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка проверки семестра задания")
+	}
+
+	if !termOpen {
+		return domain.SolvedPractice{}, apperr.Conflict(domain.CodeTermClosed)
+	}
+
+	// Назначение проверяется в семестре, в котором было выдано задание
+	var term string
+
+	if issuedPracticeEntity.AcademicTermId != nil {
+		termEntity, err := s.termDAO.ById(ctx, *issuedPracticeEntity.AcademicTermId)
 		if err != nil {
-			sendSetMarkResult(resCh, domain.SolvedPractice{}, "ошибка при обновлении практической работы")
-			return
+			return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка получения семестра задания")
 		}
 
-		practice, err := s.EntityToDomain(ctx, accountId, markedPracticeEntity)
+		term = termEntity.Name
+	}
+
+	// Задания без дисциплины может оценивать только их автор,
+	// в ином случае - преподаватель, ведущий дисциплину в группе студента
+	var canMark bool
+
+	if issuedPracticeEntity.DisciplineId == nil {
+		canMark = issuedPracticeEntity.AccountId == accountId
+	} else {
+		canMark, err = s.issuedPracticeMediator.TeacherAssigned(ctx, accountId,
+			*issuedPracticeEntity.DisciplineId, term, []string{solvedPracticeEntity.GroupName})
 		if err != nil {
-			l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
+			l.Warn("ошибка проверки назначения преподавателя", zap.Error(err))
 
-			sendSetMarkResult(resCh, domain.SolvedPractice{}, "ошибка формирования практической работы")
-			return
+			return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка проверки назначения преподавателя")
 		}
+	}
 
-		sendSetMarkResult(resCh, practice, "")
-		return
-	}()
+	if !canMark {
+		l.Info("попытка оценить работу без назначения на дисциплину",
+			zap.Int("id аккаунта", accountId),
+			zap.Int("id работы", req.SolvedPracticeId),
+		)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.SolvedPractice{}, ctx.Err()
-		case result := <-resCh:
-			return result.MarkedPractice, result.Error
-		}
+		return domain.SolvedPractice{}, apperr.Forbidden(domain.CodeNotAssigned)
 	}
-}
 
-func sendSetMarkResult(resCh chan SetMarkResult, resp domain.SolvedPractice, errMsg string) {
-	var err error
+	// Оценка сохраняется вместе с записью в истории оценок
+	err = s.solvedPracticeDAO.SetMarks(ctx, []dto.MarkChange{
+		{
+			SolvedPracticeId: req.SolvedPracticeId,
+			OldMark:          solvedPracticeEntity.Mark,
+			NewMark:          req.Mark,
+			ChangedBy:        accountId,
+			ChangedAt:        time.Now(),
+			Source:           domain.MarkSourceManual,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrMarkChanged) {
+			return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "оценка работы изменилась, повторите попытку")
+		}
+
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка при обновлении практической работы")
+	}
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+	markedPracticeEntity, err := s.solvedPracticeDAO.ById(ctx, req.SolvedPracticeId)
+	if err != nil {
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка получения оцененной работы")
 	}
 
-	resCh <- SetMarkResult{
-		MarkedPractice: resp,
-		Error:          err,
+	practice, err := s.EntityToDomain(ctx, markedPracticeEntity.PerformedAccountId, markedPracticeEntity)
+	if err != nil {
+		l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
+
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка формирования практической работы")
 	}
+
+	return practice, nil
 }
@@ -2,18 +2,26 @@ package solved_practice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go.uber.org/zap"
-	"mime/multipart"
+	"io"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/pkg/tracing"
+	"time"
 )
 
 type SolvedPracticeDAO interface {
 	Save(ctx context.Context, data dto.NewSolvedPractice) (entity.SolvedPractice, error)
 	ById(ctx context.Context, id int) (entity.SolvedPractice, error)
 	Update(ctx context.Context, old entity.SolvedPracticeUpdate) (entity.SolvedPractice, error)
+	ByIssuedPracticeId(ctx context.Context, issuedPracticeId int) ([]entity.SolvedPractice, error)
+	ByContentHash(ctx context.Context, issuedPracticeId int, hash string) ([]entity.SolvedPractice, error)
+
+	SetMarks(ctx context.Context, changes []dto.MarkChange) error
+	MarkHistory(ctx context.Context, solvedPracticeId int) ([]entity.MarkHistory, error)
 }
 
 type AccountDAO interface {
@@ -28,29 +36,59 @@ type IssuedPracticeDAO interface {
 	ById(ctx context.Context, id int) (entity.IssuedPractice, error)
 }
 
+type TermDAO interface {
+	ById(ctx context.Context, id int) (entity.AcademicTerm, error)
+}
+
+type GroupDAO interface {
+	CurrentByAccountId(ctx context.Context, accountId int, at time.Time) (entity.AccountGroup, error)
+}
+
 type AccountMediator interface {
 	HasAccess(ctx context.Context, accountId int, objectName, actionName string) (bool, error)
 }
 
 type IssuedPracticeMediator interface {
 	IssuedGroupMatch(ctx context.Context, accountId, practiceId int) (bool, error)
+	TeacherAssigned(ctx context.Context, accountId, disciplineId int, term string, groups []string) (bool, error)
+	// TermOpen Проверяет, что семестр практического задания не закрыт
+	TermOpen(ctx context.Context, practiceId int) (bool, error)
+}
+
+type Analyzer interface {
+	// Enqueue ставит задание в очередь на сравнение работ
+	Enqueue(issuedPracticeId int) bool
 }
 
 type PracticeFileStorage interface {
-	// SaveFile возвращает путь, по которому был сохранен файл
-	SaveFile(ctx context.Context, file *multipart.File, root, ext, name string) (string, error)
+	// SaveFile читает файл из потока и возвращает путь, размер и хэш сохраненного файла
+	SaveFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error)
+	// SaveUploadedFile сохраняет файл возобновляемой загрузки, для которого допустим больший размер
+	SaveUploadedFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error)
+	// Discard удаляет сохраненный файл и освобождает его место, если запись о нем не сохранилась
+	Discard(ctx context.Context, path string)
+	File(ctx context.Context, path string) (domain.File, error)
+	Preview(ctx context.Context, path, kind string) (domain.File, error)
+}
+
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Service struct {
 	logger *zap.Logger
+	tx     TxManager
 
 	accountDAO AccountDAO
 	personDAO  PersonDAO
+	groupDAO   GroupDAO
+	termDAO    TermDAO
 
 	accountMediator        AccountMediator
 	issuedPracticeMediator IssuedPracticeMediator
 
 	fileStorage PracticeFileStorage
+	analyzer    Analyzer
 
 	solvedPracticeDAO SolvedPracticeDAO
 	issuedPracticeDAO IssuedPracticeDAO
@@ -58,25 +96,33 @@ type Service struct {
 
 func New(
 	accountMediator AccountMediator, issuedPracticeMediator IssuedPracticeMediator,
-	fileStorage PracticeFileStorage, solvedPracticeDAO SolvedPracticeDAO, issuedPracticeDAO IssuedPracticeDAO,
-	personDAO PersonDAO, accountDAO AccountDAO, logger *zap.Logger) Service {
+	fileStorage PracticeFileStorage, analyzer Analyzer, solvedPracticeDAO SolvedPracticeDAO, issuedPracticeDAO IssuedPracticeDAO,
+	personDAO PersonDAO, accountDAO AccountDAO, groupDAO GroupDAO, termDAO TermDAO, tx TxManager, logger *zap.Logger) Service {
 	return Service{
+
 		accountDAO: accountDAO,
 		personDAO:  personDAO,
+		groupDAO:   groupDAO,
+		termDAO:    termDAO,
 
 		accountMediator:        accountMediator,
 		issuedPracticeMediator: issuedPracticeMediator,
 
 		fileStorage: fileStorage,
+		analyzer:    analyzer,
 
 		solvedPracticeDAO: solvedPracticeDAO,
 		issuedPracticeDAO: issuedPracticeDAO,
 
+		tx:     tx,
 		logger: logger,
 	}
 }
 
 func (s Service) EntityToDomain(ctx context.Context, accountId int, entity entity.SolvedPractice) (domain.SolvedPractice, error) {
+	ctx, span := tracing.Start(ctx, "solved_practice.EntityToDomain")
+	defer span.End()
+
 	issuedPracticeEntity, err := s.issuedPracticeDAO.ById(ctx, entity.IssuedPracticeId)
 	if err != nil {
 		return domain.SolvedPractice{}, err
@@ -103,18 +149,43 @@ func (s Service) EntityToDomain(ctx context.Context, accountId int, entity entit
 		isDeleted = true
 	}
 
+	var fileName string
+
+	if entity.FileName != nil {
+		fileName = *entity.FileName
+	}
+
 	practice := domain.SolvedPractice{
 		Id:               entity.Id,
 		IssuedPracticeId: entity.IssuedPracticeId,
 		IssuerName:       fmt.Sprintf("%s %s %s", teacher.FirstName, teacher.MiddleName, teacher.LastName),
 		AuthorName:       fmt.Sprintf("%s %s %s", student.FirstName, student.MiddleName, student.LastName),
 		AuthorId:         student.AccountId,
+		GroupName:        entity.GroupName,
+		DuplicateOf:      entity.DuplicateOf,
 		Mark:             entity.Mark,
 		MarkTime:         entity.MarkTime,
 		SolvedTime:       *entity.SolvedTime,
+		Path:             entity.Path,
+		FileName:         fileName,
 		IsDeleted:        isDeleted,
 		DeletedAt:        entity.IsDeleted,
 	}
 
 	return practice, nil
 }
+
+// saveFileErrMsg возвращает сообщение об ошибке сохранения файла. Отказ антивируса, превышение квоты и размера
+// сообщаются отдельно, чтобы пользователь не пытался загрузить тот же файл повторно
+func saveFileErrMsg(err error, fallback string) string {
+	switch {
+	case errors.Is(err, domain.ErrFileInfected):
+		return "Файл заражен и помещен в карантин"
+	case errors.Is(err, domain.ErrFileNotScanned):
+		return "Не удалось проверить файл антивирусом"
+	case errors.Is(err, domain.ErrQuotaExceeded), errors.Is(err, domain.ErrFileTooLarge):
+		return err.Error()
+	default:
+		return fallback
+	}
+}
@@ -2,110 +2,146 @@ package solved_practice
 
 import (
 	"context"
-	"fmt"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/internal/storage"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/rndutils"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
-type SavePracticeResult struct {
-	SavedPractice domain.SolvedPractice
-	Error         error
-}
-
 func (s Service) Save(ctx context.Context, req dto.NewSolvedPracticeReq) (domain.SolvedPractice, error) {
-	resCh := make(chan SavePracticeResult)
+	ctx, span := tracing.Start(ctx, "solved_practice.Save")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.UploadSolvedPracticeOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		accountId := ctx.Value("AccountId").(int)
+	accountId := ctx.Value("AccountId").(int)
 
-		// Проверка: совпадает ли целевая группа выполненного задания с группой студента
-		groupMatch, err := s.issuedPracticeMediator.IssuedGroupMatch(ctx, accountId, req.IssuedPracticeId)
-		if err != nil {
-			l.Warn("ошибка при проверке совпадения группы", zap.Error(err))
+	// Проверка: совпадает ли целевая группа выполненного задания с группой студента
+	groupMatch, err := s.issuedPracticeMediator.IssuedGroupMatch(ctx, accountId, req.IssuedPracticeId)
+	if err != nil {
+		l.Warn("ошибка при проверке совпадения группы", zap.Error(err))
 
-			sendSavePracticeResult(resCh, domain.SolvedPractice{}, "Ошибка при проверке целевой группы задания")
-			return
-		}
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка при проверке целевой группы задания")
+	}
 
-		if !groupMatch {
-			l.Warn("попытка загрузить работу не с целевой группой", zap.Int("id аккаунта", accountId))
+	if !groupMatch {
+		l.Warn("попытка загрузить работу не с целевой группой", zap.Int("id аккаунта", accountId))
 
-			sendSavePracticeResult(resCh, domain.SolvedPractice{}, "Некорректная целевая группа у практического задания")
-			return
-		}
+		return domain.SolvedPractice{}, apperr.Forbidden(domain.CodeNotTargetGroup)
+	}
 
-		// Формируем случайное название практического задания
-		// TODO: возможно стоит сделать его более осмысленным
-		name := rndutils.RandString(10)
+	termOpen, err := s.issuedPracticeMediator.TermOpen(ctx, req.IssuedPracticeId)
+	if err != nil {
+		l.Warn("ошибка проверки семестра задания", zap.Error(err))
 
-		// Сохраняем файл выполненной практической работы
-		savedPath, err := s.fileStorage.SaveFile(ctx, req.File, "solved", ".docx", name)
-		if err != nil {
-			l.Warn("возникла ошибка при сохранении файла", zap.Error(err))
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка при проверке семестра задания")
+	}
 
-			sendSavePracticeResult(resCh, domain.SolvedPractice{}, "Не удалось сохранить файл практического задания")
-			return
-		}
+	if !termOpen {
+		return domain.SolvedPractice{}, apperr.Conflict(domain.CodeTermClosed)
+	}
 
-		solvedTime := time.Now()
+	// Запоминаем группу студента на момент сдачи, чтобы работа осталась за ней после перевода
+	solvedTime := time.Now()
 
-		data := dto.NewSolvedPractice{
-			PerformedAccountId: accountId,
-			IssuedPracticeId:   req.IssuedPracticeId,
-			Mark:               0,
-			MarkTime:           nil,
-			SolvedTime:         &solvedTime,
-			Path:               savedPath,
-			IsDeleted:          nil,
-		}
+	membership, err := s.groupDAO.CurrentByAccountId(ctx, accountId, solvedTime)
+	if err != nil {
+		l.Warn("ошибка получения группы студента", zap.Error(err))
 
-		savedPracticeEntity, err := s.solvedPracticeDAO.Save(ctx, data)
-		if err != nil {
-			sendSavePracticeResult(resCh, domain.SolvedPractice{}, "Не удалось сохранить информацию о практическом задании")
-			return
-		}
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка при получении группы студента")
+	}
 
-		practice, err := s.EntityToDomain(ctx, accountId, savedPracticeEntity)
-		if err != nil {
-			l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
+	// Формируем случайное название практического задания
+	// TODO: возможно стоит сделать его более осмысленным
+	name := rndutils.RandString(10)
 
-			sendSavePracticeResult(resCh, domain.SolvedPractice{}, "ошибка формирования практической работы")
-			return
-		}
+	saveFile := s.fileStorage.SaveFile
+	if req.Resumable {
+		saveFile = s.fileStorage.SaveUploadedFile
+	}
 
-		sendSavePracticeResult(resCh, practice, "")
-		return
-	}()
+	// Сохраняем файл выполненной практической работы, хэш содержимого считается при записи
+	saved, err := saveFile(ctx, req.File, storage.SolvedRoot, ".docx", name)
+	if err != nil {
+		l.Warn("возникла ошибка при сохранении файла", zap.Error(err))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return domain.SolvedPractice{}, ctx.Err()
-		case result := <-resCh:
-			return result.SavedPractice, result.Error
-		}
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err,
+			saveFileErrMsg(err, "Не удалось сохранить файл практического задания"))
 	}
-}
 
-func sendSavePracticeResult(resCh chan SavePracticeResult, resp domain.SolvedPractice, errMsg string) {
-	var err error
+	// Побайтно совпадающие файлы разных студентов отмечаются сразу при сдаче
+	duplicateOf, err := s.duplicateOf(ctx, req.IssuedPracticeId, accountId, saved.Hash)
+	if err != nil {
+		l.Warn("ошибка поиска совпадающих работ", zap.Error(err))
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "Ошибка при проверке работы на совпадения")
 	}
 
-	resCh <- SavePracticeResult{
-		SavedPractice: resp,
-		Error:         err,
+	if duplicateOf != nil {
+		l.Warn("работа совпадает с работой другого студента",
+			zap.Int("id аккаунта", accountId),
+			zap.Int("id задания", req.IssuedPracticeId),
+			zap.Int("id совпадающей работы", *duplicateOf),
+		)
 	}
+
+	data := dto.NewSolvedPractice{
+		PerformedAccountId: accountId,
+		IssuedPracticeId:   req.IssuedPracticeId,
+		Mark:               0,
+		MarkTime:           nil,
+		SolvedTime:         &solvedTime,
+		Path:               saved.Path,
+		FileName:           req.FileName,
+		IsDeleted:          nil,
+		GroupName:          membership.GroupName,
+		ContentHash:        saved.Hash,
+		DuplicateOf:        duplicateOf,
+	}
+
+	savedPracticeEntity, err := s.solvedPracticeDAO.Save(ctx, data)
+	if err != nil {
+		s.fileStorage.Discard(ctx, saved.Path)
+
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить информацию о практическом задании")
+	}
+
+	// Текстовое сравнение с остальными работами выполняется в фоне
+	s.analyzer.Enqueue(req.IssuedPracticeId)
+
+	practice, err := s.EntityToDomain(ctx, accountId, savedPracticeEntity)
+	if err != nil {
+		l.Warn("возникла ошибка при переводе сущности БД в сущность логики", zap.Error(err))
+
+		return domain.SolvedPractice{}, ctxutils.Wrap(ctx, err, "ошибка формирования практической работы")
+	}
+
+	return practice, nil
+}
+
+// duplicateOf возвращает id первой работы другого студента по заданию с тем же содержимым файла
+func (s Service) duplicateOf(ctx context.Context, issuedPracticeId, accountId int, hash string) (*int, error) {
+	same, err := s.solvedPracticeDAO.ByContentHash(ctx, issuedPracticeId, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, practice := range same {
+		if practice.PerformedAccountId != accountId {
+			return &practice.Id, nil
+		}
+	}
+
+	return nil, nil
 }
@@ -0,0 +1,39 @@
+package term
+
+import (
+	"context"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/params"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/tracing"
+)
+
+func (s Service) CurrentTerm(ctx context.Context) (domain.AcademicTerm, error) {
+	ctx, span := tracing.Start(ctx, "term.CurrentTerm")
+	defer span.End()
+
+	term, err := s.termDAO.Current(ctx)
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Не задан текущий семестр")
+	}
+
+	return termEntityToDomain(term), nil
+}
+
+func (s Service) TermsByParams(ctx context.Context, p params.Default) ([]domain.AcademicTerm, error) {
+	ctx, span := tracing.Start(ctx, "term.TermsByParams")
+	defer span.End()
+
+	termsEntity, err := s.termDAO.ByParams(ctx, p)
+	if err != nil {
+		return nil, ctxutils.Wrap(ctx, err, "Ошибка получения семестров")
+	}
+
+	terms := make([]domain.AcademicTerm, 0, len(termsEntity))
+
+	for _, term := range termsEntity {
+		terms = append(terms, termEntityToDomain(term))
+	}
+
+	return terms, nil
+}
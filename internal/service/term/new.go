@@ -0,0 +1,44 @@
+package term
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+)
+
+func (s Service) NewTerm(ctx context.Context, req dto.NewTermReq) (domain.AcademicTerm, error) {
+	ctx, span := tracing.Start(ctx, "term.NewTerm")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AddTermOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	if req.Name == "" {
+		return domain.AcademicTerm{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "name"})
+	}
+
+	if req.StartDate.IsZero() || req.EndDate.IsZero() || req.EndDate.Before(req.StartDate) {
+		l.Warn("некорректные даты семестра",
+			zap.Time("дата начала", req.StartDate),
+			zap.Time("дата окончания", req.EndDate),
+		)
+
+		return domain.AcademicTerm{}, apperr.Validation(domain.CodeInvalidTermDates)
+	}
+
+	saved, err := s.termDAO.Save(ctx, req)
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Не удалось сохранить семестр, возможно, такой уже существует")
+	}
+
+	return termEntityToDomain(saved), nil
+}
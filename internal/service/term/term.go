@@ -0,0 +1,53 @@
+package term
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/params"
+	"time"
+)
+
+type TermDAO interface {
+	Save(ctx context.Context, data dto.NewTermReq) (entity.AcademicTerm, error)
+	ById(ctx context.Context, id int) (entity.AcademicTerm, error)
+	Current(ctx context.Context) (entity.AcademicTerm, error)
+	ByParams(ctx context.Context, p params.Default) ([]entity.AcademicTerm, error)
+
+	SetCurrent(ctx context.Context, id int) error
+	Close(ctx context.Context, id int, at time.Time) error
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	termDAO TermDAO
+}
+
+func New(termDAO TermDAO, logger *zap.Logger) Service {
+	return Service{
+		logger:  logger,
+		termDAO: termDAO,
+	}
+}
+
+func termEntityToDomain(term entity.AcademicTerm) domain.AcademicTerm {
+	var isClosed bool
+
+	if term.ClosedAt != nil {
+		isClosed = true
+	}
+
+	return domain.AcademicTerm{
+		Id:        term.Id,
+		Name:      term.Name,
+		StartDate: term.StartDate,
+		EndDate:   term.EndDate,
+		IsCurrent: term.IsCurrent,
+		CreatedAt: term.CreatedAt,
+		IsClosed:  isClosed,
+		ClosedAt:  term.ClosedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package term
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
+	"time"
+)
+
+func (s Service) SetCurrentTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error) {
+	ctx, span := tracing.Start(ctx, "term.SetCurrentTerm")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.SetCurrentTermOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	term, err := s.termDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Нет семестра с таким id")
+	}
+
+	if term.ClosedAt != nil {
+		return domain.AcademicTerm{}, apperr.Conflict(domain.CodeClosedTermCurrent)
+	}
+
+	err = s.termDAO.SetCurrent(ctx, req.Id)
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Не удалось сменить текущий семестр")
+	}
+
+	term.IsCurrent = true
+
+	l.Info("текущий семестр изменен", zap.Int("id семестра", term.Id), zap.String("название", term.Name))
+
+	return termEntityToDomain(term), nil
+}
+
+// CloseTerm закрывает семестр: его задания, работы и оценки становятся доступны только для чтения
+func (s Service) CloseTerm(ctx context.Context, req dto.EntityId) (domain.AcademicTerm, error) {
+	ctx, span := tracing.Start(ctx, "term.CloseTerm")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CloseTermOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	err := s.termDAO.Close(ctx, req.Id, time.Now())
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Не удалось закрыть семестр")
+	}
+
+	term, err := s.termDAO.ById(ctx, req.Id)
+	if err != nil {
+		return domain.AcademicTerm{}, ctxutils.Wrap(ctx, err, "Нет семестра с таким id")
+	}
+
+	l.Info("семестр закрыт", zap.Int("id семестра", term.Id), zap.String("название", term.Name))
+
+	return termEntityToDomain(term), nil
+}
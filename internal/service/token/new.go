@@ -3,101 +3,68 @@ package token
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/dto"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ctxutils"
+	"practice_vgpek/pkg/logger"
 	"practice_vgpek/pkg/password"
+	"practice_vgpek/pkg/tracing"
 	"time"
 )
 
-type LogInResult struct {
-	CreatedToken string
-	Error        error
-}
-
 type authClaims struct {
 	jwt.RegisteredClaims
 	AccountId int `json:"acc_id,omitempty"`
 }
 
 func (s Service) CreateToken(ctx context.Context, cred dto.Credentials) (string, error) {
-	resCh := make(chan LogInResult)
+	ctx, span := tracing.Start(ctx, "token.CreateToken")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.LoginOperation),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		// Находим аккаунт по введенному логину
-		acc, err := s.accountDAO.ByLogin(ctx, cred.Login)
-		if err != nil {
-			errMsg := "Ошибка получения аккаунта"
-
-			l.Warn("ошибка получения аккаунта", zap.String("логин аккаунта", cred.Login))
-
-			if errors.Is(err, pgx.ErrNoRows) {
-				errMsg = "Аккаунт не найден"
-			}
+	// Находим аккаунт по введенному логину
+	acc, err := s.accountDAO.ByLogin(ctx, cred.Login)
+	if err != nil {
+		l.Warn("ошибка получения аккаунта", logger.PII("логин аккаунта", cred.Login))
 
-			sendCreatedTokenResult(resCh, "", errMsg)
-			return
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperr.Unauthenticated(domain.CodeInvalidCredentials)
 		}
 
-		// Если не совпадает - пароль не верен
-		if !password.CheckHash(cred.Password, acc.PasswordHash) {
-			l.Warn("вход по некорректным данным",
-				zap.String("логин", cred.Login),
-				zap.String("пароль", cred.Password),
-			)
-
-			sendCreatedTokenResult(resCh, "", "Неправильный логин или пароль")
-			return
-		}
-
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &authClaims{
-			RegisteredClaims: jwt.RegisteredClaims{
-				ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-				IssuedAt:  jwt.NewNumericDate(time.Now()),
-			},
-			AccountId: acc.Id,
-		})
-
-		signedToken, err := token.SignedString([]byte(s.signingKey))
-		if err != nil {
-			l.Warn("ошибка подписи токена", zap.Error(err))
-
-			sendCreatedTokenResult(resCh, "", "Ошибка создания токена авторизации")
-			return
-		}
+		return "", ctxutils.Wrap(ctx, err, "Ошибка получения аккаунта")
+	}
 
-		sendCreatedTokenResult(resCh, signedToken, "")
-		return
-	}()
+	// Если не совпадает - пароль не верен
+	if !password.CheckHash(cred.Password, acc.PasswordHash) {
+		l.Warn("вход по некорректным данным", logger.PII("логин", cred.Login))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return "", ctx.Err()
-		case result := <-resCh:
-			return result.CreatedToken, result.Error
-		}
+		return "", apperr.Unauthenticated(domain.CodeInvalidCredentials)
 	}
-}
 
-func sendCreatedTokenResult(resCh chan LogInResult, token string, errMsg string) {
-	var err error
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &authClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.cfg.TTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		AccountId: acc.Id,
+	})
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
-	}
+	signedToken, err := token.SignedString([]byte(s.cfg.SigningKey))
+	if err != nil {
+		l.Warn("ошибка подписи токена", zap.Error(err))
 
-	resCh <- LogInResult{
-		CreatedToken: token,
-		Error:        err,
+		return "", ctxutils.Wrap(ctx, err, "Ошибка создания токена авторизации")
 	}
+
+	return signedToken, nil
 }
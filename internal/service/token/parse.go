@@ -3,77 +3,49 @@ package token
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
 	"practice_vgpek/internal/model/layer"
 	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/tracing"
 )
 
-type ParseTokenResult struct {
-	AccountId int
-	Error     error
-}
-
 func (s Service) ParseToken(ctx context.Context, token string) (int, error) {
-	resCh := make(chan ParseTokenResult)
+	ctx, span := tracing.Start(ctx, "token.ParseToken")
+	defer span.End()
 
-	l := s.logger.With(
+	l := logger.FromContext(ctx, s.logger).With(
 		zap.String(operation.Operation, operation.ParseToken),
 		zap.String(layer.Layer, layer.ServiceLayer),
 	)
 
-	go func() {
-		t, err := jwt.ParseWithClaims(token, &authClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				l.Warn("неправильная подпись токена",
-					zap.String("токен", token.Raw),
-					zap.String("ожидаем", jwt.SigningMethodHS256.Name),
-					zap.String("текущий", token.Method.Alg()),
-				)
-				return nil, errors.New("неправильный метод подписи")
-			}
-
-			return []byte(s.signingKey), nil
-		})
-		if err != nil {
-			l.Warn("ошибка расшифровки токена", zap.Error(err))
-
-			sendParseTokenResult(resCh, 0, "ошибка расшифровки токена")
-			return
-		}
-
-		c, ok := t.Claims.(*authClaims)
-		if !ok {
-			l.Warn("ошибка получения полей токена")
-
-			sendParseTokenResult(resCh, 0, "ошибка расшифровки токена")
-			return
+	t, err := jwt.ParseWithClaims(token, &authClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			l.Warn("неправильная подпись токена",
+				logger.Fingerprint("токен", token.Raw),
+				zap.String("ожидаем", jwt.SigningMethodHS256.Name),
+				zap.String("текущий", token.Method.Alg()),
+			)
+			return nil, errors.New("неправильный метод подписи")
 		}
 
-		sendParseTokenResult(resCh, c.AccountId, "")
-		return
-	}()
+		return []byte(s.cfg.SigningKey), nil
+	})
+	if err != nil {
+		l.Warn("ошибка расшифровки токена", zap.Error(err))
 
-	for {
-		select {
-		case <-ctx.Done():
-			return 0, ctx.Err()
-		case result := <-resCh:
-			return result.AccountId, result.Error
-		}
+		return 0, apperr.Unauthenticated(domain.CodeTokenInvalid).WithCause(err)
 	}
-}
 
-func sendParseTokenResult(resCh chan ParseTokenResult, resp int, errMsg string) {
-	var err error
+	c, ok := t.Claims.(*authClaims)
+	if !ok {
+		l.Warn("ошибка получения полей токена")
 
-	if errMsg != "" {
-		err = fmt.Errorf(errMsg)
+		return 0, apperr.Unauthenticated(domain.CodeTokenInvalid)
 	}
 
-	resCh <- ParseTokenResult{
-		AccountId: resp,
-		Error:     err,
-	}
+	return c.AccountId, nil
 }
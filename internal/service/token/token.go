@@ -4,24 +4,33 @@ import (
 	"context"
 	"go.uber.org/zap"
 	"practice_vgpek/internal/model/entity"
+	"time"
 )
 
 type AccountDAO interface {
 	ByLogin(ctx context.Context, login string) (entity.Account, error)
 }
 
+// Config настройки JWT токенов
+type Config struct {
+	// SigningKey ключ HMAC для подписи токенов
+	SigningKey string
+	// TTL срок действия токена
+	TTL time.Duration
+}
+
 type Service struct {
 	accountDAO AccountDAO
 
 	logger *zap.Logger
 
-	signingKey string
+	cfg Config
 }
 
-func New(accountDAO AccountDAO, key string, logger *zap.Logger) Service {
+func New(accountDAO AccountDAO, cfg Config, logger *zap.Logger) Service {
 	return Service{
 		accountDAO: accountDAO,
 		logger:     logger,
-		signingKey: key,
+		cfg:        cfg,
 	}
 }
@@ -0,0 +1,313 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/internal/model/layer"
+	"practice_vgpek/internal/model/operation"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/ioutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/rndutils"
+	"practice_vgpek/pkg/sizeutils"
+	"practice_vgpek/pkg/tracing"
+	"sync"
+	"time"
+)
+
+// Create создает загрузку файла заранее известного размера. Размер и квота проверяются сразу,
+// чтобы не принимать данные файла, который все равно не удастся сохранить
+func (s Service) Create(ctx context.Context, req dto.NewUploadReq) (domain.UploadSession, error) {
+	ctx, span := tracing.Start(ctx, "upload.Create")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CreateUploadOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	accountId := ctx.Value("AccountId").(int)
+
+	if req.Length <= 0 {
+		return domain.UploadSession{}, apperr.Validation(domain.CodeFieldRequired).WithDetails(map[string]any{"field": "Upload-Length"})
+	}
+
+	if req.Length > s.cfg.ResumableMaxSize {
+		return domain.UploadSession{}, fmt.Errorf("%w %s", domain.ErrFileTooLarge, sizeutils.Format(s.cfg.ResumableMaxSize))
+	}
+
+	err := s.quota.Check(ctx, accountId, req.Length)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	err = os.MkdirAll(s.cfg.Root, 0o700)
+	if err != nil {
+		l.Error("ошибка создания каталога загрузок", zap.Error(err))
+		return domain.UploadSession{}, err
+	}
+
+	id := rndutils.RandString(32)
+
+	f, err := os.OpenFile(s.path(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		l.Error("ошибка создания файла загрузки", zap.Error(err))
+		return domain.UploadSession{}, err
+	}
+	_ = f.Close()
+
+	session, err := s.uploadDAO.Save(ctx, dto.NewUploadSession{
+		Id:        id,
+		AccountId: accountId,
+		FileName:  filepath.Base(req.FileName),
+		Length:    req.Length,
+		ExpiresAt: time.Now().Add(s.cfg.TTL),
+	})
+	if err != nil {
+		_ = os.Remove(s.path(id))
+		return domain.UploadSession{}, err
+	}
+
+	l.Info("создана загрузка",
+		zap.String("id загрузки", session.Id),
+		zap.Int("id аккаунта", accountId),
+		zap.Int64("размер", session.Length),
+	)
+
+	return sessionEntityToDomain(session), nil
+}
+
+// Session возвращает состояние загрузки текущего аккаунта
+func (s Service) Session(ctx context.Context, req dto.UploadId) (domain.UploadSession, error) {
+	ctx, span := tracing.Start(ctx, "upload.Session")
+	defer span.End()
+
+	session, err := s.owned(ctx, req.Id)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	return sessionEntityToDomain(session), nil
+}
+
+// Append дописывает часть файла с переданного смещения. Принятые до обрыва соединения данные
+// сохраняются, и клиент продолжает загрузку с нового смещения
+func (s Service) Append(ctx context.Context, req dto.UploadChunkReq) (domain.UploadSession, error) {
+	ctx, span := tracing.Start(ctx, "upload.Append")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.AppendUploadOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+		zap.String("id загрузки", req.Id),
+	)
+
+	lock, _ := s.locks.LoadOrStore(req.Id, &sync.Mutex{})
+	if !lock.(*sync.Mutex).TryLock() {
+		return domain.UploadSession{}, domain.ErrUploadBusy
+	}
+	defer lock.(*sync.Mutex).Unlock()
+
+	session, err := s.owned(ctx, req.Id)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	if req.Offset != session.Offset {
+		return domain.UploadSession{}, fmt.Errorf("%w: принято %d байт", domain.ErrUploadOffset, session.Offset)
+	}
+
+	f, err := os.OpenFile(s.path(session.Id), os.O_WRONLY, 0o600)
+	if err != nil {
+		l.Error("ошибка открытия файла загрузки", zap.Error(err))
+		return domain.UploadSession{}, err
+	}
+	defer f.Close()
+
+	// Данные, записанные после последнего подтвержденного смещения, отбрасываются
+	err = f.Truncate(session.Offset)
+	if err == nil {
+		_, err = f.Seek(session.Offset, io.SeekStart)
+	}
+	if err != nil {
+		l.Error("ошибка подготовки файла загрузки", zap.Error(err))
+		return domain.UploadSession{}, err
+	}
+
+	remaining := session.Length - session.Offset
+
+	// Читается на байт больше оставшегося, чтобы отличить часть, выходящую за заявленный размер
+	written, copyErr := io.Copy(f, io.LimitReader(ioutils.NewContextReader(ctx, req.Body), remaining+1))
+	if written > remaining {
+		return domain.UploadSession{}, fmt.Errorf("%w: часть выходит за заявленный размер загрузки", domain.ErrFileTooLarge)
+	}
+
+	if written > 0 {
+		err = f.Sync()
+		if err != nil {
+			l.Error("ошибка записи файла загрузки", zap.Error(err))
+			return domain.UploadSession{}, err
+		}
+
+		// Отдельный контекст нужен, чтобы смещение сохранилось и при отмене запроса из-за обрыва соединения
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		err = s.uploadDAO.UpdateOffset(saveCtx, session.Id, session.Offset, session.Offset+written)
+		if err != nil {
+			l.Error("ошибка сохранения смещения загрузки", zap.Error(err))
+			return domain.UploadSession{}, err
+		}
+
+		session.Offset += written
+	}
+
+	if copyErr != nil {
+		l.Info("прием части загрузки прерван",
+			zap.Int64("принято", written),
+			zap.Int64("смещение", session.Offset),
+			zap.Error(copyErr),
+		)
+
+		return sessionEntityToDomain(session), copyErr
+	}
+
+	return sessionEntityToDomain(session), nil
+}
+
+// Open открывает завершенную загрузку текущего аккаунта для сохранения файла
+func (s Service) Open(ctx context.Context, req dto.UploadId) (domain.File, error) {
+	ctx, span := tracing.Start(ctx, "upload.Open")
+	defer span.End()
+
+	session, err := s.owned(ctx, req.Id)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if session.Offset != session.Length {
+		return domain.File{}, fmt.Errorf("%w: принято %d из %d байт", domain.ErrUploadIncomplete, session.Offset, session.Length)
+	}
+
+	// Допустимый размер мог уменьшиться после создания загрузки
+	if session.Length > s.cfg.ResumableMaxSize {
+		return domain.File{}, fmt.Errorf("%w %s", domain.ErrFileTooLarge, sizeutils.Format(s.cfg.ResumableMaxSize))
+	}
+
+	f, err := os.Open(s.path(session.Id))
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	return domain.File{
+		Name:    session.FileName,
+		Size:    session.Length,
+		ModTime: session.UpdatedAt,
+		Content: f,
+	}, nil
+}
+
+// Delete удаляет загрузку текущего аккаунта вместе с принятыми данными
+func (s Service) Delete(ctx context.Context, req dto.UploadId) error {
+	ctx, span := tracing.Start(ctx, "upload.Delete")
+	defer span.End()
+
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.DeleteUploadOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+		zap.String("id загрузки", req.Id),
+	)
+
+	session, err := s.owned(ctx, req.Id)
+	if err != nil {
+		return err
+	}
+
+	err = s.uploadDAO.Delete(ctx, session.Id)
+	if err != nil {
+		return err
+	}
+
+	s.remove(l, session.Id)
+
+	return nil
+}
+
+// Run удаляет истекшие загрузки до отмены контекста
+func (s Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		s.clean(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s Service) clean(ctx context.Context) {
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String(operation.Operation, operation.CleanUploadsOperation),
+		zap.String(layer.Layer, layer.ServiceLayer),
+	)
+
+	ids, err := s.uploadDAO.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		l.Warn("ошибка удаления истекших загрузок", zap.Error(err))
+		return
+	}
+
+	for _, id := range ids {
+		s.remove(l, id)
+	}
+
+	if len(ids) != 0 {
+		l.Info("удалены истекшие загрузки", zap.Int("кол-во", len(ids)))
+	}
+}
+
+// owned возвращает действующую загрузку, если она принадлежит текущему аккаунту.
+// Чужая загрузка не отличается от несуществующей
+func (s Service) owned(ctx context.Context, id string) (entity.UploadSession, error) {
+	accountId := ctx.Value("AccountId").(int)
+
+	session, err := s.uploadDAO.ById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.UploadSession{}, domain.ErrUploadNotFound
+	}
+	if err != nil {
+		return entity.UploadSession{}, err
+	}
+
+	if session.AccountId != accountId || session.ExpiresAt.Before(time.Now()) {
+		return entity.UploadSession{}, domain.ErrUploadNotFound
+	}
+
+	return session, nil
+}
+
+func (s Service) remove(l *zap.Logger, id string) {
+	s.locks.Delete(id)
+
+	err := os.Remove(s.path(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.Warn("ошибка удаления файла загрузки", zap.String("id загрузки", id), zap.Error(err))
+	}
+}
+
+func (s Service) path(id string) string {
+	return filepath.Join(s.cfg.Root, id)
+}
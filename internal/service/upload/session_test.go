@@ -0,0 +1,89 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/goleak"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"testing"
+	"time"
+)
+
+// expiredDAO при каждой очистке возвращает истекшие загрузки ids и сообщает об очистке
+type expiredDAO struct {
+	ids     []string
+	cleaned chan struct{}
+}
+
+func (d expiredDAO) Save(context.Context, dto.NewUploadSession) (entity.UploadSession, error) {
+	return entity.UploadSession{}, errors.New("не используется")
+}
+
+func (d expiredDAO) ById(context.Context, string) (entity.UploadSession, error) {
+	return entity.UploadSession{}, errors.New("не используется")
+}
+
+func (d expiredDAO) UpdateOffset(context.Context, string, int64, int64) error {
+	return errors.New("не используется")
+}
+
+func (d expiredDAO) Delete(context.Context, string) error {
+	return errors.New("не используется")
+}
+
+func (d expiredDAO) DeleteExpired(context.Context, time.Time) ([]string, error) {
+	select {
+	case d.cleaned <- struct{}{}:
+	default:
+	}
+
+	return d.ids, nil
+}
+
+// TestRunStopsOnCancel проверяет, что Run удаляет файлы истекших загрузок и после отмены контекста
+// возвращается, не оставив горутин
+func TestRunStopsOnCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	root := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(root, "expired"), []byte("часть файла"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dao := expiredDAO{ids: []string{"expired"}, cleaned: make(chan struct{}, 1)}
+	s := New(dao, nil, Config{Root: root, TTL: time.Hour}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx)
+	}()
+
+	select {
+	case <-dao.cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("очистка загрузок не запустилась")
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run не вернулся после отмены контекста")
+	}
+
+	_, err = os.Stat(filepath.Join(root, "expired"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("файл истекшей загрузки не удален: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package upload
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"sync"
+	"time"
+)
+
+type UploadDAO interface {
+	Save(ctx context.Context, data dto.NewUploadSession) (entity.UploadSession, error)
+	ById(ctx context.Context, id string) (entity.UploadSession, error)
+	UpdateOffset(ctx context.Context, id string, from, to int64) error
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, at time.Time) ([]string, error)
+}
+
+// QuotaChecker проверяет, что файл поместится в квоты аккаунта
+type QuotaChecker interface {
+	Check(ctx context.Context, accountId int, size int64) error
+}
+
+// Config настройки загрузки файлов
+type Config struct {
+	// MaxSize допустимый размер файла, загружаемого формой
+	MaxSize int64
+	// ResumableMaxSize допустимый размер файла возобновляемой загрузки
+	ResumableMaxSize int64
+	// Root каталог для данных незавершенных загрузок
+	Root string
+	// TTL время, за которое загрузку нужно завершить и использовать
+	TTL time.Duration
+}
+
+type Service struct {
+	logger *zap.Logger
+
+	uploadDAO UploadDAO
+	quota     QuotaChecker
+
+	cfg Config
+
+	// locks не дает одновременно писать в одну загрузку из нескольких запросов
+	locks *sync.Map
+}
+
+func New(uploadDAO UploadDAO, quota QuotaChecker, cfg Config, logger *zap.Logger) Service {
+	return Service{
+		logger:    logger,
+		uploadDAO: uploadDAO,
+		quota:     quota,
+		cfg:       cfg,
+		locks:     &sync.Map{},
+	}
+}
+
+// MaxSize возвращает допустимый размер файла, загружаемого формой
+func (s Service) MaxSize() int64 {
+	return s.cfg.MaxSize
+}
+
+// ResumableMaxSize возвращает допустимый размер файла возобновляемой загрузки
+func (s Service) ResumableMaxSize() int64 {
+	return s.cfg.ResumableMaxSize
+}
+
+func sessionEntityToDomain(session entity.UploadSession) domain.UploadSession {
+	return domain.UploadSession{
+		Id:        session.Id,
+		FileName:  session.FileName,
+		Length:    session.Length,
+		Offset:    session.Offset,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
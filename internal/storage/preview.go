@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/pkg/converter"
+	"practice_vgpek/pkg/logger"
+	"strings"
+	"sync"
+)
+
+// PreviewConfig настройки предпросмотра документов
+type PreviewConfig struct {
+	Converter converter.Converter
+	// ThumbnailWidth ширина изображения первой страницы в пикселях
+	ThumbnailWidth int
+}
+
+// previewStripes число блокировок построения предпросмотра
+const previewStripes = 64
+
+// previewTypes расширения документов, для которых строится предпросмотр
+var previewTypes = map[string]bool{
+	".doc":  true,
+	".docx": true,
+	".odt":  true,
+	".rtf":  true,
+	".pdf":  true,
+}
+
+// Preview открывает предпросмотр сохраненного файла вида kind. Предпросмотр строится при первом обращении
+// и хранится рядом с исходным файлом, пока исходный файл не изменится. В квоты предпросмотр не входит,
+// так как его всегда можно построить заново
+func (s Storage) Preview(ctx context.Context, path, kind string) (domain.File, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !previewTypes[ext] {
+		return domain.File{}, domain.ErrPreviewUnsupported
+	}
+
+	var format string
+
+	switch kind {
+	case domain.PreviewPDF:
+		// PDF выдается без преобразования
+		if ext == ".pdf" {
+			return s.File(ctx, path)
+		}
+
+		format = converter.PDF
+	case domain.PreviewThumbnail:
+		format = converter.PNG
+	default:
+		return domain.File{}, domain.ErrPreviewUnsupported
+	}
+
+	// Зараженный файл не должен попасть ни в конвертер, ни в предпросмотр
+	err := s.verify(ctx, path)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	cachePath := path + ".preview." + format
+
+	// Один и тот же предпросмотр строится одним запросом, остальные дожидаются результата
+	lock := s.previewLock(cachePath)
+	lock.Lock()
+	defer lock.Unlock()
+
+	fresh, err := previewFresh(s.abs(path), s.abs(cachePath))
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if !fresh {
+		err = s.renderPreview(ctx, path, cachePath, format)
+		if err != nil {
+			return domain.File{}, err
+		}
+	}
+
+	f, err := os.Open(s.abs(cachePath))
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return domain.File{}, err
+	}
+
+	return domain.File{
+		Name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + "." + format,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Content: f,
+	}, nil
+}
+
+// previewLock возвращает блокировку построения предпросмотра. Блокировок фиксированное число,
+// поэтому они не копятся по числу файлов, а разные предпросмотры изредка ждут друг друга
+func (s Storage) previewLock(cachePath string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cachePath))
+
+	return &s.previewLocks[h.Sum32()%previewStripes]
+}
+
+// renderPreview преобразует файл во временный файл в том же каталоге и переносит его на место предпросмотра,
+// чтобы недописанный предпросмотр никогда не выдавался
+func (s Storage) renderPreview(ctx context.Context, path, cachePath, format string) error {
+	l := logger.FromContext(ctx, s.logger).With(
+		zap.String("путь к файлу", path),
+		zap.String("формат", format),
+		zap.String("конвертер", s.converter.Name()),
+	)
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.abs(path)), ".preview-*")
+	if err != nil {
+		return err
+	}
+	_ = tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	err = s.converter.Convert(ctx, s.abs(path), tmp.Name(), format)
+	if errors.Is(err, converter.ErrUnsupported) {
+		return domain.ErrPreviewUnsupported
+	}
+	if err != nil {
+		l.Warn("ошибка построения предпросмотра", zap.Error(err))
+		return fmt.Errorf("%w: %v", domain.ErrPreviewFailed, err)
+	}
+
+	if format == converter.PNG {
+		err = converter.Shrink(tmp.Name(), s.thumbnailWidth)
+		if err != nil {
+			l.Warn("ошибка уменьшения изображения предпросмотра", zap.Error(err))
+			return fmt.Errorf("%w: %v", domain.ErrPreviewFailed, err)
+		}
+	}
+
+	err = os.Rename(tmp.Name(), s.abs(cachePath))
+	if err != nil {
+		return err
+	}
+
+	l.Info("построен предпросмотр файла")
+
+	return nil
+}
+
+// previewFresh проверяет, что предпросмотр уже построен и не старше исходного файла
+func previewFresh(path, cachePath string) (bool, error) {
+	source, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	cached, err := os.Stat(cachePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !cached.ModTime().Before(source.ModTime()), nil
+}
@@ -2,32 +2,442 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
 	"io"
-	"mime/multipart"
 	"os"
+	"path/filepath"
+	"practice_vgpek/internal/model/domain"
+	"practice_vgpek/internal/model/dto"
+	"practice_vgpek/internal/model/entity"
+	"practice_vgpek/pkg/antivirus"
+	"practice_vgpek/pkg/converter"
+	"practice_vgpek/pkg/ioutils"
+	"practice_vgpek/pkg/logger"
+	"practice_vgpek/pkg/metrics"
+	"practice_vgpek/pkg/sizeutils"
+	"sync"
+	"time"
 )
 
+// Каталоги файлов заданий и сданных работ
+const (
+	IssuedRoot = "issued"
+	SolvedRoot = "solved"
+)
+
+// quarantineRoot каталог для зараженных файлов, из него файлы не выдаются
+const quarantineRoot = "quarantine"
+
+type FileScanDAO interface {
+	Save(ctx context.Context, data dto.NewFileScan) error
+	ByPath(ctx context.Context, path string) (entity.FileScan, error)
+}
+
+type FileDigestDAO interface {
+	Save(ctx context.Context, data dto.NewFileDigest) error
+	ByPath(ctx context.Context, path string) (entity.FileDigest, error)
+}
+
+type AuditDAO interface {
+	Save(ctx context.Context, data dto.NewAuditLog) error
+}
+
+// QuotaTracker проверяет квоты и учитывает занятое файлами место
+type QuotaTracker interface {
+	Check(ctx context.Context, accountId int, size int64) error
+	Track(ctx context.Context, accountId int, path string, size int64) error
+	Release(ctx context.Context, paths []string) error
+}
+
+// Config настройки хранилища файлов
+type Config struct {
+	// Root каталог, в котором лежат каталоги файлов заданий, сданных работ и карантин
+	Root    string
+	Preview PreviewConfig
+}
+
 type Storage struct {
+	logger *zap.Logger
+
+	scanner   antivirus.Scanner
+	converter converter.Converter
+	quota     QuotaTracker
+
+	scanDAO   FileScanDAO
+	digestDAO FileDigestDAO
+	auditDAO  AuditDAO
+
+	// root каталог, относительно которого лежат файлы. Пути файлов в базе данных
+	// хранятся относительно него, поэтому каталог можно перенести, не меняя записей
+	root string
+
+	maxFileSize int64
+	// maxUploadedSize допустимый размер файла возобновляемой загрузки
+	maxUploadedSize int64
+
+	// thumbnailWidth ширина изображения первой страницы в предпросмотре
+	thumbnailWidth int
+	// previewLocks не дает строить один предпросмотр одновременно в нескольких запросах
+	previewLocks *[previewStripes]sync.Mutex
 }
 
-func NewFileStorage() Storage {
-	return Storage{}
+func NewFileStorage(scanner antivirus.Scanner, converter converter.Converter, quota QuotaTracker, scanDAO FileScanDAO,
+	digestDAO FileDigestDAO, auditDAO AuditDAO, root string, maxFileSize, maxUploadedSize int64, thumbnailWidth int, logger *zap.Logger) Storage {
+	return Storage{
+		logger:          logger,
+		root:            root,
+		maxFileSize:     maxFileSize,
+		maxUploadedSize: maxUploadedSize,
+		thumbnailWidth:  thumbnailWidth,
+		scanner:         scanner,
+		converter:       converter,
+		quota:           quota,
+		scanDAO:         scanDAO,
+		digestDAO:       digestDAO,
+		auditDAO:        auditDAO,
+		previewLocks:    &[previewStripes]sync.Mutex{},
+	}
 }
 
-func (s Storage) SaveFile(ctx context.Context, file *multipart.File, root, ext, name string) (string, error) {
+// MakeDirs создает каталоги файлов в корне хранилища root
+func MakeDirs(root string) error {
+	for _, dir := range []string{IssuedRoot, SolvedRoot, quarantineRoot} {
+		err := os.MkdirAll(filepath.Join(root, dir), 0o700)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// abs возвращает путь к файлу на диске по пути из базы данных
+func (s Storage) abs(path string) string {
+	return filepath.Join(s.root, path)
+}
+
+// Writable проверяет, что в каталоге dir можно создать файл
+func (s Storage) Writable(dir string) error {
+	f, err := os.CreateTemp(dir, ".readyz-*")
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(f.Name())
+}
+
+// SaveFile читает файл из потока, проверяет квоту загружающего аккаунта и файл антивирусом, затем сохраняет его.
+// Чтение прерывается, как только файл превысил допустимый размер, такой файл отклоняется с domain.ErrFileTooLarge.
+// Файл сверх квоты отклоняется с domain.ErrQuotaExceeded, зараженный переносится в карантин,
+// а вызывающий получает domain.ErrFileInfected
+func (s Storage) SaveFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error) {
+	return s.save(ctx, file, root, ext, name, s.maxFileSize)
+}
+
+// SaveUploadedFile сохраняет файл завершенной возобновляемой загрузки так же, как SaveFile,
+// но с допустимым размером возобновляемой загрузки
+func (s Storage) SaveUploadedFile(ctx context.Context, file io.Reader, root, ext, name string) (domain.StoredFile, error) {
+	return s.save(ctx, file, root, ext, name, s.maxUploadedSize)
+}
+
+func (s Storage) save(ctx context.Context, file io.Reader, root, ext, name string, maxSize int64) (domain.StoredFile, error) {
 	path := fmt.Sprintf("%s/%s%s", root, name, ext)
 
-	f, err := os.Create(path)
+	// Файл пишется во временный в том же каталоге, чтобы до проверки он не был доступен по итоговому пути,
+	// а после проверки переносился без копирования
+	tmp, err := os.CreateTemp(s.abs(root), ".upload-*")
+	if err != nil {
+		return domain.StoredFile{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+
+	// Читается на байт больше лимита, чтобы отличить файл допустимого размера от большего
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(ioutils.NewContextReader(ctx, file), maxSize+1))
+	if err != nil {
+		return domain.StoredFile{}, err
+	}
+
+	metrics.UploadBytes.WithLabelValues(root).Add(float64(size))
+
+	if size > maxSize {
+		return domain.StoredFile{}, fmt.Errorf("%w %s", domain.ErrFileTooLarge, sizeutils.Format(maxSize))
+	}
+
+	// Размер известен только после чтения файла, поэтому квота проверяется до антивируса
+	accountId, _ := ctx.Value("AccountId").(int)
+
+	if accountId != 0 {
+		err = s.quota.Check(ctx, accountId, size)
+		if err != nil {
+			return domain.StoredFile{}, err
+		}
+	}
+
+	_, err = tmp.Seek(0, io.SeekStart)
+	if err != nil {
+		return domain.StoredFile{}, err
+	}
+
+	verdict, err := s.scanner.Scan(ctx, tmp)
+	if err != nil {
+		s.audit(ctx, domain.AuditFileScanError, path, err.Error())
+
+		return domain.StoredFile{}, fmt.Errorf("%w: %v", domain.ErrFileNotScanned, err)
+	}
+
+	if verdict.Infected {
+		return domain.StoredFile{}, s.quarantine(ctx, tmp, path, verdict)
+	}
+
+	err = tmp.Close()
 	if err != nil {
+		return domain.StoredFile{}, err
+	}
+
+	err = os.Rename(tmp.Name(), s.abs(path))
+	if err != nil {
+		return domain.StoredFile{}, err
+	}
+
+	s.saveVerdict(ctx, path, verdict)
+
+	if accountId != 0 {
+		err = s.quota.Track(ctx, accountId, path, size)
+		if err != nil {
+			logger.FromContext(ctx, s.logger).Warn("ошибка учета занятого места", zap.String("путь к файлу", path), zap.Error(err))
+		}
+	}
+
+	stored := domain.StoredFile{
+		Path: path,
+		Size: size,
+		Hash: hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	s.saveDigest(ctx, stored)
+
+	return stored, nil
+}
+
+// Discard удаляет сохраненный файл, запись о котором не удалось сохранить, и освобождает занятое им место.
+// Вызывается и после отмены запроса, поэтому выполняется в отдельном контексте
+func (s Storage) Discard(ctx context.Context, path string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	l := logger.FromContext(ctx, s.logger).With(zap.String("путь к файлу", path))
+
+	err := s.quota.Release(ctx, []string{path})
+	if err != nil {
+		l.Warn("ошибка освобождения занятого места", zap.Error(err))
+	}
+
+	err = os.Remove(s.abs(path))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.Warn("ошибка удаления файла", zap.Error(err))
+	}
+}
+
+// Open открывает сохраненный файл на чтение
+func (s Storage) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	file, err := s.File(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return file.Content, nil
+}
+
+// File открывает сохраненный файл для выдачи, если антивирус признал его чистым.
+// Файлы, сохраненные до включения проверки или другим сканером, проверяются при первом обращении
+func (s Storage) File(ctx context.Context, path string) (domain.File, error) {
+	err := s.verify(ctx, path)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	f, err := os.Open(s.abs(path))
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return domain.File{}, err
+	}
+
+	hash, err := s.digest(ctx, path, f, info.Size())
+	if err != nil {
+		_ = f.Close()
+		return domain.File{}, err
+	}
+
+	return domain.File{
+		Name:    filepath.Base(path),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Hash:    hash,
+		Content: f,
+	}, nil
+}
+
+// digest возвращает хэш содержимого файла. Для файлов, сохраненных до учета хэшей или измененных
+// после сохранения, хэш считается заново, а чтение файла возвращается в начало
+func (s Storage) digest(ctx context.Context, path string, f io.ReadSeeker, size int64) (string, error) {
+	saved, err := s.digestDAO.ByPath(ctx, path)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return "", err
 	}
-	defer f.Close()
 
-	_, err = io.Copy(f, *file)
+	if err == nil && saved.Size == size {
+		return saved.Hash, nil
+	}
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, f)
 	if err != nil {
 		return "", err
 	}
 
-	return path, nil
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	stored := domain.StoredFile{
+		Path: path,
+		Size: size,
+		Hash: hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	s.saveDigest(ctx, stored)
+
+	return stored.Hash, nil
+}
+
+func (s Storage) verify(ctx context.Context, path string) error {
+	scan, err := s.scanDAO.ByPath(ctx, path)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	if err == nil && scan.Scanner == s.scanner.Name() {
+		if scan.IsInfected {
+			return domain.ErrFileInfected
+		}
+
+		return nil
+	}
+
+	f, err := os.Open(s.abs(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	verdict, err := s.scanner.Scan(ctx, f)
+	if err != nil {
+		s.audit(ctx, domain.AuditFileScanError, path, err.Error())
+
+		return fmt.Errorf("%w: %v", domain.ErrFileNotScanned, err)
+	}
+
+	s.saveVerdict(ctx, path, verdict)
+
+	if verdict.Infected {
+		// Файл уже связан с записью в базе, поэтому не переносится, а только перестает выдаваться
+		s.audit(ctx, domain.AuditFileInfected, path, verdict.Signature)
+
+		return domain.ErrFileInfected
+	}
+
+	return nil
+}
+
+// quarantine переносит зараженный файл в карантин и записывает событие в журнал аудита
+func (s Storage) quarantine(ctx context.Context, tmp *os.File, path string, verdict antivirus.Verdict) error {
+	l := s.logger.With(zap.String("путь к файлу", path), zap.String("сигнатура", verdict.Signature))
+
+	quarantinePath := filepath.Join(quarantineRoot, filepath.Base(filepath.Dir(path))+"_"+filepath.Base(path))
+
+	err := tmp.Close()
+	if err == nil {
+		err = os.MkdirAll(s.abs(quarantineRoot), 0o700)
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.abs(quarantinePath))
+	}
+
+	// Если перенести не удалось, временный файл будет удален, что тоже не дает его выдать
+	if err != nil {
+		l.Error("ошибка переноса файла в карантин", zap.Error(err))
+	} else {
+		s.saveVerdict(ctx, quarantinePath, verdict)
+	}
+
+	l.Warn("загружен зараженный файл", zap.String("карантин", quarantinePath))
+
+	s.audit(ctx, domain.AuditFileInfected, path, verdict.Signature)
+
+	return fmt.Errorf("%w: %s", domain.ErrFileInfected, verdict.Signature)
+}
+
+// saveVerdict сохраняет вердикт. При ошибке файл будет проверен повторно при выдаче, поэтому она только логируется
+func (s Storage) saveVerdict(ctx context.Context, path string, verdict antivirus.Verdict) {
+	err := s.scanDAO.Save(ctx, dto.NewFileScan{
+		Path:       path,
+		IsInfected: verdict.Infected,
+		Signature:  verdict.Signature,
+		Scanner:    s.scanner.Name(),
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("ошибка сохранения вердикта антивируса", zap.String("путь к файлу", path), zap.Error(err))
+	}
+}
+
+// saveDigest сохраняет хэш файла. При ошибке хэш будет посчитан заново при выдаче, поэтому она только логируется
+func (s Storage) saveDigest(ctx context.Context, file domain.StoredFile) {
+	err := s.digestDAO.Save(ctx, dto.NewFileDigest{
+		Path: file.Path,
+		Hash: file.Hash,
+		Size: file.Size,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn("ошибка сохранения хэша файла", zap.String("путь к файлу", file.Path), zap.Error(err))
+	}
+}
+
+func (s Storage) audit(ctx context.Context, event, object, details string) {
+	// В фоновых задачах аккаунта в контексте нет, событие записывается как системное
+	accountId, _ := ctx.Value("AccountId").(int)
+
+	err := s.auditDAO.Save(ctx, dto.NewAuditLog{
+		AccountId: accountId,
+		Event:     event,
+		Object:    object,
+		Details:   details,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Error("ошибка записи в журнал аудита",
+			zap.String("событие", event),
+			zap.String("объект", object),
+			zap.Error(err),
+		)
+	}
 }
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS account_group (
+    account_group_id serial PRIMARY KEY NOT NULL,
+    account_id integer NOT NULL REFERENCES account(account_id),
+    group_name varchar NOT NULL,
+    effective_from timestamp NOT NULL DEFAULT now(),
+    effective_to timestamp DEFAULT NULL,
+    reason varchar NOT NULL DEFAULT ''
+);
+
+-- Текущая группа каждого аккаунта берется из ключа, по которому он был зарегистрирован
+INSERT INTO account_group (account_id, group_name, effective_from, reason)
+SELECT a.account_id, rk.group_name, a.created_at, 'регистрация'
+FROM account a
+         JOIN registration_key rk ON a.reg_key_id = rk.reg_key_id;
+
+-- Работа остается привязанной к группе, в которой студент был в момент сдачи
+ALTER TABLE solved_practice ADD IF NOT EXISTS group_name varchar NOT NULL DEFAULT 'unknown';
+
+UPDATE solved_practice sp
+SET group_name = rk.group_name
+FROM account a
+         JOIN registration_key rk ON a.reg_key_id = rk.reg_key_id
+WHERE sp.performed_account_id = a.account_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE solved_practice DROP COLUMN IF EXISTS group_name;
+DROP TABLE IF EXISTS account_group;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS discipline (
+    discipline_id serial PRIMARY KEY NOT NULL,
+    discipline_name varchar NOT NULL UNIQUE,
+    description varchar NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT now(),
+    is_deleted timestamp DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS teaching_assignment (
+    teaching_assignment_id serial PRIMARY KEY NOT NULL,
+    account_id integer NOT NULL REFERENCES account(account_id),
+    discipline_id integer NOT NULL REFERENCES discipline(discipline_id),
+    group_name varchar NOT NULL,
+    term varchar NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now(),
+    is_deleted timestamp DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS teaching_assignment_unique_idx
+    ON teaching_assignment (account_id, discipline_id, group_name, term) WHERE is_deleted IS NULL;
+
+ALTER TABLE issued_practice ADD IF NOT EXISTS discipline_id integer REFERENCES discipline(discipline_id);
+
+INSERT INTO internal_role (role_name, description)
+VALUES ('ADMIN', 'Роль администратора, назначает преподавателям дисциплины и группы')
+ON CONFLICT (role_name) DO NOTHING;
+
+INSERT INTO internal_object (internal_object_name, description)
+VALUES ('DISCIPLINE', 'Объект для работы с дисциплинами и назначениями преподавателей');
+
+-- Администратор получает все доступы
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'ADMIN';
+
+-- Преподаватель может только просматривать свои дисциплины
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'TEACHER' AND a.internal_action_name = 'GET' AND o.internal_object_name = 'DISCIPLINE';
+
+INSERT INTO registration_key
+    (internal_role_id, body_key, max_count_usages, current_count_usages, created_at)
+SELECT internal_role_id, 'example_admin', 1, 0, now()
+FROM internal_role
+WHERE role_name = 'ADMIN';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM registration_key WHERE body_key = 'example_admin';
+DELETE FROM role_permission
+WHERE internal_object_id IN (SELECT internal_object_id FROM internal_object WHERE internal_object_name = 'DISCIPLINE')
+   OR internal_role_id IN (SELECT internal_role_id FROM internal_role WHERE role_name = 'ADMIN');
+DELETE FROM internal_object WHERE internal_object_name = 'DISCIPLINE';
+ALTER TABLE issued_practice DROP COLUMN IF EXISTS discipline_id;
+DROP TABLE IF EXISTS teaching_assignment;
+DROP TABLE IF EXISTS discipline;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS academic_term (
+    academic_term_id serial PRIMARY KEY NOT NULL,
+    term_name varchar NOT NULL UNIQUE,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    is_current boolean NOT NULL DEFAULT false,
+    created_at timestamp NOT NULL DEFAULT now(),
+    closed_at timestamp DEFAULT NULL,
+    CHECK (start_date <= end_date)
+);
+
+-- Текущим может быть только один семестр
+CREATE UNIQUE INDEX IF NOT EXISTS academic_term_current_idx ON academic_term (is_current) WHERE is_current;
+
+-- Семестры, уже использованные в назначениях преподавателей, переносим в справочник
+INSERT INTO academic_term (term_name, start_date, end_date)
+SELECT term, min(created_at)::date, (min(created_at) + interval '6 months')::date
+FROM teaching_assignment
+GROUP BY term
+ON CONFLICT (term_name) DO NOTHING;
+
+ALTER TABLE teaching_assignment
+    ADD CONSTRAINT teaching_assignment_term_fk FOREIGN KEY (term) REFERENCES academic_term(term_name);
+
+ALTER TABLE issued_practice ADD IF NOT EXISTS academic_term_id integer REFERENCES academic_term(academic_term_id);
+
+CREATE INDEX IF NOT EXISTS issued_practice_term_idx ON issued_practice (academic_term_id);
+
+INSERT INTO internal_object (internal_object_name, description)
+VALUES ('TERM', 'Объект для работы с учебными семестрами');
+
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'ADMIN' AND o.internal_object_name = 'TERM';
+
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'TEACHER' AND a.internal_action_name = 'GET' AND o.internal_object_name = 'TERM';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM role_permission
+WHERE internal_object_id IN (SELECT internal_object_id FROM internal_object WHERE internal_object_name = 'TERM');
+DELETE FROM internal_object WHERE internal_object_name = 'TERM';
+ALTER TABLE issued_practice DROP COLUMN IF EXISTS academic_term_id;
+ALTER TABLE teaching_assignment DROP CONSTRAINT IF EXISTS teaching_assignment_term_fk;
+DROP TABLE IF EXISTS academic_term;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Предыдущие версии файла практического задания, сохраняются при замене файла
+CREATE TABLE IF NOT EXISTS issued_practice_file_version (
+    file_version_id serial PRIMARY KEY NOT NULL,
+    issued_practice_id integer NOT NULL REFERENCES issued_practice(issued_practice_id),
+    practice_path varchar NOT NULL,
+    replaced_by integer NOT NULL REFERENCES account(account_id),
+    replaced_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS issued_practice_file_version_practice_idx ON issued_practice_file_version (issued_practice_id);
+
+CREATE TABLE IF NOT EXISTS notification (
+    notification_id serial PRIMARY KEY NOT NULL,
+    account_id integer NOT NULL REFERENCES account(account_id),
+    message varchar NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now(),
+    read_at timestamp DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notification_account_idx ON notification (account_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS notification;
+DROP TABLE IF EXISTS issued_practice_file_version;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE issued_practice ADD IF NOT EXISTS deadline timestamp DEFAULT NULL;
+
+-- Клонированное задание ссылается на исходное, файл при этом не копируется
+ALTER TABLE issued_practice ADD IF NOT EXISTS cloned_from integer REFERENCES issued_practice(issued_practice_id);
+
+CREATE INDEX IF NOT EXISTS issued_practice_discipline_term_idx ON issued_practice (discipline_id, academic_term_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS issued_practice_discipline_term_idx;
+ALTER TABLE issued_practice DROP COLUMN IF EXISTS cloned_from;
+ALTER TABLE issued_practice DROP COLUMN IF EXISTS deadline;
+-- +goose StatementEnd
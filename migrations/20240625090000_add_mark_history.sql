@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS mark_history (
+    mark_history_id serial PRIMARY KEY NOT NULL,
+    solved_practice_id integer NOT NULL REFERENCES solved_practice(solved_practice_id),
+    old_mark integer NOT NULL,
+    new_mark integer NOT NULL,
+    changed_by integer NOT NULL REFERENCES account(account_id),
+    changed_at timestamp NOT NULL DEFAULT now(),
+    -- manual - оценка выставлена вручную, import - загружена из таблицы
+    source varchar NOT NULL DEFAULT 'manual'
+);
+
+CREATE INDEX IF NOT EXISTS mark_history_solved_practice_idx ON mark_history (solved_practice_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS mark_history;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE solved_practice ADD IF NOT EXISTS content_hash varchar DEFAULT NULL;
+
+-- Работа, побайтно совпадающая с ранее сданной работой другого студента
+ALTER TABLE solved_practice ADD IF NOT EXISTS duplicate_of integer REFERENCES solved_practice(solved_practice_id);
+
+CREATE INDEX IF NOT EXISTS solved_practice_content_hash_idx ON solved_practice (issued_practice_id, content_hash);
+
+CREATE TABLE IF NOT EXISTS similarity (
+    similarity_id serial PRIMARY KEY NOT NULL,
+    issued_practice_id integer NOT NULL REFERENCES issued_practice(issued_practice_id),
+    first_solved_id integer NOT NULL REFERENCES solved_practice(solved_practice_id),
+    second_solved_id integer NOT NULL REFERENCES solved_practice(solved_practice_id),
+    score real NOT NULL,
+    is_identical boolean NOT NULL DEFAULT false,
+    fragments text[] NOT NULL DEFAULT '{}',
+    computed_at timestamp NOT NULL DEFAULT now(),
+    UNIQUE (first_solved_id, second_solved_id)
+);
+
+CREATE INDEX IF NOT EXISTS similarity_issued_practice_idx ON similarity (issued_practice_id, score);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS similarity;
+DROP INDEX IF EXISTS solved_practice_content_hash_idx;
+ALTER TABLE solved_practice DROP COLUMN IF EXISTS duplicate_of;
+ALTER TABLE solved_practice DROP COLUMN IF EXISTS content_hash;
+-- +goose StatementEnd
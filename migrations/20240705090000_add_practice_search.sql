@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Текст файла задания хранится отдельно, чтобы не тянуть его в каждый SELECT * из issued_practice
+CREATE TABLE IF NOT EXISTS issued_practice_text (
+    issued_practice_id integer PRIMARY KEY NOT NULL REFERENCES issued_practice(issued_practice_id),
+    body_text text NOT NULL DEFAULT '',
+    body_vector tsvector GENERATED ALWAYS AS (to_tsvector('russian', body_text)) STORED,
+    extracted_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS issued_practice_text_vector_idx ON issued_practice_text USING gin (body_vector);
+
+CREATE INDEX IF NOT EXISTS issued_practice_meta_vector_idx ON issued_practice
+    USING gin (to_tsvector('russian', title || ' ' || theme || ' ' || major));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS issued_practice_meta_vector_idx;
+DROP TABLE IF EXISTS issued_practice_text;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Вердикт антивируса по сохраненному файлу. Файлы без записи проверяются при первой выдаче
+CREATE TABLE IF NOT EXISTS file_scan (
+    file_path varchar PRIMARY KEY NOT NULL,
+    is_infected boolean NOT NULL DEFAULT false,
+    signature varchar DEFAULT NULL,
+    scanner varchar NOT NULL,
+    scanned_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    audit_log_id serial PRIMARY KEY NOT NULL,
+    account_id integer DEFAULT NULL REFERENCES account(account_id),
+    event varchar NOT NULL,
+    object varchar NOT NULL DEFAULT '',
+    details varchar NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_event_idx ON audit_log (event, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS file_scan;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Учет места, занятого файлами. Файлы, загруженные до появления учета, в квоту не входят
+CREATE TABLE IF NOT EXISTS stored_file (
+    file_path varchar PRIMARY KEY NOT NULL,
+    account_id integer NOT NULL REFERENCES account(account_id),
+    -- Группа владельца в момент загрузки, у преподавателей пусто
+    group_name varchar DEFAULT NULL,
+    size_bytes bigint NOT NULL,
+    stored_at timestamp NOT NULL DEFAULT now(),
+    -- Время освобождения места, например при удалении задания
+    released_at timestamp DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS stored_file_account_idx ON stored_file (account_id) WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS stored_file_group_idx ON stored_file (group_name) WHERE released_at IS NULL;
+
+-- Квота задается либо для роли (на каждый аккаунт роли), либо для группы (на всех ее членов вместе)
+CREATE TABLE IF NOT EXISTS storage_quota (
+    storage_quota_id serial PRIMARY KEY NOT NULL,
+    internal_role_id integer UNIQUE REFERENCES internal_role(internal_role_id),
+    group_name varchar UNIQUE,
+    limit_bytes bigint NOT NULL CHECK (limit_bytes > 0),
+    updated_at timestamp NOT NULL DEFAULT now(),
+    CHECK ((internal_role_id IS NULL) <> (group_name IS NULL))
+);
+
+INSERT INTO storage_quota (internal_role_id, limit_bytes)
+SELECT internal_role_id, 200 * 1024 * 1024 FROM internal_role WHERE role_name = 'STUDENT';
+
+INSERT INTO internal_object (internal_object_name, description)
+VALUES ('STORAGE', 'Объект для работы с квотами и занятым местом');
+
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'ADMIN' AND o.internal_object_name = 'STORAGE';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM role_permission
+WHERE internal_object_id IN (SELECT internal_object_id FROM internal_object WHERE internal_object_name = 'STORAGE');
+DELETE FROM internal_object WHERE internal_object_name = 'STORAGE';
+DROP TABLE IF EXISTS storage_quota;
+DROP TABLE IF EXISTS stored_file;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Возобновляемые загрузки: файл принимается частями, смещение фиксируется после записи каждой части
+CREATE TABLE IF NOT EXISTS upload_session (
+    upload_id varchar PRIMARY KEY NOT NULL,
+    account_id integer NOT NULL REFERENCES account(account_id),
+    file_name varchar NOT NULL DEFAULT '',
+    upload_length bigint NOT NULL CHECK (upload_length > 0),
+    upload_offset bigint NOT NULL DEFAULT 0 CHECK (upload_offset >= 0 AND upload_offset <= upload_length),
+    created_at timestamp NOT NULL DEFAULT now(),
+    updated_at timestamp NOT NULL DEFAULT now(),
+    -- Незавершенные и неиспользованные загрузки удаляются после истечения срока
+    expires_at timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS upload_session_expires_idx ON upload_session (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS upload_session;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Хэш содержимого сохраненных файлов для ETag. Для файлов, сохраненных раньше, считается при первой выдаче
+CREATE TABLE IF NOT EXISTS file_digest (
+    file_path varchar PRIMARY KEY NOT NULL,
+    -- sha256 содержимого в hex
+    content_hash varchar NOT NULL,
+    size_bytes bigint NOT NULL,
+    computed_at timestamp NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS file_digest;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Подписанные ссылки на скачивание файлов без заголовка Authorization
+CREATE TABLE IF NOT EXISTS download_link (
+    link_id varchar PRIMARY KEY NOT NULL,
+    -- Аккаунт, от имени которого выдается файл по ссылке
+    account_id integer NOT NULL REFERENCES account(account_id),
+    -- Объект RBAC, к которому относится файл, и id записи
+    object varchar NOT NULL,
+    resource_id integer NOT NULL,
+    single_use boolean NOT NULL DEFAULT false,
+    created_at timestamp NOT NULL DEFAULT now(),
+    expires_at timestamp NOT NULL,
+    used_at timestamp DEFAULT NULL,
+    revoked_at timestamp DEFAULT NULL,
+    revoked_by integer DEFAULT NULL REFERENCES account(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS download_link_expires_idx ON download_link (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS download_link;
+-- +goose StatementEnd
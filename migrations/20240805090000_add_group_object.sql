@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+INSERT INTO internal_object (internal_object_name, description)
+VALUES ('GROUP', 'Объект для перевода студентов между группами и выпуска групп');
+
+-- Переводить и выпускать группы может только администратор
+INSERT INTO role_permission (internal_role_id, internal_action_id, internal_object_id)
+SELECT r.internal_role_id, a.internal_action_id, o.internal_object_id
+FROM internal_role r
+         CROSS JOIN internal_action a
+         CROSS JOIN internal_object o
+WHERE r.role_name = 'ADMIN' AND o.internal_object_name = 'GROUP';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM role_permission
+WHERE internal_object_id IN (SELECT internal_object_id FROM internal_object WHERE internal_object_name = 'GROUP');
+DELETE FROM internal_object WHERE internal_object_name = 'GROUP';
+-- +goose StatementEnd
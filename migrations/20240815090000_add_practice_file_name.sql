@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Имя файла, с которым его загрузили, отдается при скачивании
+ALTER TABLE issued_practice ADD IF NOT EXISTS file_name varchar DEFAULT NULL;
+ALTER TABLE solved_practice ADD IF NOT EXISTS file_name varchar DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE solved_practice DROP COLUMN IF EXISTS file_name;
+ALTER TABLE issued_practice DROP COLUMN IF EXISTS file_name;
+-- +goose StatementEnd
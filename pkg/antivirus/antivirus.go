@@ -0,0 +1,35 @@
+package antivirus
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrScanFailed проверка не выполнена: антивирус недоступен или вернул ошибку
+var ErrScanFailed = errors.New("antivirus: проверка не выполнена")
+
+// Verdict результат проверки файла
+type Verdict struct {
+	Infected bool
+	// Signature название найденной сигнатуры, пусто для чистого файла
+	Signature string
+}
+
+type Scanner interface {
+	// Scan проверяет содержимое r. Ошибка означает, что вердикт не получен
+	Scan(ctx context.Context, r io.Reader) (Verdict, error)
+	// Name название сканера для журнала проверок
+	Name() string
+}
+
+// Noop сканер, который считает все файлы чистыми. Используется, если антивирус не настроен
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Verdict, error) {
+	return Verdict{}, nil
+}
+
+func (Noop) Name() string {
+	return "none"
+}
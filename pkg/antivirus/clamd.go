@@ -0,0 +1,184 @@
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// chunkSize размер части файла в команде INSTREAM. Должен быть меньше StreamMaxLength в clamd.conf
+const chunkSize = 64 << 10
+
+// Clamd клиент демона ClamAV, передающий файл командой INSTREAM по TCP или unix сокету
+type Clamd struct {
+	// network "tcp" или "unix"
+	network string
+	address string
+
+	timeout time.Duration
+}
+
+func NewClamd(network, address string, timeout time.Duration) Clamd {
+	return Clamd{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (c Clamd) Name() string {
+	return "clamd"
+}
+
+func (c Clamd) Scan(ctx context.Context, r io.Reader) (Verdict, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer conn.Close()
+
+	// Команды с префиксом z завершаются нулевым байтом, ответ приходит в том же формате
+	_, err = conn.Write([]byte("zINSTREAM\x00"))
+	if err != nil {
+		return Verdict{}, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	w := bufio.NewWriterSize(conn, chunkSize+4)
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+
+	for {
+		n, readErr := r.Read(buf)
+
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+
+			_, err = w.Write(size)
+			if err == nil {
+				_, err = w.Write(buf[:n])
+			}
+
+			if err != nil {
+				return Verdict{}, streamError(conn, err)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			return Verdict{}, readErr
+		}
+	}
+
+	// Часть нулевой длины завершает поток
+	binary.BigEndian.PutUint32(size, 0)
+
+	_, err = w.Write(size)
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err != nil {
+		return Verdict{}, streamError(conn, err)
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	return parseReply(reply)
+}
+
+// streamError возвращает ошибку передачи файла. Файл больше StreamMaxLength clamd отклоняет ответом
+// "INSTREAM size limit exceeded. ERROR" и закрывает соединение, поэтому причину берем из ответа, если он успел прийти
+func streamError(conn net.Conn, err error) error {
+	reply, replyErr := readReply(conn)
+	if replyErr != nil || reply == "" {
+		return fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrScanFailed, strings.TrimPrefix(reply, "stream: "))
+}
+
+// Ping проверяет, что демон отвечает
+func (c Clamd) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("zPING\x00"))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	if reply != "PONG" {
+		return fmt.Errorf("%w: неожиданный ответ %q", ErrScanFailed, reply)
+	}
+
+	return nil
+}
+
+func (c Clamd) dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	conn, err := d.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	// Таймаут действует на весь обмен, а не только на подключение
+	deadline, _ := ctx.Deadline()
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	return conn, nil
+}
+
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return string(bytes.TrimRight(reply, "\x00\n")), nil
+}
+
+// parseReply разбирает ответ вида "stream: OK", "stream: Eicar-Signature FOUND" или "... ERROR"
+func parseReply(reply string) (Verdict, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case result == "OK":
+		return Verdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return Verdict{
+			Infected:  true,
+			Signature: strings.TrimSuffix(result, " FOUND"),
+		}, nil
+	default:
+		return Verdict{}, fmt.Errorf("%w: %s", ErrScanFailed, result)
+	}
+}
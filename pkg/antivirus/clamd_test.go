@@ -0,0 +1,215 @@
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd демон, отвечающий на INSTREAM как clamd. reply получает принятый файл и возвращает
+// ответ без завершающего нуля, пустой ответ - не отвечать вовсе
+type fakeClamd struct {
+	ln net.Listener
+
+	// maxLength StreamMaxLength из clamd.conf, 0 - без ограничения
+	maxLength int
+	reply     func(data []byte) string
+}
+
+func newFakeClamd(t *testing.T, maxLength int, reply func(data []byte) string) *fakeClamd {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeClamd{ln: ln, maxLength: maxLength, reply: reply}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go f.serve()
+
+	return f
+}
+
+func (f *fakeClamd) client(timeout time.Duration) Clamd {
+	return NewClamd("tcp", f.ln.Addr().String(), timeout)
+}
+
+func (f *fakeClamd) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	cmd, err := r.ReadString(0)
+	if err != nil {
+		return
+	}
+
+	switch strings.TrimSuffix(cmd, "\x00") {
+	case "zPING":
+		_, _ = conn.Write([]byte("PONG\x00"))
+		return
+	case "zINSTREAM":
+	default:
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var data bytes.Buffer
+	size := make([]byte, 4)
+
+	for {
+		_, err = io.ReadFull(r, size)
+		if err != nil {
+			return
+		}
+
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+
+		if f.maxLength > 0 && data.Len()+int(n) > f.maxLength {
+			_, _ = conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+			return
+		}
+
+		_, err = io.CopyN(&data, r, int64(n))
+		if err != nil {
+			return
+		}
+	}
+
+	reply := f.reply(data.Bytes())
+	if reply == "" {
+		// Клиент должен прервать ожидание по таймауту
+		_, _ = io.Copy(io.Discard, r)
+		return
+	}
+
+	_, _ = conn.Write([]byte(reply + "\x00"))
+}
+
+func TestClamdScan(t *testing.T) {
+	eicar := []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+
+	reply := func(data []byte) string {
+		switch {
+		case bytes.Contains(data, []byte("EICAR")):
+			return "stream: Eicar-Signature FOUND"
+		case bytes.Equal(data, []byte("broken")):
+			return "stream: Can't allocate memory ERROR"
+		default:
+			return "stream: OK"
+		}
+	}
+
+	clamd := newFakeClamd(t, 0, reply).client(time.Second)
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    Verdict
+		wantErr bool
+	}{
+		{name: "чистый файл", content: []byte("обычный документ")},
+		// Файл больше части INSTREAM передается несколькими частями
+		{name: "файл из нескольких частей", content: bytes.Repeat([]byte("a"), 3*chunkSize+17)},
+		{name: "пустой файл", content: nil},
+		{name: "зараженный файл", content: eicar, want: Verdict{Infected: true, Signature: "Eicar-Signature"}},
+		{name: "ошибка антивируса", content: []byte("broken"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clamd.Scan(context.Background(), bytes.NewReader(tt.content))
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrScanFailed) {
+					t.Fatalf("ошибка %v, ожидается ErrScanFailed", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("вердикт %+v, ожидается %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClamdScanSizeLimit(t *testing.T) {
+	clamd := newFakeClamd(t, chunkSize, func([]byte) string { return "stream: OK" }).client(time.Second)
+
+	// Файл намного больше буферов сокета, поэтому clamd закрывает соединение, пока клиент еще пишет
+	_, err := clamd.Scan(context.Background(), bytes.NewReader(bytes.Repeat([]byte("a"), 256*chunkSize)))
+	if !errors.Is(err, ErrScanFailed) {
+		t.Fatalf("ошибка %v, ожидается ErrScanFailed", err)
+	}
+
+	if !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Errorf("ошибка %v, ожидается причина из ответа clamd", err)
+	}
+}
+
+func TestClamdScanTimeout(t *testing.T) {
+	clamd := newFakeClamd(t, 0, func([]byte) string { return "" }).client(100 * time.Millisecond)
+
+	start := time.Now()
+
+	_, err := clamd.Scan(context.Background(), strings.NewReader("файл"))
+	if !errors.Is(err, ErrScanFailed) {
+		t.Fatalf("ошибка %v, ожидается ErrScanFailed", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("проверка прервана через %s, ожидается около таймаута", elapsed)
+	}
+}
+
+func TestClamdUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	_, err = NewClamd("tcp", addr, time.Second).Scan(context.Background(), strings.NewReader("файл"))
+	if !errors.Is(err, ErrScanFailed) {
+		t.Fatalf("ошибка %v, ожидается ErrScanFailed", err)
+	}
+}
+
+func TestClamdPing(t *testing.T) {
+	clamd := newFakeClamd(t, 0, nil).client(time.Second)
+
+	err := clamd.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
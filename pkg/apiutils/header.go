@@ -2,14 +2,96 @@ package apiutils
 
 import (
 	"fmt"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"strings"
 )
 
-// SetDownloadHeaders устанавливает нужные заголовки при отдаче файла с сервера
-func SetDownloadHeaders(w http.ResponseWriter, name, len string) {
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
-	w.Header().Set("Content-Length", len)
-	w.Header().Set("Cache-Control", "private")
-	w.Header().Set("Pragma", "private")
+// documentTypes типы документов, которых может не быть в системной таблице типов
+var documentTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".pdf":  "application/pdf",
+}
+
+// SetDownloadHeaders устанавливает нужные заголовки при отдаче файла с сервера. Размер, диапазоны и
+// условные запросы обрабатывает http.ServeContent. По ETag из хэша содержимого клиент может хранить
+// файл у себя и проверять, не изменился ли он
+func SetDownloadHeaders(w http.ResponseWriter, name, hash string) {
+	w.Header().Set("Content-Type", ContentType(name))
+	w.Header().Set("Content-Disposition", ContentDisposition(name))
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if hash != "" {
+		w.Header().Set("ETag", fmt.Sprintf(`"%s"`, hash))
+	}
+}
+
+// SetPreviewHeaders устанавливает заголовки для показа файла в браузере вместо скачивания
+func SetPreviewHeaders(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", ContentType(name))
+	w.Header().Set("Content-Disposition", disposition("inline", name))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+}
+
+// ContentType возвращает тип содержимого по расширению файла
+func ContentType(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+
+	if contentType, ok := documentTypes[ext]; ok {
+		return contentType
+	}
+
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
+
+// ContentDisposition возвращает заголовок для скачивания файла. Имя с кириллицей передается
+// в filename* по RFC 5987, а для старых клиентов в filename остается его ASCII-вариант
+func ContentDisposition(name string) string {
+	return disposition("attachment", name)
+}
+
+func disposition(kind, name string) string {
+	var fallback, encoded strings.Builder
+
+	ascii := true
+
+	for _, r := range name {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			ascii = false
+			fallback.WriteByte('_')
+		} else {
+			fallback.WriteRune(r)
+		}
+	}
+
+	if ascii {
+		return fmt.Sprintf(`%s; filename="%s"`, kind, name)
+	}
+
+	for _, b := range []byte(name) {
+		if isAttrChar(b) {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return fmt.Sprintf(`%s; filename="%s"; filename*=UTF-8''%s`, kind, fallback.String(), encoded.String())
+}
+
+// isAttrChar символы, которые RFC 5987 разрешает передавать без кодирования
+func isAttrChar(b byte) bool {
+	switch {
+	case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+		return true
+	}
+
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
 }
@@ -0,0 +1,13 @@
+package apiutils
+
+import "time"
+
+// Timeouts ограничения времени обработки запроса по виду запроса
+type Timeouts struct {
+	// Request обычные запросы
+	Request time.Duration
+	// Long запросы, обрабатывающие много записей
+	Long time.Duration
+	// File загрузка и выдача файлов, предпросмотр и архивы
+	File time.Duration
+}
@@ -0,0 +1,19 @@
+package apiutils
+
+import "net/http"
+
+// BaseURL возвращает схему и адрес сервера, по которым клиент отправил запрос. За обратным прокси
+// схема берется из X-Forwarded-Proto
+func BaseURL(r *http.Request) string {
+	scheme := "http"
+
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	return scheme + "://" + r.Host
+}
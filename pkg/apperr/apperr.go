@@ -1,17 +1,142 @@
 package apperr
 
 import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"go.uber.org/zap"
 	"net/http"
+	"practice_vgpek/pkg/i18n"
+	"practice_vgpek/pkg/logger"
 )
 
 type AppError struct {
-	Action string `json:"action"`
-	Error  string `json:"error"`
+	Action    string         `json:"action"`
+	Error     string         `json:"error"`
+	Code      string         `json:"code"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestId string         `json:"request_id,omitempty"`
 }
 
+// New отвечает ошибкой с кодом ответа code. Если код ошибки не задан, он выводится из кода ответа,
+// если не задан текст - берется из каталога на языке из Accept-Language
 func New(w http.ResponseWriter, r *http.Request, code int, ae AppError) {
+	locale := i18n.FromRequest(r)
+
+	if ae.Code == "" {
+		ae.Code = codeByStatus(code)
+	}
+
+	if ae.Error == "" {
+		ae.Error = (&Error{Code: ae.Code, Details: ae.Details}).Localize(locale)
+	}
+
+	if ae.RequestId == "" {
+		ae.RequestId = requestId(r)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", string(locale))
 	w.WriteHeader(code)
 	render.JSON(w, r, ae)
 }
+
+// Write отвечает ошибкой слоя логики err. Код ответа и код ошибки определяются ее видом,
+// сообщение переводится по коду ошибки. Текст ошибки без вида клиенту не отдается, так как в нем
+// бывают подробности базы данных и файловой системы: такая ошибка попадает только в журнал запроса
+func Write(w http.ResponseWriter, r *http.Request, action string, err error) {
+	status := Status(err)
+
+	var typed *Error
+	switch {
+	case status == http.StatusRequestTimeout:
+		typed = &Error{Code: CodeTimeout}
+	case status == StatusClientClosedRequest:
+		typed = &Error{Code: CodeCanceled}
+	case !errors.As(err, &typed):
+		typed = &Error{Code: CodeInternal}
+	}
+
+	// Ошибка попадает в журнал доступа: поля запроса создаются в middleware до обработчика
+	if status >= http.StatusInternalServerError {
+		logger.AddFields(r.Context(), zap.Error(err))
+	}
+
+	New(w, r, status, AppError{
+		Action:  action,
+		Error:   typed.Localize(i18n.FromRequest(r)),
+		Code:    typed.Code,
+		Details: typed.Details,
+	})
+}
+
+// StatusClientClosedRequest код ответа на запрос, который клиент отменил, не дождавшись ответа.
+// Код нестандартный, как в nginx, и не считается ошибкой сервера
+const StatusClientClosedRequest = 499
+
+// Status возвращает код ответа для ошибки err
+func Status(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrGone):
+		return http.StatusGone
+	case errors.Is(err, ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func codeByStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusUnsupportedMediaType:
+		return CodeBadRequest
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusRequestTimeout:
+		return CodeTimeout
+	case StatusClientClosedRequest:
+		return CodeCanceled
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusRequestEntityTooLarge:
+		return CodeTooLarge
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// requestId возвращает id запроса, присвоенный middleware, или переданный клиентом
+func requestId(r *http.Request) string {
+	if id := middleware.GetReqID(r.Context()); id != "" {
+		return id
+	}
+
+	return r.Header.Get(middleware.RequestIDHeader)
+}
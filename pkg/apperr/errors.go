@@ -0,0 +1,161 @@
+package apperr
+
+import (
+	"errors"
+	"practice_vgpek/pkg/i18n"
+)
+
+// Виды ошибок. По виду ошибки обработчик выбирает код ответа, проверка - через errors.Is
+var (
+	ErrNotFound        = errors.New("не найдено")
+	ErrConflict        = errors.New("конфликт")
+	ErrForbidden       = errors.New("доступ запрещен")
+	ErrValidation      = errors.New("некорректные данные")
+	ErrUnauthenticated = errors.New("требуется аутентификация")
+	ErrGone            = errors.New("больше не доступно")
+	ErrTooLarge        = errors.New("превышен допустимый размер")
+	ErrUnavailable     = errors.New("временно недоступно")
+)
+
+// Коды ошибок по умолчанию для каждого вида
+const (
+	CodeBadRequest      = "bad_request"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeForbidden       = "forbidden"
+	CodeValidation      = "validation_failed"
+	CodeUnauthenticated = "unauthenticated"
+	CodeGone            = "gone"
+	CodeTooLarge        = "too_large"
+	CodeUnavailable     = "unavailable"
+	CodeTimeout         = "timeout"
+	CodeCanceled        = "canceled"
+	CodeInternal        = "internal"
+)
+
+// Error ошибка с видом и стабильным кодом, по которому клиент может отличать ошибки
+// без разбора сообщения. Текст ошибки берется из каталога сообщений по коду,
+// Details подставляются в него
+type Error struct {
+	kind error
+
+	Code    string
+	Message string
+	Details map[string]any
+
+	err error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return e.Localize(i18n.Default)
+}
+
+// Localize возвращает текст ошибки на языке locale. Сообщение, заданное через Wrap,
+// написано на языке по умолчанию, для остальных языков берется перевод кода
+func (e *Error) Localize(locale i18n.Locale) string {
+	if e.Message != "" && locale == i18n.Default {
+		return e.Message
+	}
+
+	text, ok := i18n.Text(locale, e.Code, e.Details)
+	if !ok && e.Message != "" {
+		return e.Message
+	} else if !ok {
+		return e.Code
+	}
+
+	return text
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Is(target error) bool {
+	return e.kind != nil && target == e.kind
+}
+
+// WithDetails возвращает копию ошибки с дополнительными сведениями для клиента
+func (e *Error) WithDetails(details map[string]any) *Error {
+	c := *e
+	c.Details = details
+
+	return &c
+}
+
+// WithCause возвращает копию ошибки, оборачивающую исходную ошибку cause
+func (e *Error) WithCause(cause error) *Error {
+	c := *e
+	c.err = cause
+
+	return &c
+}
+
+func newError(kind error, code string) *Error {
+	return &Error{
+		kind: kind,
+		Code: code,
+	}
+}
+
+// Internal внутренняя ошибка с собственным кодом
+func Internal(code string) *Error {
+	return newError(nil, code)
+}
+
+func NotFound(code string) *Error {
+	return newError(ErrNotFound, code)
+}
+
+func Conflict(code string) *Error {
+	return newError(ErrConflict, code)
+}
+
+func Forbidden(code string) *Error {
+	return newError(ErrForbidden, code)
+}
+
+func Validation(code string) *Error {
+	return newError(ErrValidation, code)
+}
+
+func Unauthenticated(code string) *Error {
+	return newError(ErrUnauthenticated, code)
+}
+
+func Gone(code string) *Error {
+	return newError(ErrGone, code)
+}
+
+func TooLarge(code string) *Error {
+	return newError(ErrTooLarge, code)
+}
+
+func Unavailable(code string) *Error {
+	return newError(ErrUnavailable, code)
+}
+
+// Wrap заменяет сообщение ошибки err на msg, сохраняя ее вид и код.
+// Ошибка без вида считается внутренней
+func Wrap(err error, msg string) error {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return &Error{
+			kind:    typed.kind,
+			Code:    typed.Code,
+			Message: msg,
+			Details: typed.Details,
+			err:     err,
+		}
+	}
+
+	return &Error{
+		Code:    CodeInternal,
+		Message: msg,
+		err:     err,
+	}
+}
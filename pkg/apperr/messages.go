@@ -0,0 +1,56 @@
+package apperr
+
+import "practice_vgpek/pkg/i18n"
+
+func init() {
+	i18n.Register(i18n.Catalogue{
+		CodeBadRequest: {
+			i18n.Ru: "Некорректный запрос",
+			i18n.En: "Malformed request",
+		},
+		CodeNotFound: {
+			i18n.Ru: "Запись не найдена",
+			i18n.En: "Record not found",
+		},
+		CodeConflict: {
+			i18n.Ru: "Запись уже существует",
+			i18n.En: "Record already exists",
+		},
+		CodeForbidden: {
+			i18n.Ru: "Недостаточно прав",
+			i18n.En: "Insufficient permissions",
+		},
+		CodeValidation: {
+			i18n.Ru: "Некорректные данные",
+			i18n.En: "Invalid data",
+		},
+		CodeUnauthenticated: {
+			i18n.Ru: "Требуется аутентификация",
+			i18n.En: "Authentication required",
+		},
+		CodeGone: {
+			i18n.Ru: "Больше не доступно",
+			i18n.En: "No longer available",
+		},
+		CodeTooLarge: {
+			i18n.Ru: "Превышен допустимый размер",
+			i18n.En: "Size limit exceeded",
+		},
+		CodeUnavailable: {
+			i18n.Ru: "Временно недоступно, повторите позже",
+			i18n.En: "Temporarily unavailable, try again later",
+		},
+		CodeTimeout: {
+			i18n.Ru: "Таймаут",
+			i18n.En: "Request timed out",
+		},
+		CodeCanceled: {
+			i18n.Ru: "Запрос отменен",
+			i18n.En: "Request canceled",
+		},
+		CodeInternal: {
+			i18n.Ru: "Внутренняя ошибка",
+			i18n.En: "Internal server error",
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit и Time задаются при сборке:
+//
+//	go build -ldflags "-X practice_vgpek/pkg/buildinfo.Commit=$(git rev-parse HEAD) -X practice_vgpek/pkg/buildinfo.Time=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Без них значения берутся из сведений о сборке, которые go build записывает в бинарник из git
+var (
+	Commit string
+	Time   string
+)
+
+type Info struct {
+	Commit    string
+	Time      string
+	Modified  bool
+	GoVersion string
+}
+
+// Get возвращает сведения о сборке приложения
+func Get() Info {
+	info := Info{
+		Commit:    Commit,
+		Time:      Time,
+		GoVersion: runtime.Version(),
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.Time == "" {
+				info.Time = s.Value
+			}
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnsupported конвертер не настроен или не умеет преобразовывать файл в нужный формат
+	ErrUnsupported = errors.New("converter: преобразование не поддерживается")
+	// ErrConvertFailed конвертер недоступен или завершился с ошибкой
+	ErrConvertFailed = errors.New("converter: преобразование не выполнено")
+)
+
+// Форматы, в которые преобразуются документы
+const (
+	PDF = "pdf"
+	// PNG изображение первой страницы документа
+	PNG = "png"
+)
+
+type Converter interface {
+	// Convert преобразует файл src в формат format и записывает результат в dst.
+	// Ошибка означает, что файл dst не создан
+	Convert(ctx context.Context, src, dst, format string) error
+	// Name название конвертера для журнала
+	Name() string
+}
+
+// Noop конвертер, который ничего не преобразует. Используется, если предпросмотр не настроен
+type Noop struct{}
+
+func (Noop) Convert(ctx context.Context, src, dst, format string) error {
+	return ErrUnsupported
+}
+
+func (Noop) Name() string {
+	return "none"
+}
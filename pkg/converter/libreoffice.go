@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LibreOffice конвертер, запускающий LibreOffice без интерфейса отдельным процессом на каждый файл
+type LibreOffice struct {
+	// binary путь к soffice или имя для поиска в PATH
+	binary  string
+	timeout time.Duration
+
+	// slots ограничивает число одновременно запущенных процессов
+	slots chan struct{}
+}
+
+func NewLibreOffice(binary string, timeout time.Duration, workers int) LibreOffice {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return LibreOffice{
+		binary:  binary,
+		timeout: timeout,
+		slots:   make(chan struct{}, workers),
+	}
+}
+
+func (c LibreOffice) Name() string {
+	return "libreoffice"
+}
+
+func (c LibreOffice) Convert(ctx context.Context, src, dst, format string) error {
+	if format != PDF && format != PNG {
+		return fmt.Errorf("%w: формат %q", ErrUnsupported, format)
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		defer func() { <-c.slots }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Каждый процесс получает свой каталог и профиль, иначе LibreOffice передает файл уже запущенному
+	// экземпляру, и одновременные преобразования мешают друг другу
+	work, err := os.MkdirTemp("", "convert-*")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConvertFailed, err)
+	}
+	defer os.RemoveAll(work)
+
+	src, err = filepath.Abs(src)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConvertFailed, err)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.binary,
+		"--headless", "--norestore", "--nolockcheck", "--nodefault",
+		"-env:UserInstallation=file://"+filepath.ToSlash(filepath.Join(work, "profile")),
+		"--convert-to", format,
+		"--outdir", work,
+		src,
+	)
+	cmd.Stderr = &stderr
+	cmd.Stdout = io.Discard
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %v", ErrConvertFailed, ctx.Err())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v: %s", ErrConvertFailed, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Результат называется как исходный файл с расширением формата
+	out := filepath.Join(work, strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))+"."+format)
+
+	err = copyFile(out, dst)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConvertFailed, err)
+	}
+
+	return nil
+}
+
+// copyFile копирует файл, так как временный каталог может быть на другой файловой системе
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+	}
+
+	return err
+}
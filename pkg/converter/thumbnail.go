@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+)
+
+// Shrink уменьшает PNG изображение в файле path до ширины width с сохранением пропорций.
+// Изображение не шире width остается без изменений
+func Shrink(path string, width int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	src, err := png.Decode(f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+
+	bounds := src.Bounds()
+	if width <= 0 || bounds.Dx() <= width {
+		return nil
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// Каждый пиксель уменьшенного изображения - среднее цветов попадающей в него области исходного,
+	// так мелкий текст не рассыпается на отдельные точки
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = png.Encode(out, dst)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
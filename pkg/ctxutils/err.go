@@ -0,0 +1,37 @@
+package ctxutils
+
+import (
+	"context"
+	"errors"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/tracing"
+)
+
+// Error возвращает ошибку контекста, если он уже отменен или истек, иначе ошибку с сообщением msg.
+// Сервисы заменяют ошибки нижних слоев сообщениями для пользователя, и без этой проверки таймаут
+// запроса был бы неотличим от отказа базы данных
+func Error(ctx context.Context, msg string) error {
+	err := ctx.Err()
+	if err == nil {
+		err = errors.New(msg)
+	}
+
+	tracing.RecordError(ctx, err)
+
+	return err
+}
+
+// Wrap как Error заменяет ошибку err нижнего слоя сообщением msg, но сохраняет ее вид:
+// отсутствующая запись остается ErrNotFound, нарушение уникальности - ErrConflict.
+// Ошибка записывается в текущий спан трассировки
+func Wrap(ctx context.Context, err error, msg string) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	} else {
+		err = apperr.Wrap(err, msg)
+	}
+
+	tracing.RecordError(ctx, err)
+
+	return err
+}
@@ -0,0 +1,116 @@
+package docutils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxContentSize ограничивает размер распакованного содержимого документа
+const maxContentSize = 64 << 20
+
+var ErrUnsupported = errors.New("формат документа не поддерживается")
+
+// ExtractText возвращает текст документа по его расширению. Абзацы разделяются переводом строки
+func ExtractText(data []byte, ext string) (string, error) {
+	switch strings.ToLower(ext) {
+	case ".docx":
+		return extractZipXML(data, "word/document.xml", docxHandler)
+	case ".odt":
+		return extractZipXML(data, "content.xml", odtHandler)
+	case ".pdf":
+		return extractPDF(data)
+	default:
+		return "", ErrUnsupported
+	}
+}
+
+// xmlHandler решает, что делать с элементами разметки конкретного формата
+type xmlHandler struct {
+	// text элемент, содержимое которого является текстом документа. Пустое значение - весь текст
+	text string
+	// paragraphs элементы, после которых начинается новая строка
+	paragraphs map[string]bool
+	// spaces пустые элементы, заменяющие пробел или табуляцию
+	spaces map[string]bool
+}
+
+var docxHandler = xmlHandler{
+	text:       "t",
+	paragraphs: map[string]bool{"p": true, "br": true},
+	spaces:     map[string]bool{"tab": true},
+}
+
+var odtHandler = xmlHandler{
+	paragraphs: map[string]bool{"p": true, "h": true, "line-break": true},
+	spaces:     map[string]bool{"s": true, "tab": true},
+}
+
+func extractZipXML(data []byte, name string, handler xmlHandler) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+
+		return handler.extract(io.LimitReader(rc, maxContentSize))
+	}
+
+	return "", fmt.Errorf("в документе нет %s", name)
+}
+
+func (h xmlHandler) extract(r io.Reader) (string, error) {
+	var b strings.Builder
+
+	decoder := xml.NewDecoder(r)
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if h.text != "" && t.Name.Local == h.text {
+				depth++
+			}
+
+			if h.spaces[t.Name.Local] {
+				b.WriteByte(' ')
+			}
+		case xml.EndElement:
+			if h.text != "" && t.Name.Local == h.text && depth > 0 {
+				depth--
+			}
+
+			if h.paragraphs[t.Name.Local] {
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if h.text == "" || depth > 0 {
+				b.Write(t)
+			}
+		}
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,388 @@
+package docutils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Извлечение текста из PDF упрощенное: читаются все потоки файла, текст берется из операторов
+// Tj, TJ, ' и ", а коды символов переводятся через ToUnicode CMap шрифтов. Таблицы всех шрифтов
+// объединяются, поэтому документы с конфликтующими кодировками шрифтов могут извлекаться с ошибками
+
+var (
+	streamStart = regexp.MustCompile(`stream\r?\n`)
+	bfcharBlock = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	bfrangeBody = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	hexToken    = regexp.MustCompile(`<([0-9A-Fa-f\s]*)>`)
+	rangeLine   = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>\s*(<[0-9A-Fa-f]+>|\[[^\]]*\])`)
+)
+
+// cmap соответствие кодов символов шрифта тексту
+type cmap struct {
+	// codeLen длина кода символа в байтах
+	codeLen int
+	runes   map[uint32]string
+}
+
+func extractPDF(data []byte) (string, error) {
+	streams := pdfStreams(data)
+
+	unicode := cmap{codeLen: 1, runes: make(map[uint32]string)}
+	contents := make([][]byte, 0, len(streams))
+
+	for _, stream := range streams {
+		if bytes.Contains(stream, []byte("begincmap")) {
+			unicode.parse(stream)
+			continue
+		}
+
+		if bytes.Contains(stream, []byte("BT")) {
+			contents = append(contents, stream)
+		}
+	}
+
+	var b strings.Builder
+
+	for _, content := range contents {
+		extractContent(&b, content, unicode)
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// pdfStreams возвращает распакованные потоки файла. Потоки с неизвестным сжатием пропускаются
+func pdfStreams(data []byte) [][]byte {
+	streams := make([][]byte, 0)
+
+	for _, loc := range streamStart.FindAllIndex(data, -1) {
+		start := loc[1]
+
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			continue
+		}
+
+		raw := data[start : start+end]
+
+		// Словарь потока находится перед ключевым словом stream
+		dictStart := bytes.LastIndex(data[:loc[0]], []byte("<<"))
+		var dict []byte
+		if dictStart >= 0 {
+			dict = data[dictStart:loc[0]]
+		}
+
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			zr, err := zlib.NewReader(bytes.NewReader(raw))
+			if err != nil {
+				continue
+			}
+
+			// Поток может быть обрезан в конце, прочитанной части достаточно
+			decoded, _ := io.ReadAll(io.LimitReader(zr, maxContentSize))
+			_ = zr.Close()
+
+			streams = append(streams, decoded)
+		case bytes.Contains(dict, []byte("/Filter")):
+			continue
+		default:
+			streams = append(streams, raw)
+		}
+	}
+
+	return streams
+}
+
+func (c *cmap) parse(stream []byte) {
+	for _, block := range bfcharBlock.FindAllSubmatch(stream, -1) {
+		tokens := hexToken.FindAllSubmatch(block[1], -1)
+
+		for i := 0; i+1 < len(tokens); i += 2 {
+			src := decodeHex(tokens[i][1])
+			c.set(src, utf16Text(decodeHex(tokens[i+1][1])))
+		}
+	}
+
+	for _, block := range bfrangeBody.FindAllSubmatch(stream, -1) {
+		for _, line := range rangeLine.FindAllSubmatch(block[1], -1) {
+			low := decodeHex(line[1])
+			high := decodeHex(line[2])
+
+			from, to := codeValue(low), codeValue(high)
+			if to < from || to-from > 0xffff {
+				continue
+			}
+
+			dst := line[3]
+
+			// Диапазон с массивом задает текст для каждого кода отдельно
+			if dst[0] == '[' {
+				items := hexToken.FindAllSubmatch(dst, -1)
+
+				for i, item := range items {
+					c.setCode(len(low), from+uint32(i), utf16Text(decodeHex(item[1])))
+				}
+
+				continue
+			}
+
+			base := []rune(utf16Text(decodeHex(dst[1 : len(dst)-1])))
+			if len(base) == 0 {
+				continue
+			}
+
+			for code := from; code <= to; code++ {
+				text := append([]rune{}, base...)
+				text[len(text)-1] += rune(code - from)
+
+				c.setCode(len(low), code, string(text))
+			}
+		}
+	}
+}
+
+func (c *cmap) set(src []byte, text string) {
+	c.setCode(len(src), codeValue(src), text)
+}
+
+func (c *cmap) setCode(codeLen int, code uint32, text string) {
+	if codeLen > c.codeLen {
+		c.codeLen = codeLen
+	}
+
+	c.runes[code] = text
+}
+
+// decode переводит байты строки PDF в текст. Без таблицы шрифта байты читаются как Latin-1
+func (c cmap) decode(raw []byte) string {
+	var b strings.Builder
+
+	if len(c.runes) == 0 {
+		for _, ch := range raw {
+			b.WriteRune(rune(ch))
+		}
+
+		return b.String()
+	}
+
+	for i := 0; i+c.codeLen <= len(raw); i += c.codeLen {
+		code := codeValue(raw[i : i+c.codeLen])
+
+		if text, ok := c.runes[code]; ok {
+			b.WriteString(text)
+		} else if c.codeLen == 1 {
+			b.WriteRune(rune(code))
+		}
+	}
+
+	return b.String()
+}
+
+// extractContent разбирает поток содержимого страницы и дописывает найденный текст в b
+func extractContent(b *strings.Builder, content []byte, unicode cmap) {
+	// Строки декодируются сразу, чтобы пробелы из TJ не сбивали двухбайтовые коды символов
+	var operands []string
+	var inArray bool
+	var array strings.Builder
+
+	for i := 0; i < len(content); {
+		ch := content[i]
+
+		switch {
+		case ch == '(':
+			raw, next := readLiteral(content, i)
+			if inArray {
+				array.WriteString(unicode.decode(raw))
+			} else {
+				operands = append(operands, unicode.decode(raw))
+			}
+			i = next
+		case ch == '<' && i+1 < len(content) && content[i+1] == '<':
+			// Словари внутри содержимого (например, у маркированного содержимого) пропускаются как разделители
+			i += 2
+		case ch == '<':
+			end := bytes.IndexByte(content[i:], '>')
+			if end < 0 {
+				return
+			}
+
+			raw := decodeHex(content[i+1 : i+end])
+			if inArray {
+				array.WriteString(unicode.decode(raw))
+			} else {
+				operands = append(operands, unicode.decode(raw))
+			}
+			i += end + 1
+		case ch == '[':
+			inArray = true
+			array.Reset()
+			i++
+		case ch == ']':
+			inArray = false
+			operands = append(operands, array.String())
+			i++
+		case ch == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isRegular(ch):
+			start := i
+			for i < len(content) && isRegular(content[i]) {
+				i++
+			}
+
+			token := string(content[start:i])
+
+			// Большой отступ внутри TJ обычно означает пробел между словами
+			if inArray {
+				if n, err := strconv.ParseFloat(token, 64); err == nil && n < -200 {
+					array.WriteByte(' ')
+				}
+				continue
+			}
+
+			switch token {
+			case "Tj", "TJ":
+				if len(operands) > 0 {
+					b.WriteString(operands[len(operands)-1])
+				}
+			case "'", "\"":
+				b.WriteByte('\n')
+				if len(operands) > 0 {
+					b.WriteString(operands[len(operands)-1])
+				}
+			case "T*", "Td", "TD":
+				b.WriteByte(' ')
+			case "ET":
+				b.WriteByte('\n')
+			}
+
+			if _, err := strconv.ParseFloat(token, 64); err != nil && token[0] != '/' {
+				operands = operands[:0]
+			}
+		default:
+			i++
+		}
+	}
+}
+
+// readLiteral читает строку в круглых скобках с учетом вложенных скобок и экранирования
+func readLiteral(content []byte, start int) ([]byte, int) {
+	var raw []byte
+	depth := 0
+
+	for i := start; i < len(content); i++ {
+		ch := content[i]
+
+		switch ch {
+		case '\\':
+			if i+1 >= len(content) {
+				return raw, i + 1
+			}
+
+			i++
+			next := content[i]
+
+			switch next {
+			case 'n':
+				raw = append(raw, '\n')
+			case 'r':
+				raw = append(raw, '\r')
+			case 't':
+				raw = append(raw, '\t')
+			case 'b':
+				raw = append(raw, '\b')
+			case 'f':
+				raw = append(raw, '\f')
+			case '\r', '\n':
+				// Перенос строки внутри строки PDF игнорируется
+			default:
+				if next >= '0' && next <= '7' {
+					end := i
+					for end < len(content) && end < i+3 && content[end] >= '0' && content[end] <= '7' {
+						end++
+					}
+
+					n, _ := strconv.ParseUint(string(content[i:end]), 8, 8)
+					raw = append(raw, byte(n))
+					i = end - 1
+				} else {
+					raw = append(raw, next)
+				}
+			}
+		case '(':
+			if depth > 0 {
+				raw = append(raw, ch)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return raw, i + 1
+			}
+			raw = append(raw, ch)
+		default:
+			raw = append(raw, ch)
+		}
+	}
+
+	return raw, len(content)
+}
+
+func isRegular(ch byte) bool {
+	switch ch {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return ch == '/'
+	}
+
+	return true
+}
+
+func decodeHex(raw []byte) []byte {
+	clean := bytes.Map(func(r rune) rune {
+		if strings.ContainsRune(" \t\r\n\f", r) {
+			return -1
+		}
+		return r
+	}, raw)
+
+	// Нечетная длина дополняется нулем по спецификации PDF
+	if len(clean)%2 == 1 {
+		clean = append(clean, '0')
+	}
+
+	decoded := make([]byte, hex.DecodedLen(len(clean)))
+
+	n, err := hex.Decode(decoded, clean)
+	if err != nil {
+		return nil
+	}
+
+	return decoded[:n]
+}
+
+func codeValue(raw []byte) uint32 {
+	var code uint32
+
+	for _, ch := range raw {
+		code = code<<8 | uint32(ch)
+	}
+
+	return code
+}
+
+func utf16Text(raw []byte) string {
+	units := make([]uint16, 0, len(raw)/2)
+
+	for i := 0; i+1 < len(raw); i += 2 {
+		units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+	}
+
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,84 @@
+package formutils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// MaxFieldsSize допустимый общий размер текстовых полей формы
+const MaxFieldsSize = 1 << 20
+
+// ErrTooLarge тело запроса больше допустимого размера
+var ErrTooLarge = errors.New("слишком большой запрос")
+
+// ReadUntilFile читает текстовые поля multipart-формы до части с файлом fileField и возвращает
+// эту часть непрочитанной, чтобы файл сохранялся прямо из тела запроса. Поэтому файл должен
+// передаваться последним полем формы, поля после него не читаются. Если файла в форме нет, часть - nil.
+// Тело запроса ограничивается maxFileSize и размером полей, запрос с большим Content-Length отклоняется сразу
+func ReadUntilFile(w http.ResponseWriter, r *http.Request, fileField string, maxFileSize int64) (url.Values, *multipart.Part, error) {
+	limit := maxFileSize + MaxFieldsSize
+
+	if r.ContentLength > limit {
+		return nil, nil, ErrTooLarge
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(url.Values)
+	remaining := int64(MaxFieldsSize)
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return values, nil, nil
+		}
+		if err != nil {
+			return nil, nil, tooLarge(err)
+		}
+
+		if part.FormName() == fileField {
+			return values, part, nil
+		}
+
+		// Файлы в других полях не ожидаются и пропускаются
+		if part.FileName() != "" {
+			_, err = io.Copy(io.Discard, part)
+			if err != nil {
+				return nil, nil, tooLarge(err)
+			}
+
+			continue
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, remaining+1))
+		if err != nil {
+			return nil, nil, tooLarge(err)
+		}
+
+		remaining -= int64(len(value))
+		if remaining < 0 {
+			return nil, nil, fmt.Errorf("%w: поля формы", ErrTooLarge)
+		}
+
+		values.Add(part.FormName(), string(value))
+	}
+}
+
+func tooLarge(err error) error {
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		return ErrTooLarge
+	}
+
+	return err
+}
@@ -0,0 +1,153 @@
+package i18n_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	_ "practice_vgpek/internal/model/domain"
+	_ "practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/i18n"
+	_ "practice_vgpek/pkg/postgres"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// codeSources файлы с кодами ошибок, у каждого из которых должно быть сообщение в каталоге
+var codeSources = []string{
+	"../../internal/model/domain/errors.go",
+	"../apperr/errors.go",
+	"../postgres/*.go",
+}
+
+func TestCatalogueComplete(t *testing.T) {
+	codes := declaredCodes(t)
+	if len(codes) == 0 {
+		t.Fatal("не найдено ни одного кода ошибки")
+	}
+
+	for name, code := range codes {
+		msgs, ok := i18n.Lookup(code)
+		if !ok {
+			t.Errorf("%s (%q): нет сообщения в каталоге", name, code)
+			continue
+		}
+
+		for _, locale := range i18n.Locales {
+			if strings.TrimSpace(msgs[locale]) == "" {
+				t.Errorf("%s (%q): нет перевода на %s", name, code, locale)
+			}
+		}
+
+		err := i18n.Check(msgs)
+		if err != nil {
+			t.Errorf("%s (%q): %v", name, code, err)
+		}
+	}
+}
+
+func TestTextPlaceholders(t *testing.T) {
+	for name, code := range declaredCodes(t) {
+		msgs, ok := i18n.Lookup(code)
+		if !ok {
+			continue
+		}
+
+		params := make(map[string]any)
+		for _, p := range i18n.Placeholders(msgs[i18n.Default]) {
+			params[p] = "значение"
+		}
+
+		for _, locale := range i18n.Locales {
+			text, _ := i18n.Text(locale, code, params)
+			if len(i18n.Placeholders(text)) != 0 {
+				t.Errorf("%s (%q): в переводе на %s остались подстановки: %s", name, code, locale, text)
+			}
+		}
+	}
+}
+
+// declaredCodes возвращает строковые константы Code* из codeSources по имени константы
+func declaredCodes(t *testing.T) map[string]string {
+	t.Helper()
+
+	codes := make(map[string]string)
+	fset := token.NewFileSet()
+
+	for _, pattern := range codeSources {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, path := range files {
+			if strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+
+			f, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+
+					for i, ident := range vs.Names {
+						if !strings.HasPrefix(ident.Name, "Code") || i >= len(vs.Values) {
+							continue
+						}
+
+						lit, ok := vs.Values[i].(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							continue
+						}
+
+						value, err := strconv.Unquote(lit.Value)
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						codes[ident.Name] = value
+					}
+				}
+			}
+		}
+	}
+
+	return codes
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Locale
+	}{
+		{"", i18n.Ru},
+		{"en", i18n.En},
+		{"en-US,en;q=0.9", i18n.En},
+		{"de, en;q=0.5", i18n.En},
+		{"ru;q=0.3, en;q=0.8", i18n.En},
+		{"*", i18n.Ru},
+		{"fr", i18n.Ru},
+	}
+
+	for _, tt := range tests {
+		got := i18n.Parse(tt.header)
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+
+	if !slices.Contains(i18n.Locales, i18n.Default) {
+		t.Errorf("язык по умолчанию %s не входит в Locales", i18n.Default)
+	}
+}
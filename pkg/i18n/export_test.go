@@ -0,0 +1,12 @@
+package i18n
+
+// Lookup возвращает переводы сообщения с кодом code без подстановки языка по умолчанию
+func Lookup(code string) (Messages, bool) {
+	msgs, ok := catalogue[code]
+	return msgs, ok
+}
+
+var (
+	Check        = check
+	Placeholders = placeholders
+)
@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	Ru Locale = "ru"
+	En Locale = "en"
+
+	// Default язык, если клиент не указал поддерживаемый
+	Default = Ru
+)
+
+// Locales поддерживаемые языки. У каждого сообщения в каталоге должен быть перевод на все из них
+var Locales = []Locale{Ru, En}
+
+// Messages переводы одного сообщения. Текст может содержать подстановки вида {name}
+type Messages map[Locale]string
+
+// Catalogue сообщения по стабильному коду
+type Catalogue map[string]Messages
+
+var (
+	catalogue   = Catalogue{}
+	placeholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+)
+
+// Register добавляет сообщения в общий каталог. Вызывается из init пакетов, объявляющих коды,
+// поэтому неполный каталог не дает приложению запуститься
+func Register(c Catalogue) {
+	for code, msgs := range c {
+		if _, ok := catalogue[code]; ok {
+			panic(fmt.Sprintf("i18n: код %s уже зарегистрирован", code))
+		}
+
+		err := check(msgs)
+		if err != nil {
+			panic(fmt.Sprintf("i18n: код %s: %v", code, err))
+		}
+
+		catalogue[code] = msgs
+	}
+}
+
+// check проверяет, что сообщение переведено на все языки и подстановки в переводах совпадают
+func check(msgs Messages) error {
+	var want []string
+
+	for i, locale := range Locales {
+		text, ok := msgs[locale]
+		if !ok || text == "" {
+			return fmt.Errorf("нет перевода на %s", locale)
+		}
+
+		names := placeholders(text)
+		if i == 0 {
+			want = names
+			continue
+		}
+
+		if !slices.Equal(want, names) {
+			return fmt.Errorf("подстановки %v в переводе на %s не совпадают с %v", names, locale, want)
+		}
+	}
+
+	return nil
+}
+
+func placeholders(text string) []string {
+	var names []string
+	for _, m := range placeholder.FindAllStringSubmatch(text, -1) {
+		names = append(names, m[1])
+	}
+
+	sort.Strings(names)
+
+	return slices.Compact(names)
+}
+
+// Text возвращает сообщение с кодом code на языке locale с подставленными params.
+// Неизвестная подстановка остается в тексте как есть
+func Text(locale Locale, code string, params map[string]any) (string, bool) {
+	msgs, ok := catalogue[code]
+	if !ok {
+		return "", false
+	}
+
+	text, ok := msgs[locale]
+	if !ok {
+		text = msgs[Default]
+	}
+
+	return placeholder.ReplaceAllStringFunc(text, func(m string) string {
+		v, ok := params[m[1:len(m)-1]]
+		if !ok {
+			return m
+		}
+
+		return fmt.Sprint(v)
+	}), true
+}
+
+// FromRequest выбирает язык ответа по заголовку Accept-Language
+func FromRequest(r *http.Request) Locale {
+	return Parse(r.Header.Get("Accept-Language"))
+}
+
+// Parse выбирает из Accept-Language поддерживаемый язык с наибольшим весом.
+// Регион не учитывается, en-US считается en
+func Parse(header string) Locale {
+	best, bestQ := Default, 0.0
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		locale := Locale(base)
+		if base == "*" {
+			locale = Default
+		}
+
+		if q > bestQ && slices.Contains(Locales, locale) {
+			best, bestQ = locale, q
+		}
+	}
+
+	return best
+}
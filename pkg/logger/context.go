@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+)
+
+type fieldsKey struct{}
+
+// fields поля запроса, общие для всех логгеров, полученных из его контекста
+type fields struct {
+	mu   sync.RWMutex
+	list []zap.Field
+}
+
+// WithFields добавляет поля к логгерам, которые будут получены из контекста через FromContext.
+// Если в контексте уже есть поля запроса, новые дописываются к ним, поэтому поля,
+// добавленные внутренним middleware, видны и во внешних, например в журнале доступа
+func WithFields(ctx context.Context, fs ...zap.Field) context.Context {
+	if AddFields(ctx, fs...) {
+		return ctx
+	}
+
+	return context.WithValue(ctx, fieldsKey{}, &fields{list: fs})
+}
+
+// AddFields дописывает поля к полям запроса, уже лежащим в контексте, и не возвращает новый контекст:
+// поля меняются на месте и видны всем, кто получает логгер из этого запроса, в том числе журналу доступа.
+// Возвращает false, если в контексте нет полей запроса, тогда поля никуда не добавляются
+func AddFields(ctx context.Context, fs ...zap.Field) bool {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return false
+	}
+
+	f.mu.Lock()
+	f.list = append(f.list, fs...)
+	f.mu.Unlock()
+
+	return true
+}
+
+// FromContext возвращает логгер l с полями запроса из контекста: id запроса, id аккаунта и т.д.
+func FromContext(ctx context.Context, l *zap.Logger) *zap.Logger {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return l
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.list) == 0 {
+		return l
+	}
+
+	return l.With(f.list...)
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+)
+
+// TestAddFieldsMutatesRequestFields проверяет, что поля, добавленные глубже по стеку без возврата контекста,
+// видны логгеру, полученному из внешнего контекста запроса, как в журнале доступа
+func TestAddFieldsMutatesRequestFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := zap.New(core)
+
+	outer := WithFields(context.Background(), zap.String("id запроса", "1"))
+	inner, cancel := context.WithCancel(outer)
+	defer cancel()
+
+	if !AddFields(inner, zap.String("ошибка", "сбой")) {
+		t.Fatal("поля не добавлены, хотя в контексте есть поля запроса")
+	}
+
+	FromContext(outer, l).Info("запрос")
+
+	fields := logs.All()[0].ContextMap()
+	if fields["id запроса"] != "1" || fields["ошибка"] != "сбой" {
+		t.Errorf("поля записи %v, ожидаются поля запроса и добавленное поле", fields)
+	}
+}
+
+func TestAddFieldsWithoutRequestFields(t *testing.T) {
+	if AddFields(context.Background(), zap.String("ошибка", "сбой")) {
+		t.Error("AddFields сообщает о добавлении полей в контекст без полей запроса")
+	}
+}
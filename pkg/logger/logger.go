@@ -1,18 +1,29 @@
 package logger
 
-import "go.uber.org/zap"
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
 
 type Config struct {
 	Level         string
 	HasCaller     bool
 	HasStacktrace bool
 	Encoding      string
+
+	// PIIFields ключи полей с персональными данными, которые маскируются в журнале.
+	// Если не заданы, используются DefaultPIIFields
+	PIIFields []string
 }
 
 func New(cfg Config) (*zap.Logger, error) {
+	redact := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newRedactCore(core, cfg.PIIFields)
+	})
+
 	lvl, err := zap.ParseAtomicLevel(cfg.Level)
 	if err != nil {
-		return zap.NewProduction()
+		return zap.NewProduction(redact)
 	}
 
 	zapCfg := zap.NewProductionConfig()
@@ -22,5 +33,5 @@ func New(cfg Config) (*zap.Logger, error) {
 	zapCfg.DisableCaller = !cfg.HasCaller
 	zapCfg.DisableStacktrace = !cfg.HasStacktrace
 
-	return zapCfg.Build()
+	return zapCfg.Build(redact)
 }
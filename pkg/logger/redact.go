@@ -0,0 +1,178 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// Redacted подставляется в журнал вместо секрета
+	Redacted = "[скрыто]"
+	// redactedParam подставляется вместо секретного параметра запроса, латиницей, чтобы адрес
+	// оставался читаемым после кодирования
+	redactedParam = "redacted"
+)
+
+// SecretFields ключи полей, значения которых никогда не попадают в журнал: пароли, токены, тела ключей
+var SecretFields = []string{
+	"пароль", "password",
+	"токен", "token", "jwt",
+	"тело ключа", "ключ регистрации", "registration_key",
+	"authorization", "секрет", "secret",
+}
+
+// URLFields ключи полей с адресом запроса, в которых скрываются секретные параметры SecretParams
+var URLFields = []string{"эндпоинт", "url"}
+
+// SecretParams параметры запроса с секретами, например подпись ссылки на скачивание
+var SecretParams = []string{"sig", "token", "access_token", "password"}
+
+// DefaultPIIFields ключи полей с персональными данными, которые маскируются, если в конфиге
+// не задан свой список
+var DefaultPIIFields = []string{
+	"логин", "логин аккаунта", "login",
+	"имя", "фамилия", "отчество", "фио",
+}
+
+// Fingerprint поле с отпечатком секрета вместо самого значения. Отпечаток позволяет сопоставить
+// записи об одном и том же токене или ключе, не раскрывая его
+func Fingerprint(key, secret string) zap.Field {
+	if secret == "" {
+		return zap.String(key, "")
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+
+	return zap.String(key+" (отпечаток)", "sha256:"+hex.EncodeToString(sum[:4]))
+}
+
+// PII поле с персональными данными, от значения остается только первый символ
+func PII(key, value string) zap.Field {
+	return zap.String(key, maskPII(value))
+}
+
+func maskPII(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	r, _ := utf8.DecodeRuneInString(value)
+
+	return string(r) + "***"
+}
+
+type redaction int
+
+const (
+	redactSecret redaction = iota + 1
+	redactPII
+	redactURL
+)
+
+// redactCore скрывает значения полей по их ключам, поэтому секрет, переданный в журнал
+// напрямую через zap.String, все равно не будет записан
+type redactCore struct {
+	zapcore.Core
+	policy map[string]redaction
+}
+
+func newRedactCore(core zapcore.Core, piiFields []string) zapcore.Core {
+	if len(piiFields) == 0 {
+		piiFields = DefaultPIIFields
+	}
+
+	policy := make(map[string]redaction, len(SecretFields)+len(piiFields))
+	for _, key := range piiFields {
+		policy[strings.ToLower(key)] = redactPII
+	}
+	for _, key := range URLFields {
+		policy[strings.ToLower(key)] = redactURL
+	}
+	for _, key := range SecretFields {
+		policy[strings.ToLower(key)] = redactSecret
+	}
+
+	return redactCore{Core: core, policy: policy}
+}
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{Core: c.Core.With(c.redact(fields)), policy: c.policy}
+}
+
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+func (c redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+
+	for i, f := range fields {
+		mode, ok := c.policy[strings.ToLower(f.Key)]
+		if !ok {
+			continue
+		}
+
+		// Исходный срез принадлежит вызывающему коду, поэтому копируем его перед изменением
+		if redacted == nil {
+			redacted = make([]zapcore.Field, len(fields))
+			copy(redacted, fields)
+		}
+
+		switch {
+		case mode == redactPII && f.Type == zapcore.StringType:
+			redacted[i] = zap.String(f.Key, maskPII(f.String))
+		case mode == redactURL && f.Type == zapcore.StringType:
+			redacted[i] = zap.String(f.Key, redactQuery(f.String))
+		case mode == redactURL:
+			redacted[i] = f
+		default:
+			redacted[i] = zap.String(f.Key, Redacted)
+		}
+	}
+
+	if redacted == nil {
+		return fields
+	}
+
+	return redacted
+}
+
+// redactQuery заменяет в адресе значения секретных параметров запроса
+func redactQuery(uri string) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path + "?" + redactedParam
+	}
+
+	changed := false
+	for _, param := range SecretParams {
+		if query.Has(param) {
+			query.Set(param, redactedParam)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return uri
+	}
+
+	return path + "?" + query.Encode()
+}
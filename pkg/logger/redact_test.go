@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const rawSecret = "s3cr3t-value"
+
+func newObservedLogger(piiFields []string) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	return zap.New(newRedactCore(core, piiFields)), logs
+}
+
+func TestRedactSecretFields(t *testing.T) {
+	l, logs := newObservedLogger(nil)
+
+	for _, key := range SecretFields {
+		l.Info("секрет", zap.String(key, rawSecret))
+		l.Info("секрет в верхнем регистре", zap.String(strings.ToUpper(key), rawSecret))
+		l.Info("секрет не строкой", zap.ByteString(key, []byte(rawSecret)))
+		l.With(zap.String(key, rawSecret)).Info("секрет в полях логгера")
+	}
+
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			if !slices.Contains(SecretFields, strings.ToLower(key)) {
+				continue
+			}
+
+			if value != Redacted {
+				t.Errorf("%s: поле %q записано как %v, ожидается %q", entry.Message, key, value, Redacted)
+			}
+		}
+	}
+
+	if logs.Len() != 4*len(SecretFields) {
+		t.Fatalf("записано %d сообщений, ожидается %d", logs.Len(), 4*len(SecretFields))
+	}
+}
+
+func TestRedactPIIFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		piiFields []string
+		key       string
+		masked    bool
+	}{
+		{name: "список по умолчанию", key: "фамилия", masked: true},
+		{name: "логин по умолчанию", key: "логин", masked: true},
+		{name: "свой список", piiFields: []string{"email"}, key: "email", masked: true},
+		{name: "свой список заменяет умолчания", piiFields: []string{"email"}, key: "фамилия", masked: false},
+		{name: "обычное поле", key: "группа", masked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, logs := newObservedLogger(tt.piiFields)
+
+			l.Info("персональные данные", zap.String(tt.key, "Иванов"))
+
+			got := logs.All()[0].ContextMap()[tt.key]
+
+			want := "Иванов"
+			if tt.masked {
+				want = "И***"
+			}
+
+			if got != want {
+				t.Errorf("поле %q записано как %v, ожидается %q", tt.key, got, want)
+			}
+		})
+	}
+}
+
+func TestRedactURLFields(t *testing.T) {
+	l, logs := newObservedLogger(nil)
+
+	l.Info("запрос", zap.String("эндпоинт", "/link/issued?id=1&sig="+rawSecret+"&token="+rawSecret))
+
+	got := logs.All()[0].ContextMap()["эндпоинт"].(string)
+	if strings.Contains(got, rawSecret) {
+		t.Errorf("секретный параметр записан в адрес: %s", got)
+	}
+
+	if !strings.Contains(got, "id=1") {
+		t.Errorf("обычный параметр пропал из адреса: %s", got)
+	}
+}
+
+// loggedFuncs функции zap, которые записывают значение поля как есть
+var loggedFuncs = []string{"String", "Strings", "ByteString", "Binary", "Any", "Stringer", "Reflect"}
+
+// TestNoRawSecretsInSource ищет в коде приложения поля журнала с ключами SecretFields. Ядро журнала
+// скроет такое значение, но передавать его в журнал не нужно вовсе: для сопоставления
+// записей об одном секрете есть Fingerprint
+func TestNoRawSecretsInSource(t *testing.T) {
+	root := filepath.Join("..", "..")
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == filepath.Join(root, "pkg", "logger") || strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !slices.Contains(loggedFuncs, sel.Sel.Name) {
+				return true
+			}
+
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "zap" {
+				return true
+			}
+
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+
+			key, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+
+			if slices.Contains(SecretFields, strings.ToLower(key)) {
+				t.Errorf("%s: секрет передается в журнал полем zap.%s(%q), используйте logger.Fingerprint",
+					fset.Position(call.Pos()), sel.Sel.Name, key)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
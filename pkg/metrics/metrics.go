@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"practice_vgpek/pkg/timeutils"
+	"time"
+)
+
+const namespace = "practice"
+
+// Registry реестр метрик приложения. Отдельный от глобального, чтобы в /metrics
+// не попадали метрики, зарегистрированные сторонними библиотеками
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки HTTP запросов по маршруту, методу и коду ответа",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPResponseBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "response_bytes_total",
+		Help:      "Объем тел ответов по маршруту",
+	}, []string{"method", "route"})
+
+	DAOQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "dao",
+		Name:      "query_duration_seconds",
+		Help:      "Время выполнения запросов к базе данных по операции DAO",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	UploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "files",
+		Name:      "upload_bytes_total",
+		Help:      "Объем принятых файлов по виду",
+	}, []string{"kind"})
+
+	DownloadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "files",
+		Name:      "download_bytes_total",
+		Help:      "Объем отданных файлов по виду",
+	}, []string{"kind"})
+
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Попытки входа по результату",
+	}, []string{"result"})
+
+	RBACDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rbac",
+		Name:      "denials_total",
+		Help:      "Отказы в доступе по объекту и действию",
+	}, []string{"object", "action"})
+)
+
+// Виды файлов для UploadBytes и DownloadBytes. Вид принятого файла - каталог, в который он сохранен
+const (
+	FilesIssued        = "issued"
+	FilesSolved        = "solved"
+	FilesIssuedPreview = "issued_preview"
+	FilesSolvedPreview = "solved_preview"
+	FilesSubmissionZip = "submissions_zip"
+)
+
+// Результаты входа для Logins
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginError   = "error"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPResponseBytes,
+		DAOQueryDuration,
+		UploadBytes,
+		DownloadBytes,
+		Logins,
+		RBACDenials,
+	)
+}
+
+// Handler отдает метрики реестра Registry в формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// TrackQuery учитывает время запроса операции DAO op, начатого в start, и возвращает его
+// для журнала, как timeutils.TrackTime
+func TrackQuery(op string, start time.Time) time.Duration {
+	elapsed := timeutils.TrackTime(start)
+	DAOQueryDuration.WithLabelValues(op).Observe(elapsed.Seconds())
+
+	return elapsed
+}
+
+// CountingWriter считает байты, отданные клиенту при скачивании файла вида kind
+type CountingWriter struct {
+	http.ResponseWriter
+	kind string
+}
+
+func NewCountingWriter(w http.ResponseWriter, kind string) CountingWriter {
+	return CountingWriter{ResponseWriter: w, kind: kind}
+}
+
+func (w CountingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	DownloadBytes.WithLabelValues(w.kind).Add(float64(n))
+
+	return n, err
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"practice_vgpek/pkg/apperr"
+)
+
+var (
+	errNotFound = apperr.NotFound(apperr.CodeNotFound)
+	errConflict = apperr.Conflict(apperr.CodeConflict)
+)
+
+// MapError переводит ошибки pgx в ошибки приложения: отсутствие строки - в ErrNotFound,
+// нарушение уникальности - в ErrConflict с именем ограничения в деталях. Исходная ошибка
+// остается в цепочке, поэтому errors.Is(err, pgx.ErrNoRows) продолжает работать
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errNotFound.WithCause(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errConflict.WithCause(err).WithDetails(map[string]any{"constraint": pgErr.ConstraintName})
+	}
+
+	return err
+}
@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"practice_vgpek/pkg/apperr"
+	"practice_vgpek/pkg/i18n"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	uniqueViolation      = "23505"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// CodeTxConflict код ошибки, если транзакция так и не смогла зафиксироваться
+const CodeTxConflict = "tx_conflict"
+
+// ErrTxRetriesExceeded возвращается, если транзакция так и не смогла
+// зафиксироваться из-за конфликтов сериализации
+var ErrTxRetriesExceeded = apperr.Conflict(CodeTxConflict)
+
+func init() {
+	i18n.Register(i18n.Catalogue{
+		CodeTxConflict: {
+			i18n.Ru: "Превышено кол-во попыток выполнения транзакции, повторите запрос",
+			i18n.En: "Too many concurrent changes, retry the request",
+		},
+	})
+}
+
+type txKey struct{}
+
+// txState транзакция, привязанная к контексту. Флаг retryable выставляется
+// при первой ошибке сериализации, так как после нее транзакция уже прервана,
+// а вызывающий код мог превратить исходную ошибку в сообщение
+type txState struct {
+	tx        pgx.Tx
+	retryable atomic.Bool
+}
+
+func stateFrom(ctx context.Context) *txState {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+// IsRetryable сообщает, можно ли повторить транзакцию после ошибки err
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+// DB выполняет запросы в транзакции из контекста, если она есть, иначе - через пул
+type DB struct {
+	pool *pgxpool.Pool
+}
+
+func NewDB(pool *pgxpool.Pool) DB {
+	return DB{pool: pool}
+}
+
+func (db DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	state := stateFrom(ctx)
+	if state == nil {
+		return db.pool.Exec(ctx, sql, args...)
+	}
+
+	tag, err := state.tx.Exec(ctx, sql, args...)
+	state.track(err)
+
+	return tag, err
+}
+
+func (db DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	state := stateFrom(ctx)
+	if state == nil {
+		return db.pool.Query(ctx, sql, args...)
+	}
+
+	rows, err := state.tx.Query(ctx, sql, args...)
+	state.track(err)
+
+	return trackedRows{Rows: rows, state: state}, err
+}
+
+func (db DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	state := stateFrom(ctx)
+	if state == nil {
+		return db.pool.QueryRow(ctx, sql, args...)
+	}
+
+	return trackedRow{row: state.tx.QueryRow(ctx, sql, args...), state: state}
+}
+
+// Ping проверяет соединение с базой данных
+func (db DB) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
+// Begin начинает транзакцию, а внутри транзакции из контекста - точку сохранения
+func (db DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	state := stateFrom(ctx)
+	if state == nil {
+		return db.pool.Begin(ctx)
+	}
+
+	tx, err := state.tx.Begin(ctx)
+	if err != nil {
+		state.track(err)
+		return nil, err
+	}
+
+	return trackedTx{Tx: tx, state: state}, nil
+}
+
+func (s *txState) track(err error) {
+	if IsRetryable(err) {
+		s.retryable.Store(true)
+	}
+}
+
+type trackedRows struct {
+	pgx.Rows
+	state *txState
+}
+
+func (r trackedRows) Err() error {
+	err := r.Rows.Err()
+	r.state.track(err)
+
+	return err
+}
+
+// trackedTx точка сохранения внутри транзакции из контекста. Ошибка сериализации
+// в ней прерывает и внешнюю транзакцию, поэтому тоже учитывается
+type trackedTx struct {
+	pgx.Tx
+	state *txState
+}
+
+func (t trackedTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tag, err := t.Tx.Exec(ctx, sql, args...)
+	t.state.track(err)
+
+	return tag, err
+}
+
+func (t trackedTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := t.Tx.Query(ctx, sql, args...)
+	t.state.track(err)
+
+	return trackedRows{Rows: rows, state: t.state}, err
+}
+
+func (t trackedTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return trackedRow{row: t.Tx.QueryRow(ctx, sql, args...), state: t.state}
+}
+
+func (t trackedTx) Commit(ctx context.Context) error {
+	err := t.Tx.Commit(ctx)
+	t.state.track(err)
+
+	return err
+}
+
+type trackedRow struct {
+	row   pgx.Row
+	state *txState
+}
+
+func (r trackedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	r.state.track(err)
+
+	return err
+}
+
+// TxManager выполняет функции в одной транзакции, к которой DAO
+// присоединяются через контекст
+type TxManager struct {
+	pool     *pgxpool.Pool
+	attempts int
+	backoff  time.Duration
+}
+
+func NewTxManager(pool *pgxpool.Pool, attempts int, backoff time.Duration) TxManager {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return TxManager{
+		pool:     pool,
+		attempts: attempts,
+		backoff:  backoff,
+	}
+}
+
+// WithinTx выполняет fn в сериализуемой транзакции и фиксирует ее, если fn
+// не вернула ошибку. Если в контексте уже есть транзакция, fn присоединяется
+// к ней. При конфликтах сериализации транзакция повторяется целиком
+func (m TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if stateFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryable, err := m.run(ctx, fn)
+		if err == nil || !retryable {
+			return err
+		}
+
+		if attempt == m.attempts {
+			return fmt.Errorf("%w: %w", ErrTxRetriesExceeded, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * m.backoff):
+		}
+	}
+}
+
+func (m TxManager) run(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(context.Background())
+
+	state := &txState{tx: tx}
+
+	err = fn(context.WithValue(ctx, txKey{}, state))
+	if err != nil {
+		return state.retryable.Load(), err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return IsRetryable(err), err
+	}
+
+	return false, nil
+}
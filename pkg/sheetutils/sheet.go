@@ -0,0 +1,228 @@
+package sheetutils
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSheetSize ограничивает размер распакованного листа, чтобы архив не раздувался в памяти
+const maxSheetSize = 32 << 20
+
+var ErrNoSheet = errors.New("в книге нет листов")
+
+// ReadCSV читает таблицу CSV. Разделитель (запятая или точка с запятой) определяется по первой строке
+func ReadCSV(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+
+	// Excel сохраняет CSV с BOM, его нужно пропустить
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = br.Discard(3)
+	}
+
+	// Peek возвращает io.EOF для файла короче буфера, прочитанного при этом достаточно
+	first, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+
+	line := first
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		line = first[:i]
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		cr.Comma = ';'
+	}
+
+	return cr.ReadAll()
+}
+
+// ReadXLSX читает первый лист книги XLSX. Пропущенные ячейки возвращаются пустыми строками
+func ReadXLSX(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	sheets := make([]string, 0, 1)
+
+	for _, f := range zr.File {
+		files[f.Name] = f
+
+		if path.Dir(f.Name) == "xl/worksheets" && strings.HasSuffix(f.Name, ".xml") {
+			sheets = append(sheets, f.Name)
+		}
+	}
+
+	if len(sheets) == 0 {
+		return nil, ErrNoSheet
+	}
+
+	// Первый лист книги - sheet1.xml, остальные сортируются по номеру
+	sort.Slice(sheets, func(i, j int) bool {
+		return sheetNumber(sheets[i]) < sheetNumber(sheets[j])
+	})
+
+	var shared []string
+
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		shared, err = readSharedStrings(f)
+		if err != nil {
+			return nil, fmt.Errorf("чтение общих строк: %w", err)
+		}
+	}
+
+	return readSheet(files[sheets[0]], shared)
+}
+
+type richText struct {
+	Text []string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	var b strings.Builder
+
+	for _, text := range t.Text {
+		b.WriteString(text)
+	}
+
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+
+	return b.String()
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var sst struct {
+		Items []richText `xml:"si"`
+	}
+
+	err = xml.NewDecoder(io.LimitReader(rc, maxSheetSize)).Decode(&sst)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]string, 0, len(sst.Items))
+
+	for _, item := range sst.Items {
+		shared = append(shared, item.String())
+	}
+
+	return shared, nil
+}
+
+func readSheet(f *zip.File, shared []string) ([][]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+
+	err = xml.NewDecoder(io.LimitReader(rc, maxSheetSize)).Decode(&sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+
+	for _, row := range sheet.Rows {
+		values := make([]string, 0, len(row.Cells))
+
+		for _, cell := range row.Cells {
+			// Пустые ячейки в файле не хранятся, позицию восстанавливаем по ссылке вида B3
+			if col := columnIndex(cell.Ref); col > len(values) {
+				values = append(values, make([]string, col-len(values))...)
+			}
+
+			var value string
+
+			switch cell.Type {
+			case "s":
+				i, err := strconv.Atoi(cell.Value)
+				if err != nil || i < 0 || i >= len(shared) {
+					return nil, fmt.Errorf("некорректная ссылка на строку в ячейке %s", cell.Ref)
+				}
+
+				value = shared[i]
+			case "inlineStr":
+				value = cell.Inline.String()
+			default:
+				value = cell.Value
+			}
+
+			values = append(values, value)
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// columnIndex переводит буквы ссылки на ячейку в номер столбца с нуля. Без ссылки возвращает -1
+func columnIndex(ref string) int {
+	col := 0
+	letters := 0
+
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+
+		col = col*26 + int(r-'A'+1)
+		letters++
+	}
+
+	if letters == 0 {
+		return -1
+	}
+
+	return col - 1
+}
+
+func sheetNumber(name string) int {
+	base := strings.TrimSuffix(path.Base(name), ".xml")
+
+	n, err := strconv.Atoi(strings.TrimPrefix(base, "sheet"))
+	if err != nil {
+		return 1 << 30
+	}
+
+	return n
+}
@@ -0,0 +1,8 @@
+package sizeutils
+
+import "fmt"
+
+// Format переводит размер в байтах в мегабайты для сообщений пользователю
+func Format(size int64) string {
+	return fmt.Sprintf("%.1f МБ", float64(size)/(1<<20))
+}
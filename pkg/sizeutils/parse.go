@@ -0,0 +1,42 @@
+package sizeutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// units множители суффиксов размера, как в viper: KB, MB и GB - степени 1024
+var units = []struct {
+	suffix string
+	size   int64
+}{
+	{"gb", 1 << 30},
+	{"mb", 1 << 20},
+	{"kb", 1 << 10},
+	{"g", 1 << 30},
+	{"m", 1 << 20},
+	{"k", 1 << 10},
+	{"b", 1},
+}
+
+// Parse переводит размер вида "10MB", "512kb" или "1048576" в байты
+func Parse(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	multiplier := int64(1)
+
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("неверный размер %q, ожидается число байт или число с суффиксом KB, MB, GB", s)
+	}
+
+	return n * multiplier, nil
+}
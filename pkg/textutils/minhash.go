@@ -0,0 +1,201 @@
+package textutils
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Word слово текста: исходное написание для вывода и нормализованное для сравнения
+type Word struct {
+	Text string
+	Norm string
+}
+
+// Words разбивает текст на слова из букв и цифр
+func Words(text string) []Word {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]Word, 0, len(fields))
+
+	for _, field := range fields {
+		norm := strings.ReplaceAll(strings.ToLower(field), "ё", "е")
+		words = append(words, Word{Text: field, Norm: norm})
+	}
+
+	return words
+}
+
+// Shingles возвращает хэши всех последовательностей из k слов по позициям их начала
+func Shingles(words []Word, k int) []uint64 {
+	if len(words) < k {
+		return nil
+	}
+
+	shingles := make([]uint64, 0, len(words)-k+1)
+
+	for i := 0; i+k <= len(words); i++ {
+		h := fnv.New64a()
+
+		for _, word := range words[i : i+k] {
+			_, _ = h.Write([]byte(word.Norm))
+			_, _ = h.Write([]byte{0})
+		}
+
+		shingles = append(shingles, h.Sum64())
+	}
+
+	return shingles
+}
+
+// Signature MinHash подпись множества шинглов
+type Signature []uint64
+
+// MinHash считает подпись длины size. Совпадение подписей по позициям оценивает коэффициент Жаккара
+func MinHash(shingles []uint64, size int) Signature {
+	signature := make(Signature, size)
+
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+
+	for _, shingle := range shingles {
+		for i := range signature {
+			if h := mix(shingle ^ seed(i)); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+
+	return signature
+}
+
+// Similarity оценивает коэффициент Жаккара по двум подписям одинаковой длины
+func (s Signature) Similarity(other Signature) float64 {
+	if len(s) == 0 || len(s) != len(other) {
+		return 0
+	}
+
+	equal := 0
+
+	for i := range s {
+		if s[i] == other[i] && s[i] != ^uint64(0) {
+			equal++
+		}
+	}
+
+	return float64(equal) / float64(len(s))
+}
+
+// Jaccard точный коэффициент Жаккара двух множеств шинглов
+func Jaccard(a, b []uint64) float64 {
+	setA := toSet(a)
+	setB := toSet(b)
+
+	if len(setA) == 0 && len(setB) == 0 {
+		return 0
+	}
+
+	common := 0
+
+	for shingle := range setA {
+		if setB[shingle] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(setA)+len(setB)-common)
+}
+
+// CommonFragments возвращает совпадающие с другим текстом фрагменты первого текста,
+// от длинных к коротким, не больше limit штук и не длиннее maxLen символов каждый
+func CommonFragments(words []Word, shingles, other []uint64, k, limit, maxLen int) []string {
+	otherSet := toSet(other)
+	covered := make([]bool, len(words))
+
+	for i, shingle := range shingles {
+		if !otherSet[shingle] {
+			continue
+		}
+
+		for j := i; j < i+k && j < len(words); j++ {
+			covered[j] = true
+		}
+	}
+
+	type fragment struct {
+		start, end int
+	}
+
+	fragments := make([]fragment, 0)
+
+	for i := 0; i < len(covered); {
+		if !covered[i] {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(covered) && covered[i] {
+			i++
+		}
+
+		fragments = append(fragments, fragment{start: start, end: i})
+	}
+
+	sort.SliceStable(fragments, func(i, j int) bool {
+		return fragments[i].end-fragments[i].start > fragments[j].end-fragments[j].start
+	})
+
+	if len(fragments) > limit {
+		fragments = fragments[:limit]
+	}
+
+	result := make([]string, 0, len(fragments))
+
+	for _, f := range fragments {
+		parts := make([]string, 0, f.end-f.start)
+
+		for _, word := range words[f.start:f.end] {
+			parts = append(parts, word.Text)
+		}
+
+		text := strings.Join(parts, " ")
+
+		if runes := []rune(text); len(runes) > maxLen {
+			text = string(runes[:maxLen]) + "…"
+		}
+
+		result = append(result, text)
+	}
+
+	return result
+}
+
+func toSet(shingles []uint64) map[uint64]bool {
+	set := make(map[uint64]bool, len(shingles))
+
+	for _, shingle := range shingles {
+		set[shingle] = true
+	}
+
+	return set
+}
+
+// seed возвращает соль i-й хэш-функции подписи
+func seed(i int) uint64 {
+	return mix(uint64(i) + 0x9e3779b97f4a7c15)
+}
+
+// mix перемешивает биты по схеме splitmix64
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"practice_vgpek/pkg/apperr"
+)
+
+// instrumentation имя, под которым приложение создает спаны
+const instrumentation = "practice_vgpek"
+
+// Экспортеры спанов
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	// Exporter куда отправляются спаны: ExporterNone, ExporterStdout для локального запуска
+	// или ExporterOTLP для коллектора
+	Exporter string
+	// Endpoint адрес коллектора OTLP по HTTP в виде host:port. Если не задан, берется
+	// из OTEL_EXPORTER_OTLP_ENDPOINT или используется localhost:4318
+	Endpoint string
+	// Insecure отправлять спаны коллектору без TLS
+	Insecure bool
+	// SampleRatio доля записываемых трасс от 0 до 1, по умолчанию записываются все.
+	// Трассы, начатые вызывающей стороной, записываются по ее решению
+	SampleRatio float64
+	ServiceName string
+}
+
+// Shutdown отправляет накопленные спаны и останавливает экспортер
+type Shutdown func(ctx context.Context) error
+
+// New настраивает глобальный провайдер трассировки и распространение контекста трассы
+// через заголовки traceparent и baggage. Без экспортера спаны не создаются, но id трассы
+// из входящего запроса все равно попадает в журнал
+func New(ctx context.Context, cfg Config) (Shutdown, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("неизвестный экспортер трассировки %q", cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start начинает спан name, дочерний к спану из контекста
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, opts...)
+}
+
+// RecordError записывает ошибку err в спан из контекста. Статус ошибки спан получает только
+// для ошибок сервера: отсутствующая запись или неверный запрос - ожидаемый исход операции
+func RecordError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	span.RecordError(err)
+
+	if apperr.Status(err) >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}